@@ -45,8 +45,10 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollers "github.com/kedacore/keda/v2/controllers/keda"
 	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
+	"github.com/kedacore/keda/v2/pkg/metricscache"
 	kedaprovider "github.com/kedacore/keda/v2/pkg/provider"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	"github.com/kedacore/keda/v2/version"
 )
@@ -66,6 +68,11 @@ var (
 	prometheusMetricsPath     string
 	adapterClientRequestQPS   float32
 	adapterClientRequestBurst int
+	operatorMetricsAddress    string
+	operatorMetricsTokenFile  string
+	operatorMetricsCAFile     string
+	operatorNamespace         string
+	tracingConfig             tracing.Config
 )
 
 func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Duration, maxConcurrentReconciles int) (provider.MetricsProvider, <-chan struct{}, error) {
@@ -141,7 +148,16 @@ func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Durat
 		return nil, nil, err
 	}
 
-	return kedaprovider.NewProvider(ctx, logger, handler, mgr.GetClient(), namespace, externalMetricsInfo, externalMetricsInfoLock), stopCh, nil
+	var metricsCacheClient *metricscache.Client
+	if operatorMetricsAddress != "" {
+		metricsCacheClient, err = metricscache.NewClient(operatorMetricsAddress, operatorMetricsTokenFile, operatorMetricsCAFile, operatorNamespace, mgr.GetAPIReader(), globalHTTPTimeout)
+		if err != nil {
+			logger.Error(err, "failed to create client for cached metrics")
+			return nil, nil, fmt.Errorf("failed to create client for cached metrics (%s)", err)
+		}
+	}
+
+	return kedaprovider.NewProvider(ctx, logger, handler, mgr.GetClient(), namespace, externalMetricsInfo, externalMetricsInfoLock, metricsCacheClient), stopCh, nil
 }
 
 func runScaledObjectController(ctx context.Context, mgr manager.Manager, scaleHandler scaling.ScaleHandler, logger logr.Logger, externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex, maxConcurrentReconciles int, stopCh chan<- struct{}) error {
@@ -153,6 +169,21 @@ func runScaledObjectController(ctx context.Context, mgr manager.Manager, scaleHa
 	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}); err != nil {
 		return err
 	}
+	// the scalers served by KEDA Metrics Server are refreshed when their authentication changes, the same as in KEDA Operator
+	if err := (&kedacontrollers.TriggerAuthenticationReconciler{
+		Client:             mgr.GetClient(),
+		ScalersRefreshers:  []kedacontrollers.ScalersRefresher{scaleHandler},
+		RefreshScalersOnly: true,
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+	if err := (&kedacontrollers.ClusterTriggerAuthenticationReconciler{
+		Client:             mgr.GetClient(),
+		ScalersRefreshers:  []kedacontrollers.ScalersRefresher{scaleHandler},
+		RefreshScalersOnly: true,
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
 
 	go func() {
 		if err := mgr.Start(ctx); err != nil {
@@ -205,6 +236,13 @@ func main() {
 	cmd.Flags().StringVar(&prometheusMetricsPath, "metrics-path", "/metrics", "Set the path for the prometheus metrics endpoint")
 	cmd.Flags().Float32Var(&adapterClientRequestQPS, "kube-api-qps", 20.0, "Set the QPS rate for throttling requests sent to the apiserver")
 	cmd.Flags().IntVar(&adapterClientRequestBurst, "kube-api-burst", 30, "Set the burst for throttling requests sent to the apiserver")
+	cmd.Flags().StringVar(&operatorMetricsAddress, "operator-metrics-address", "", "Set the address of KEDA Operator metrics endpoint serving metrics of triggers with useCachedMetrics enabled, eg. https://keda-operator.keda.svc:9666")
+	cmd.Flags().StringVar(&operatorMetricsTokenFile, "operator-metrics-token-file", "/var/run/secrets/keda-operator/token", "Set the path of the service account token with audience keda-operator used to authenticate to KEDA Operator metrics endpoint")
+	cmd.Flags().StringVar(&operatorMetricsCAFile, "operator-metrics-ca-file", "/var/run/secrets/keda-operator-ca/ca.crt", "Set the path of the CA verifying the certificate of KEDA Operator metrics endpoint")
+	cmd.Flags().StringVar(&operatorNamespace, "operator-namespace", "keda", "Set the namespace of KEDA Operator, the requests to its metrics endpoint are sent to its leader")
+	cmd.Flags().StringVar(&tracingConfig.Endpoint, "otlp-endpoint", "", "Set the address of OTLP gRPC receiver the spans of scaler calls are exported to, eg. otel-collector.monitoring:4317, tracing is disabled if it is not set")
+	cmd.Flags().BoolVar(&tracingConfig.Insecure, "otlp-insecure", false, "Disable TLS for the connection to OTLP receiver")
+	cmd.Flags().Float64Var(&tracingConfig.SamplingRatio, "otlp-sampling-ratio", 1.0, "Set the ratio of traces that are sampled, between 0 and 1")
 	if err := cmd.Flags().Parse(os.Args); err != nil {
 		return
 	}
@@ -213,6 +251,17 @@ func main() {
 
 	ctrl.SetLogger(logger)
 
+	shutdownTracerProvider, err := tracing.InitTracerProvider(ctx, "keda-metrics-apiserver", tracingConfig)
+	if err != nil {
+		logger.Error(err, "unable to set up tracing")
+		return
+	}
+	defer func() {
+		if err := shutdownTracerProvider(context.Background()); err != nil {
+			logger.Error(err, "unable to flush spans")
+		}
+	}()
+
 	// default to 3 seconds if they don't pass the env var
 	globalHTTPTimeoutMS, err := kedautil.ResolveOsEnvInt("KEDA_HTTP_DEFAULT_TIMEOUT", 3000)
 	if err != nil {
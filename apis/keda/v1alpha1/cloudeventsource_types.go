@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=cloudeventsources,scope=Namespaced
+// +kubebuilder:printcolumn:name="Sink",type="string",JSONPath=".spec.destination.http.uri"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CloudEventSource subscribes to the events of KEDA resources in its namespace and emits them as CloudEvents
+type CloudEventSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudEventSourceSpec   `json:"spec"`
+	Status CloudEventSourceStatus `json:"status,omitempty"`
+}
+
+// CloudEventSourceSpec defines the sink of the CloudEvents and the events it subscribes to
+type CloudEventSourceSpec struct {
+	// ClusterName identifies the cluster in the source and subject of the emitted CloudEvents
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	Destination CloudEventDestination `json:"destination"`
+
+	// +optional
+	EventSubscription CloudEventSubscription `json:"eventSubscription,omitempty"`
+}
+
+// CloudEventDestination defines where the CloudEvents are sent
+type CloudEventDestination struct {
+	// +optional
+	HTTP *CloudEventHTTP `json:"http,omitempty"`
+}
+
+// CloudEventHTTP defines a sink receiving CloudEvents in the structured content mode over HTTP
+type CloudEventHTTP struct {
+	URI string `json:"uri"`
+}
+
+// CloudEventSubscription filters the types of emitted CloudEvents, all types are emitted if it is empty
+type CloudEventSubscription struct {
+	// +optional
+	IncludedEventTypes []CloudEventType `json:"includedEventTypes,omitempty"`
+	// +optional
+	ExcludedEventTypes []CloudEventType `json:"excludedEventTypes,omitempty"`
+}
+
+// CloudEventType is the type of CloudEvent emitted by KEDA
+// +kubebuilder:validation:Enum=keda.scaledobject.ready.v1;keda.scaledobject.failed.v1;keda.scaler.failed.v1;keda.scaledobject.fallback.entered.v1;keda.scaledobject.fallback.exited.v1;keda.scaledobject.scaledtozero.v1;keda.scaledobject.scaledfromzero.v1;keda.scaledjob.jobcreated.v1
+type CloudEventType string
+
+// Types of CloudEvents emitted by KEDA
+const (
+	// CloudEventScaledObjectReady is emitted when a ScaledObject is ready for scaling
+	CloudEventScaledObjectReady CloudEventType = "keda.scaledobject.ready.v1"
+	// CloudEventScaledObjectFailed is emitted when the validation of a ScaledObject fails
+	CloudEventScaledObjectFailed CloudEventType = "keda.scaledobject.failed.v1"
+	// CloudEventScalerFailed is emitted when a scaler of a ScaledObject or ScaledJob fails
+	CloudEventScalerFailed CloudEventType = "keda.scaler.failed.v1"
+	// CloudEventScaledObjectFallbackEntered is emitted when the scale target of a ScaledObject is scaled to the fallback replicas
+	CloudEventScaledObjectFallbackEntered CloudEventType = "keda.scaledobject.fallback.entered.v1"
+	// CloudEventScaledObjectFallbackExited is emitted when the scalers of a ScaledObject in fallback recover
+	CloudEventScaledObjectFallbackExited CloudEventType = "keda.scaledobject.fallback.exited.v1"
+	// CloudEventScaledObjectScaledToZero is emitted when the scale target of a ScaledObject is deactivated
+	CloudEventScaledObjectScaledToZero CloudEventType = "keda.scaledobject.scaledtozero.v1"
+	// CloudEventScaledObjectScaledFromZero is emitted when the scale target of a ScaledObject is activated
+	CloudEventScaledObjectScaledFromZero CloudEventType = "keda.scaledobject.scaledfromzero.v1"
+	// CloudEventScaledJobJobCreated is emitted when Jobs of a ScaledJob are created
+	CloudEventScaledJobJobCreated CloudEventType = "keda.scaledjob.jobcreated.v1"
+)
+
+// CloudEventSourceStatus defines the observed state of CloudEventSource
+// +optional
+type CloudEventSourceStatus struct {
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+}
+
+// CloudEventSourceList contains a list of CloudEventSource
+// +kubebuilder:object:root=true
+type CloudEventSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudEventSource `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CloudEventSource{}, &CloudEventSourceList{})
+}
+
+// IsSubscribed returns whether the CloudEventSource subscribes to the type of CloudEvent
+func (s *CloudEventSourceSpec) IsSubscribed(eventType CloudEventType) bool {
+	for _, excluded := range s.EventSubscription.ExcludedEventTypes {
+		if excluded == eventType {
+			return false
+		}
+	}
+	if len(s.EventSubscription.IncludedEventTypes) == 0 {
+		return true
+	}
+	for _, included := range s.EventSubscription.IncludedEventTypes {
+		if included == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -32,6 +32,10 @@ const (
 	ConditionActive ConditionType = "Active"
 	// ConditionFallback specifies that the resource has a fallback active.
 	ConditionFallback ConditionType = "Fallback"
+	// ConditionPaused specifies that the scaling of the resource is paused.
+	ConditionPaused ConditionType = "Paused"
+	// ConditionDegraded specifies that the Jobs of the resource keep failing and no Job is created.
+	ConditionDegraded ConditionType = "Degraded"
 )
 
 const (
@@ -41,6 +45,15 @@ const (
 	ScaledObjectConditionReadySuccessMessage = "ScaledObject is defined correctly and is ready for scaling"
 )
 
+const (
+	// TriggerAuthenticationConditionReadySuccessReason defines the Reason for a TriggerAuthentication with all parameters resolved
+	TriggerAuthenticationConditionReadySuccessReason = "TriggerAuthenticationReady"
+	// TriggerAuthenticationConditionReadySuccessMessage defines the Message for a TriggerAuthentication with all parameters resolved
+	TriggerAuthenticationConditionReadySuccessMessage = "All parameters of the TriggerAuthentication are resolved"
+	// TriggerAuthenticationConditionReadyFailedReason defines the Reason for a TriggerAuthentication with parameters which can't be resolved
+	TriggerAuthenticationConditionReadyFailedReason = "TriggerAuthenticationResolveFailed"
+)
+
 // Condition to store the condition state
 type Condition struct {
 	// Type of condition
@@ -147,6 +160,20 @@ func (c *Conditions) SetFallbackCondition(status metav1.ConditionStatus, reason
 	c.setCondition(ConditionFallback, status, reason, message)
 }
 
+// SetPausedCondition modifies Paused Condition according to input parameters,
+// the condition is added when it's first set
+func (c *Conditions) SetPausedCondition(status metav1.ConditionStatus, reason string, message string) {
+	c.addCondition(ConditionPaused)
+	c.setCondition(ConditionPaused, status, reason, message)
+}
+
+// SetDegradedCondition modifies Degraded Condition according to input parameters,
+// the condition is added when it's first set as it's only reported by the ScaledJobs with a failurePolicy
+func (c *Conditions) SetDegradedCondition(status metav1.ConditionStatus, reason string, message string) {
+	c.addCondition(ConditionDegraded)
+	c.setCondition(ConditionDegraded, status, reason, message)
+}
+
 // GetActiveCondition returns Condition of type Active
 func (c *Conditions) GetActiveCondition() Condition {
 	if *c == nil {
@@ -171,6 +198,24 @@ func (c *Conditions) GetFallbackCondition() Condition {
 	return c.getCondition(ConditionFallback)
 }
 
+// GetPausedCondition returns Condition of type Paused, it has no status until it's set
+func (c *Conditions) GetPausedCondition() Condition {
+	return c.getCondition(ConditionPaused)
+}
+
+// GetDegradedCondition returns Condition of type Degraded, it has no status until it's set
+func (c *Conditions) GetDegradedCondition() Condition {
+	return c.getCondition(ConditionDegraded)
+}
+
+// addCondition adds the condition if it isn't in the conditions. The conditions which aren't initialized
+// are added when they are first set, so that adding them doesn't reset the conditions of the existing objects
+func (c *Conditions) addCondition(conditionType ConditionType) {
+	if c.getCondition(conditionType).Type == "" {
+		*c = append(*c, Condition{Type: conditionType, Status: metav1.ConditionUnknown})
+	}
+}
+
 func (c Conditions) getCondition(conditionType ConditionType) Condition {
 	for i := range c {
 		if c[i].Type == conditionType {
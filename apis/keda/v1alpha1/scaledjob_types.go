@@ -30,7 +30,13 @@ import (
 // +kubebuilder:printcolumn:name="Authentication",type="string",JSONPath=".spec.triggers[*].authenticationRef.name"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
 // +kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.conditions[?(@.type==\"Active\")].status"
+// +kubebuilder:printcolumn:name="Running",type="integer",JSONPath=".status.runningJobs"
+// +kubebuilder:printcolumn:name="Pending",type="integer",JSONPath=".status.pendingJobs"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Queue",type="integer",JSONPath=".status.lastScalingDecision.queueLength",priority=1
+// +kubebuilder:printcolumn:name="Effective Max",type="integer",JSONPath=".status.lastScalingDecision.effectiveMaxScale",priority=1
+// +kubebuilder:printcolumn:name="Last Created",type="integer",JSONPath=".status.lastJobsCreation.createdJobs",priority=1
+// +kubebuilder:printcolumn:name="Last Creation",type="date",JSONPath=".status.lastJobsCreation.time",priority=1
 
 // ScaledJob is the Schema for the scaledjobs API
 type ScaledJob struct {
@@ -62,9 +68,26 @@ type ScaledJobSpec struct {
 	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
 	// +optional
 	ScalingStrategy ScalingStrategy `json:"scalingStrategy,omitempty"`
-	Triggers        []ScaleTriggers `json:"triggers"`
+	// FailurePolicy backs off the creation of Jobs after consecutive failed Jobs, and stops creating Jobs
+	// after failureThreshold consecutive failed Jobs. Jobs are created regardless of their failures when unset.
+	// +optional
+	FailurePolicy *FailurePolicy `json:"failurePolicy,omitempty"`
+	// ScalingMode is either jobs, creating new Jobs for the pending work, or parallelism, scaling the parallelism
+	// of a single long-lived Job between the min and max replica counts, which is suspended when the triggers
+	// aren't active and the min replica count is zero. Defaults to jobs.
+	// +optional
+	// +kubebuilder:validation:Enum=jobs;parallelism
+	ScalingMode string          `json:"scalingMode,omitempty"`
+	Triggers    []ScaleTriggers `json:"triggers"`
 }
 
+const (
+	// ScaledJobScalingModeJobs creates new Jobs for the pending work
+	ScaledJobScalingModeJobs = "jobs"
+	// ScaledJobScalingModeParallelism scales the parallelism of a single long-lived Job
+	ScaledJobScalingModeParallelism = "parallelism"
+)
+
 // ScaledJobStatus defines the observed state of ScaledJob
 // +optional
 type ScaledJobStatus struct {
@@ -72,6 +95,51 @@ type ScaledJobStatus struct {
 	LastActiveTime *metav1.Time `json:"lastActiveTime,omitempty"`
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
+	// +optional
+	RunningJobs *int64 `json:"runningJobs,omitempty"`
+	// +optional
+	PendingJobs *int64 `json:"pendingJobs,omitempty"`
+	// +optional
+	LastScalingDecision *ScaledJobScalingDecision `json:"lastScalingDecision,omitempty"`
+	// +optional
+	LastJobsCreation *ScaledJobJobsCreation `json:"lastJobsCreation,omitempty"`
+	// +optional
+	JobFailures *ScaledJobFailures `json:"jobFailures,omitempty"`
+	// ObservedGeneration is the generation of the ScaledJob the Jobs were last rolled out for,
+	// the Jobs are only rolled out again once the generation differs from it
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ScaledJobScalingDecision reports the values the last scaling decision of a ScaledJob was computed from
+type ScaledJobScalingDecision struct {
+	// QueueLength is the queue length reported by the triggers
+	QueueLength int64 `json:"queueLength"`
+	// MaxScale is the queue length capped by the max replica count
+	MaxScale int64 `json:"maxScale"`
+	// EffectiveMaxScale is the number of Jobs the scaling strategy allows to create
+	EffectiveMaxScale int64 `json:"effectiveMaxScale"`
+}
+
+// ScaledJobJobsCreation reports the last batch of Jobs created for a ScaledJob
+type ScaledJobJobsCreation struct {
+	Time          metav1.Time `json:"time"`
+	RequestedJobs int64       `json:"requestedJobs"`
+	CreatedJobs   int64       `json:"createdJobs"`
+}
+
+// ScaledJobFailures reports the streak of consecutive failed Jobs of a ScaledJob with a failurePolicy,
+// it's kept in the status because the finished Jobs are cleaned up according to the history limits
+type ScaledJobFailures struct {
+	// Consecutive is the number of consecutive failed Jobs since the last succeeded Job
+	Consecutive int64 `json:"consecutive"`
+	// LastFailureTime is the time the last of the consecutive failed Jobs finished
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// AccountedJobs are the names of the finished Jobs already accounted, so they aren't accounted again.
+	// Only the Jobs kept according to the history limits are listed
+	// +optional
+	AccountedJobs []string `json:"accountedJobs,omitempty"`
 }
 
 // ScaledJobList contains a list of ScaledJob
@@ -97,6 +165,29 @@ type ScalingStrategy struct {
 	MultipleScalersCalculation string `json:"multipleScalersCalculation,omitempty"`
 }
 
+// FailurePolicy defines how the creation of Jobs backs off when the Jobs of a ScaledJob keep failing
+// +optional
+type FailurePolicy struct {
+	// BackoffSeconds is the delay before creating Jobs after a failed Job, doubled for each consecutive failed Job. Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	BackoffSeconds *int32 `json:"backoffSeconds,omitempty"`
+	// MaxBackoffSeconds caps the delay before creating Jobs after consecutive failed Jobs. Defaults to 300.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxBackoffSeconds *int32 `json:"maxBackoffSeconds,omitempty"`
+	// FailureThreshold is the number of consecutive failed Jobs after which the circuit opens: no Job is created
+	// and the ScaledJob is Degraded. Defaults to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+	// CircuitResetSeconds is the delay after the last failed Job after which a single Job is created
+	// to probe whether the Jobs recovered while the circuit is open. Defaults to 600.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	CircuitResetSeconds *int32 `json:"circuitResetSeconds,omitempty"`
+}
+
 // Rollout defines the strategy for job rollouts
 // +optional
 type Rollout struct {
@@ -104,8 +195,25 @@ type Rollout struct {
 	Strategy string `json:"strategy,omitempty"`
 	// +optional
 	PropagationPolicy string `json:"propagationPolicy,omitempty"`
+	// DrainDeadlineSeconds is the duration after a rollout with the drain strategy after which the Jobs
+	// of the previous version don't count toward maxReplicaCount anymore
+	// +optional
+	DrainDeadlineSeconds *int64 `json:"drainDeadlineSeconds,omitempty"`
+	// ActiveDeadlineSeconds is the duration after a rollout with the drain strategy after which the Jobs
+	// of the previous version are terminated
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
 }
 
+const (
+	// ScaledJobGenerationLabel is the generation of the ScaledJob which created the Job
+	ScaledJobGenerationLabel = "scaledjob.keda.sh/generation"
+	// ScaledJobDrainingLabel marks the Jobs of a previous version of the ScaledJob drained by the drain rollout strategy
+	ScaledJobDrainingLabel = "scaledjob.keda.sh/draining"
+	// ScaledJobDrainStartAnnotation is the time of the rollout after which the Job is drained
+	ScaledJobDrainStartAnnotation = "scaledjob.keda.sh/drain-start"
+)
+
 func init() {
 	SchemeBuilder.Register(&ScaledJob{}, &ScaledJobList{})
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -34,6 +36,7 @@ import (
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
 // +kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.conditions[?(@.type==\"Active\")].status"
 // +kubebuilder:printcolumn:name="Fallback",type="string",JSONPath=".status.conditions[?(@.type==\"Fallback\")].status"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=".status.conditions[?(@.type==\"Paused\")].status"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ScaledObject is a specification for a ScaledObject resource
@@ -46,6 +49,14 @@ type ScaledObject struct {
 	Status ScaledObjectStatus `json:"status,omitempty"`
 }
 
+const (
+	defaultHPAMinReplicas int32 = 1
+	defaultHPAMaxReplicas int32 = 100
+)
+
+// CompositeMetricName is the name of the external metric exposed for ScaledObjects using ScalingModifiers
+const CompositeMetricName = "composite-metric"
+
 // HealthStatus is the status for a ScaledObject's health
 type HealthStatus struct {
 	// +optional
@@ -98,6 +109,19 @@ type AdvancedConfig struct {
 	HorizontalPodAutoscalerConfig *HorizontalPodAutoscalerConfig `json:"horizontalPodAutoscalerConfig,omitempty"`
 	// +optional
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
+	// +optional
+	ScalingModifiers *ScalingModifiers `json:"scalingModifiers,omitempty"`
+}
+
+// ScalingModifiers describes a formula which combines the metrics of named triggers
+// into a single composite metric used by the HPA
+type ScalingModifiers struct {
+	Formula string `json:"formula"`
+	Target  string `json:"target"`
+	// +optional
+	ActivationTarget string `json:"activationTarget,omitempty"`
+	// +optional
+	MetricType autoscalingv2beta2.MetricTargetType `json:"metricType,omitempty"`
 }
 
 // HorizontalPodAutoscalerConfig specifies horizontal scale config
@@ -129,6 +153,10 @@ type ScaleTriggers struct {
 	AuthenticationRef *ScaledObjectAuthRef `json:"authenticationRef,omitempty"`
 	// +optional
 	MetricType autoscalingv2beta2.MetricTargetType `json:"metricType,omitempty"`
+	// UseCachedMetrics serves the metrics of the trigger to the HPA from the values KEDA Operator collects
+	// on each polling interval, it's only supported by ScaledObjects
+	// +optional
+	UseCachedMetrics bool `json:"useCachedMetrics,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -174,8 +202,55 @@ type ScaledObjectAuthRef struct {
 	// Kind of the resource being referred to. Defaults to TriggerAuthentication.
 	// +optional
 	Kind string `json:"kind,omitempty"`
+	// Namespace of the TriggerAuthentication, defaults to the namespace of the ScaledObject or ScaledJob.
+	// A TriggerAuthenticationGrant in the namespace has to allow the reference from another namespace.
+	// It can't be set on a reference to a ClusterTriggerAuthentication.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 func init() {
 	SchemeBuilder.Register(&ScaledObject{}, &ScaledObjectList{})
 }
+
+// IsUsingModifiers returns true if the ScaledObject combines its triggers through ScalingModifiers
+func (so *ScaledObject) IsUsingModifiers() bool {
+	return so.Spec.Advanced != nil && so.Spec.Advanced.ScalingModifiers != nil
+}
+
+// GetHPAMinReplicas returns MinReplicas based on definition in ScaledObject or default value if not defined
+func (so *ScaledObject) GetHPAMinReplicas() *int32 {
+	if so.Spec.MinReplicaCount != nil && *so.Spec.MinReplicaCount > 0 {
+		return so.Spec.MinReplicaCount
+	}
+	tmp := defaultHPAMinReplicas
+	return &tmp
+}
+
+// GetHPAMaxReplicas returns MaxReplicas based on definition in ScaledObject or default value if not defined
+func (so *ScaledObject) GetHPAMaxReplicas() int32 {
+	if so.Spec.MaxReplicaCount != nil {
+		return *so.Spec.MaxReplicaCount
+	}
+	return defaultHPAMaxReplicas
+}
+
+// CheckReplicaCountBoundsAreValid checks that Idle/Min/Max ReplicaCount defined in ScaledObject are correctly specified
+// ie. that Min is not greater then Max or Idle greater or equal to Min
+func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
+	min := int32(0)
+	if scaledObject.Spec.MinReplicaCount != nil {
+		min = *scaledObject.GetHPAMinReplicas()
+	}
+	max := scaledObject.GetHPAMaxReplicas()
+
+	if min > max {
+		return fmt.Errorf("MinReplicaCount=%d must be less than MaxReplicaCount=%d", min, max)
+	}
+
+	if scaledObject.Spec.IdleReplicaCount != nil && *scaledObject.Spec.IdleReplicaCount >= min {
+		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *scaledObject.Spec.IdleReplicaCount, min)
+	}
+
+	return nil
+}
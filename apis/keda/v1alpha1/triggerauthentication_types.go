@@ -26,15 +26,18 @@ import (
 // +genclient
 // +genclient:nonNamespaced
 // +kubebuilder:resource:path=clustertriggerauthentications,scope=Cluster,shortName=cta;clustertriggerauth
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="PodIdentity",type="string",JSONPath=".spec.podIdentity.provider"
 // +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretTargetRef[*].name"
 // +kubebuilder:printcolumn:name="Env",type="string",JSONPath=".spec.env[*].name"
 // +kubebuilder:printcolumn:name="VaultAddress",type="string",JSONPath=".spec.hashiCorpVault.address"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
 type ClusterTriggerAuthentication struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec TriggerAuthenticationSpec `json:"spec"`
+	Spec   TriggerAuthenticationSpec   `json:"spec"`
+	Status TriggerAuthenticationStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -51,15 +54,18 @@ type ClusterTriggerAuthenticationList struct {
 // TriggerAuthentication defines how a trigger can authenticate
 // +genclient
 // +kubebuilder:resource:path=triggerauthentications,scope=Namespaced,shortName=ta;triggerauth
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="PodIdentity",type="string",JSONPath=".spec.podIdentity.provider"
 // +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretTargetRef[*].name"
 // +kubebuilder:printcolumn:name="Env",type="string",JSONPath=".spec.env[*].name"
 // +kubebuilder:printcolumn:name="VaultAddress",type="string",JSONPath=".spec.hashiCorpVault.address"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
 type TriggerAuthentication struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec TriggerAuthenticationSpec `json:"spec"`
+	Spec   TriggerAuthenticationSpec   `json:"spec"`
+	Status TriggerAuthenticationStatus `json:"status,omitempty"`
 }
 
 // TriggerAuthenticationSpec defines the various ways to authenticate
@@ -78,6 +84,46 @@ type TriggerAuthenticationSpec struct {
 
 	// +optional
 	AzureKeyVault *AzureKeyVault `json:"azureKeyVault,omitempty"`
+
+	// +optional
+	AwsSecretManager *AwsSecretManager `json:"awsSecretManager,omitempty"`
+
+	// +optional
+	GcpSecretManager *GcpSecretManager `json:"gcpSecretManager,omitempty"`
+}
+
+// TriggerAuthenticationStatus defines the observed state of TriggerAuthentication and ClusterTriggerAuthentication
+// +optional
+type TriggerAuthenticationStatus struct {
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+
+	// Parameters reports whether the references of each parameter resolve, the parameters resolved from the environment
+	// of the scale target are resolved for each ScaledObject or ScaledJob and aren't reported. The secret stores aren't read,
+	// their parameters are only reported when the Secrets holding the credentials of the secret store don't resolve
+	// +optional
+	Parameters []TriggerAuthenticationParameterStatus `json:"parameters,omitempty"`
+
+	// +optional
+	LastResolutionError string `json:"lastResolutionError,omitempty"`
+
+	// ScaledObjects lists the ScaledObjects referencing the TriggerAuthentication as namespace/name
+	// +optional
+	ScaledObjects []string `json:"scaledObjects,omitempty"`
+
+	// ScaledJobs lists the ScaledJobs referencing the TriggerAuthentication as namespace/name
+	// +optional
+	ScaledJobs []string `json:"scaledJobs,omitempty"`
+}
+
+// TriggerAuthenticationParameterStatus reports whether an authentication parameter resolved
+type TriggerAuthenticationParameterStatus struct {
+	Parameter string `json:"parameter"`
+	// Source of the parameter, eg. secretTargetRef or hashiCorpVault
+	Source   string `json:"source"`
+	Resolved bool   `json:"resolved"`
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -162,6 +208,20 @@ type Credential struct {
 
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// +optional
+	RoleID string `json:"roleId,omitempty"`
+
+	// +optional
+	SecretID *VaultCredentialValue `json:"secretId,omitempty"`
+
+	// +optional
+	JWT *VaultCredentialValue `json:"jwt,omitempty"`
+}
+
+// VaultCredentialValue defines the Kubernetes secret holding a Hashicorp Vault credential
+type VaultCredentialValue struct {
+	ValueFrom ValueFromSecret `json:"valueFrom"`
 }
 
 // VaultAuthentication contains the list of Hashicorp Vault authentication methods
@@ -171,6 +231,9 @@ type VaultAuthentication string
 const (
 	VaultAuthenticationToken      VaultAuthentication = "token"
 	VaultAuthenticationKubernetes VaultAuthentication = "kubernetes"
+	VaultAuthenticationAppRole    VaultAuthentication = "approle"
+	// VaultAuthenticationJWT logs in with the JWT/OIDC auth method using a JWT
+	VaultAuthenticationJWT VaultAuthentication = "jwt"
 	// VaultAuthenticationAWS                            = "aws"
 )
 
@@ -179,6 +242,47 @@ type VaultSecret struct {
 	Parameter string `json:"parameter"`
 	Path      string `json:"path"`
 	Key       string `json:"key"`
+
+	// +optional
+	Type VaultSecretType `json:"type,omitempty"`
+
+	// +optional
+	PkiData *VaultPkiData `json:"pkiData,omitempty"`
+}
+
+// VaultSecretType defines how a secret is requested from Hashicorp Vault and where its key is found
+type VaultSecretType string
+
+const (
+	// VaultSecretTypeSecretV2 reads a secret of the KV version 2 engine, the key is looked up in its data
+	VaultSecretTypeSecretV2 VaultSecretType = "secretV2"
+	// VaultSecretTypeSecret reads a secret of the KV version 1 engine or the credentials of a dynamic secret engine, eg. database/creds/<role>
+	VaultSecretTypeSecret VaultSecretType = "secret"
+	// VaultSecretTypePki issues a certificate with the PKI engine, eg. pki/issue/<role>
+	VaultSecretTypePki VaultSecretType = "pki"
+)
+
+// VaultPkiData defines the data of the request issuing a certificate with the Hashicorp Vault PKI engine
+type VaultPkiData struct {
+	CommonName string `json:"commonName"`
+
+	// +optional
+	AltNames string `json:"altNames,omitempty"`
+
+	// +optional
+	IPSans string `json:"ipSans,omitempty"`
+
+	// +optional
+	URISans string `json:"uriSans,omitempty"`
+
+	// +optional
+	OtherSans string `json:"otherSans,omitempty"`
+
+	// +optional
+	TTL string `json:"ttl,omitempty"`
+
+	// +optional
+	Format string `json:"format,omitempty"`
 }
 
 // AzureKeyVault is used to authenticate using Azure Key Vault
@@ -225,6 +329,62 @@ type AzureKeyVaultCloudInfo struct {
 	ActiveDirectoryEndpoint string `json:"activeDirectoryEndpoint"`
 }
 
+// AwsSecretManager is used to authenticate using AWS Secrets Manager
+type AwsSecretManager struct {
+	Region  string                   `json:"region"`
+	Secrets []AwsSecretManagerSecret `json:"secrets"`
+	// +optional
+	Credentials *AwsSecretManagerCredentials `json:"credentials,omitempty"`
+}
+
+type AwsSecretManagerCredentials struct {
+	AccessKey       *AwsSecretManagerValue `json:"accessKey"`
+	AccessSecretKey *AwsSecretManagerValue `json:"accessSecretKey"`
+	// +optional
+	AccessToken *AwsSecretManagerValue `json:"accessToken,omitempty"`
+}
+
+type AwsSecretManagerValue struct {
+	ValueFrom ValueFromSecret `json:"valueFrom"`
+}
+
+type AwsSecretManagerSecret struct {
+	Parameter string `json:"parameter"`
+	Name      string `json:"name"`
+	// +optional
+	VersionID string `json:"versionId,omitempty"`
+	// +optional
+	VersionStage string `json:"versionStage,omitempty"`
+	// Key of the value in the secret string, if the secret string is a JSON object
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// GcpSecretManager is used to authenticate using GCP Secret Manager
+type GcpSecretManager struct {
+	Secrets []GcpSecretManagerSecret `json:"secrets"`
+	// ProjectID of the secrets, defaults to the project of the service account or of the GKE cluster
+	// +optional
+	ProjectID string `json:"projectId,omitempty"`
+	// +optional
+	Credentials *GcpCredentials `json:"credentials,omitempty"`
+}
+
+type GcpCredentials struct {
+	ClientSecret GcpSecretManagerValue `json:"clientSecret"`
+}
+
+type GcpSecretManagerValue struct {
+	ValueFrom ValueFromSecret `json:"valueFrom"`
+}
+
+type GcpSecretManagerSecret struct {
+	Parameter string `json:"parameter"`
+	ID        string `json:"id"`
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&ClusterTriggerAuthentication{}, &ClusterTriggerAuthenticationList{})
 	SchemeBuilder.Register(&TriggerAuthentication{}, &TriggerAuthenticationList{})
@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=triggerauthenticationgrants,scope=Namespaced,shortName=tagrant
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TriggerAuthenticationGrant allows ScaledObjects and ScaledJobs in other namespaces to reference TriggerAuthentications in its namespace
+type TriggerAuthenticationGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TriggerAuthenticationGrantSpec `json:"spec"`
+}
+
+// TriggerAuthenticationGrantSpec defines the namespaces which are granted to reference the TriggerAuthentications
+type TriggerAuthenticationGrantSpec struct {
+	// From lists the namespaces of the ScaledObjects and ScaledJobs which can reference the TriggerAuthentications
+	// +kubebuilder:validation:MinItems=1
+	From []TriggerAuthenticationGrantFrom `json:"from"`
+
+	// To lists the TriggerAuthentications which can be referenced, all TriggerAuthentications in the namespace of the grant if empty
+	// +optional
+	To []TriggerAuthenticationGrantTo `json:"to,omitempty"`
+}
+
+// TriggerAuthenticationGrantFrom defines a namespace granted to reference the TriggerAuthentications
+type TriggerAuthenticationGrantFrom struct {
+	Namespace string `json:"namespace"`
+}
+
+// TriggerAuthenticationGrantTo defines a TriggerAuthentication which can be referenced from the granted namespaces
+type TriggerAuthenticationGrantTo struct {
+	Name string `json:"name"`
+}
+
+// TriggerAuthenticationGrantList contains a list of TriggerAuthenticationGrant
+// +kubebuilder:object:root=true
+type TriggerAuthenticationGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TriggerAuthenticationGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TriggerAuthenticationGrant{}, &TriggerAuthenticationGrantList{})
+}
+
+// Allows returns whether the grant allows the namespace to reference the TriggerAuthentication
+func (s *TriggerAuthenticationGrantSpec) Allows(namespace, triggerAuthenticationName string) bool {
+	granted := false
+	for _, from := range s.From {
+		if from.Namespace == namespace {
+			granted = true
+			break
+		}
+	}
+	if !granted {
+		return false
+	}
+	if len(s.To) == 0 {
+		return true
+	}
+	for _, to := range s.To {
+		if to.Name == triggerAuthenticationName {
+			return true
+		}
+	}
+	return false
+}
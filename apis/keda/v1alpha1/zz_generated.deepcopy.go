@@ -35,6 +35,11 @@ func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 		*out = new(HorizontalPodAutoscalerConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ScalingModifiers != nil {
+		in, out := &in.ScalingModifiers, &out.ScalingModifiers
+		*out = new(ScalingModifiers)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedConfig.
@@ -92,6 +97,92 @@ func (in *AuthSecretTargetRef) DeepCopy() *AuthSecretTargetRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsSecretManager) DeepCopyInto(out *AwsSecretManager) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]AwsSecretManagerSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(AwsSecretManagerCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsSecretManager.
+func (in *AwsSecretManager) DeepCopy() *AwsSecretManager {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsSecretManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsSecretManagerCredentials) DeepCopyInto(out *AwsSecretManagerCredentials) {
+	*out = *in
+	if in.AccessKey != nil {
+		in, out := &in.AccessKey, &out.AccessKey
+		*out = new(AwsSecretManagerValue)
+		**out = **in
+	}
+	if in.AccessSecretKey != nil {
+		in, out := &in.AccessSecretKey, &out.AccessSecretKey
+		*out = new(AwsSecretManagerValue)
+		**out = **in
+	}
+	if in.AccessToken != nil {
+		in, out := &in.AccessToken, &out.AccessToken
+		*out = new(AwsSecretManagerValue)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsSecretManagerCredentials.
+func (in *AwsSecretManagerCredentials) DeepCopy() *AwsSecretManagerCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsSecretManagerCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsSecretManagerSecret) DeepCopyInto(out *AwsSecretManagerSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsSecretManagerSecret.
+func (in *AwsSecretManagerSecret) DeepCopy() *AwsSecretManagerSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsSecretManagerSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsSecretManagerValue) DeepCopyInto(out *AwsSecretManagerValue) {
+	*out = *in
+	out.ValueFrom = in.ValueFrom
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsSecretManagerValue.
+func (in *AwsSecretManagerValue) DeepCopy() *AwsSecretManagerValue {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsSecretManagerValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureKeyVault) DeepCopyInto(out *AzureKeyVault) {
 	*out = *in
@@ -188,12 +279,169 @@ func (in *AzureKeyVaultSecret) DeepCopy() *AzureKeyVaultSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventDestination) DeepCopyInto(out *CloudEventDestination) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(CloudEventHTTP)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventDestination.
+func (in *CloudEventDestination) DeepCopy() *CloudEventDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventHTTP) DeepCopyInto(out *CloudEventHTTP) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventHTTP.
+func (in *CloudEventHTTP) DeepCopy() *CloudEventHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventSource) DeepCopyInto(out *CloudEventSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSource.
+func (in *CloudEventSource) DeepCopy() *CloudEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudEventSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventSourceList) DeepCopyInto(out *CloudEventSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudEventSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSourceList.
+func (in *CloudEventSourceList) DeepCopy() *CloudEventSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudEventSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventSourceSpec) DeepCopyInto(out *CloudEventSourceSpec) {
+	*out = *in
+	in.Destination.DeepCopyInto(&out.Destination)
+	in.EventSubscription.DeepCopyInto(&out.EventSubscription)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSourceSpec.
+func (in *CloudEventSourceSpec) DeepCopy() *CloudEventSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventSourceStatus) DeepCopyInto(out *CloudEventSourceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(Conditions, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSourceStatus.
+func (in *CloudEventSourceStatus) DeepCopy() *CloudEventSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventSubscription) DeepCopyInto(out *CloudEventSubscription) {
+	*out = *in
+	if in.IncludedEventTypes != nil {
+		in, out := &in.IncludedEventTypes, &out.IncludedEventTypes
+		*out = make([]CloudEventType, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedEventTypes != nil {
+		in, out := &in.ExcludedEventTypes, &out.ExcludedEventTypes
+		*out = make([]CloudEventType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSubscription.
+func (in *CloudEventSubscription) DeepCopy() *CloudEventSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterTriggerAuthentication) DeepCopyInto(out *ClusterTriggerAuthentication) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTriggerAuthentication.
@@ -283,6 +531,16 @@ func (in Conditions) DeepCopy() Conditions {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Credential) DeepCopyInto(out *Credential) {
 	*out = *in
+	if in.SecretID != nil {
+		in, out := &in.SecretID, &out.SecretID
+		*out = new(VaultCredentialValue)
+		**out = **in
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(VaultCredentialValue)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
@@ -295,6 +553,41 @@ func (in *Credential) DeepCopy() *Credential {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailurePolicy) DeepCopyInto(out *FailurePolicy) {
+	*out = *in
+	if in.BackoffSeconds != nil {
+		in, out := &in.BackoffSeconds, &out.BackoffSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBackoffSeconds != nil {
+		in, out := &in.MaxBackoffSeconds, &out.MaxBackoffSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CircuitResetSeconds != nil {
+		in, out := &in.CircuitResetSeconds, &out.CircuitResetSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailurePolicy.
+func (in *FailurePolicy) DeepCopy() *FailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fallback) DeepCopyInto(out *Fallback) {
 	*out = *in
@@ -310,6 +603,78 @@ func (in *Fallback) DeepCopy() *Fallback {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcpCredentials) DeepCopyInto(out *GcpCredentials) {
+	*out = *in
+	out.ClientSecret = in.ClientSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpCredentials.
+func (in *GcpCredentials) DeepCopy() *GcpCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(GcpCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcpSecretManager) DeepCopyInto(out *GcpSecretManager) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]GcpSecretManagerSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(GcpCredentials)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpSecretManager.
+func (in *GcpSecretManager) DeepCopy() *GcpSecretManager {
+	if in == nil {
+		return nil
+	}
+	out := new(GcpSecretManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcpSecretManagerSecret) DeepCopyInto(out *GcpSecretManagerSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpSecretManagerSecret.
+func (in *GcpSecretManagerSecret) DeepCopy() *GcpSecretManagerSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(GcpSecretManagerSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcpSecretManagerValue) DeepCopyInto(out *GcpSecretManagerValue) {
+	*out = *in
+	out.ValueFrom = in.ValueFrom
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpSecretManagerValue.
+func (in *GcpSecretManagerValue) DeepCopy() *GcpSecretManagerValue {
+	if in == nil {
+		return nil
+	}
+	out := new(GcpSecretManagerValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupVersionKindResource) DeepCopyInto(out *GroupVersionKindResource) {
 	*out = *in
@@ -331,12 +696,14 @@ func (in *HashiCorpVault) DeepCopyInto(out *HashiCorpVault) {
 	if in.Secrets != nil {
 		in, out := &in.Secrets, &out.Secrets
 		*out = make([]VaultSecret, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Credential != nil {
 		in, out := &in.Credential, &out.Credential
 		*out = new(Credential)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -393,6 +760,16 @@ func (in *HorizontalPodAutoscalerConfig) DeepCopy() *HorizontalPodAutoscalerConf
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rollout) DeepCopyInto(out *Rollout) {
 	*out = *in
+	if in.DrainDeadlineSeconds != nil {
+		in, out := &in.DrainDeadlineSeconds, &out.DrainDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollout.
@@ -474,6 +851,46 @@ func (in *ScaledJob) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledJobFailures) DeepCopyInto(out *ScaledJobFailures) {
+	*out = *in
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AccountedJobs != nil {
+		in, out := &in.AccountedJobs, &out.AccountedJobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledJobFailures.
+func (in *ScaledJobFailures) DeepCopy() *ScaledJobFailures {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledJobFailures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledJobJobsCreation) DeepCopyInto(out *ScaledJobJobsCreation) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledJobJobsCreation.
+func (in *ScaledJobJobsCreation) DeepCopy() *ScaledJobJobsCreation {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledJobJobsCreation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaledJobList) DeepCopyInto(out *ScaledJobList) {
 	*out = *in
@@ -506,6 +923,21 @@ func (in *ScaledJobList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledJobScalingDecision) DeepCopyInto(out *ScaledJobScalingDecision) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledJobScalingDecision.
+func (in *ScaledJobScalingDecision) DeepCopy() *ScaledJobScalingDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledJobScalingDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 	*out = *in
@@ -529,7 +961,7 @@ func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 		*out = new(int32)
 		**out = **in
 	}
-	out.Rollout = in.Rollout
+	in.Rollout.DeepCopyInto(&out.Rollout)
 	if in.MinReplicaCount != nil {
 		in, out := &in.MinReplicaCount, &out.MinReplicaCount
 		*out = new(int32)
@@ -541,6 +973,11 @@ func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 		**out = **in
 	}
 	in.ScalingStrategy.DeepCopyInto(&out.ScalingStrategy)
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(FailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]ScaleTriggers, len(*in))
@@ -572,6 +1009,31 @@ func (in *ScaledJobStatus) DeepCopyInto(out *ScaledJobStatus) {
 		*out = make(Conditions, len(*in))
 		copy(*out, *in)
 	}
+	if in.RunningJobs != nil {
+		in, out := &in.RunningJobs, &out.RunningJobs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PendingJobs != nil {
+		in, out := &in.PendingJobs, &out.PendingJobs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastScalingDecision != nil {
+		in, out := &in.LastScalingDecision, &out.LastScalingDecision
+		*out = new(ScaledJobScalingDecision)
+		**out = **in
+	}
+	if in.LastJobsCreation != nil {
+		in, out := &in.LastJobsCreation, &out.LastJobsCreation
+		*out = new(ScaledJobJobsCreation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobFailures != nil {
+		in, out := &in.JobFailures, &out.JobFailures
+		*out = new(ScaledJobFailures)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledJobStatus.
@@ -776,6 +1238,21 @@ func (in *ScaledObjectStatus) DeepCopy() *ScaledObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingModifiers) DeepCopyInto(out *ScalingModifiers) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingModifiers.
+func (in *ScalingModifiers) DeepCopy() *ScalingModifiers {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingModifiers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingStrategy) DeepCopyInto(out *ScalingStrategy) {
 	*out = *in
@@ -822,6 +1299,7 @@ func (in *TriggerAuthentication) DeepCopyInto(out *TriggerAuthentication) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthentication.
@@ -842,6 +1320,119 @@ func (in *TriggerAuthentication) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationGrant) DeepCopyInto(out *TriggerAuthenticationGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationGrant.
+func (in *TriggerAuthenticationGrant) DeepCopy() *TriggerAuthenticationGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerAuthenticationGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationGrantFrom) DeepCopyInto(out *TriggerAuthenticationGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationGrantFrom.
+func (in *TriggerAuthenticationGrantFrom) DeepCopy() *TriggerAuthenticationGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationGrantList) DeepCopyInto(out *TriggerAuthenticationGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TriggerAuthenticationGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationGrantList.
+func (in *TriggerAuthenticationGrantList) DeepCopy() *TriggerAuthenticationGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerAuthenticationGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationGrantSpec) DeepCopyInto(out *TriggerAuthenticationGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]TriggerAuthenticationGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]TriggerAuthenticationGrantTo, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationGrantSpec.
+func (in *TriggerAuthenticationGrantSpec) DeepCopy() *TriggerAuthenticationGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationGrantTo) DeepCopyInto(out *TriggerAuthenticationGrantTo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationGrantTo.
+func (in *TriggerAuthenticationGrantTo) DeepCopy() *TriggerAuthenticationGrantTo {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationGrantTo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerAuthenticationList) DeepCopyInto(out *TriggerAuthenticationList) {
 	*out = *in
@@ -874,6 +1465,21 @@ func (in *TriggerAuthenticationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationParameterStatus) DeepCopyInto(out *TriggerAuthenticationParameterStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationParameterStatus.
+func (in *TriggerAuthenticationParameterStatus) DeepCopy() *TriggerAuthenticationParameterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationParameterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerAuthenticationSpec) DeepCopyInto(out *TriggerAuthenticationSpec) {
 	*out = *in
@@ -902,6 +1508,16 @@ func (in *TriggerAuthenticationSpec) DeepCopyInto(out *TriggerAuthenticationSpec
 		*out = new(AzureKeyVault)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AwsSecretManager != nil {
+		in, out := &in.AwsSecretManager, &out.AwsSecretManager
+		*out = new(AwsSecretManager)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GcpSecretManager != nil {
+		in, out := &in.GcpSecretManager, &out.GcpSecretManager
+		*out = new(GcpSecretManager)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationSpec.
@@ -914,6 +1530,41 @@ func (in *TriggerAuthenticationSpec) DeepCopy() *TriggerAuthenticationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationStatus) DeepCopyInto(out *TriggerAuthenticationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(Conditions, len(*in))
+		copy(*out, *in)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]TriggerAuthenticationParameterStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaledObjects != nil {
+		in, out := &in.ScaledObjects, &out.ScaledObjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaledJobs != nil {
+		in, out := &in.ScaledJobs, &out.ScaledJobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationStatus.
+func (in *TriggerAuthenticationStatus) DeepCopy() *TriggerAuthenticationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromSecret) DeepCopyInto(out *ValueFromSecret) {
 	*out = *in
@@ -930,9 +1581,45 @@ func (in *ValueFromSecret) DeepCopy() *ValueFromSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCredentialValue) DeepCopyInto(out *VaultCredentialValue) {
+	*out = *in
+	out.ValueFrom = in.ValueFrom
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCredentialValue.
+func (in *VaultCredentialValue) DeepCopy() *VaultCredentialValue {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCredentialValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultPkiData) DeepCopyInto(out *VaultPkiData) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultPkiData.
+func (in *VaultPkiData) DeepCopy() *VaultPkiData {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultPkiData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultSecret) DeepCopyInto(out *VaultSecret) {
 	*out = *in
+	if in.PkiData != nil {
+		in, out := &in.PkiData, &out.PkiData
+		*out = new(VaultPkiData)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecret.
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
+)
+
+// CloudEventSourceReconciler reconciles a CloudEventSource object
+type CloudEventSourceReconciler struct {
+	client.Client
+	Scheme       *runtime.Scheme
+	EventEmitter *eventemitter.EventEmitter
+}
+
+// +kubebuilder:rbac:groups=keda.sh,resources=cloudeventsources;cloudeventsources/status,verbs="*"
+
+// Reconcile registers the sink of the identified CloudEventSource in the EventEmitter, or removes it if the CloudEventSource is deleted
+func (r *CloudEventSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	cloudEventSource := &kedav1alpha1.CloudEventSource{}
+	err := r.Client.Get(ctx, req.NamespacedName, cloudEventSource)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.EventEmitter.UnregisterCloudEventSource(req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		reqLogger.Error(err, "Failed to get CloudEventSource")
+		return ctrl.Result{}, err
+	}
+
+	if cloudEventSource.GetDeletionTimestamp() != nil {
+		r.EventEmitter.UnregisterCloudEventSource(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	status := metav1.ConditionTrue
+	reason := "CloudEventSourceReady"
+	message := "CloudEventSource is defined correctly and CloudEvents are emitted to the destination"
+	if err := r.EventEmitter.RegisterCloudEventSource(cloudEventSource); err != nil {
+		reqLogger.Error(err, "CloudEventSource is not defined correctly")
+		r.EventEmitter.UnregisterCloudEventSource(req.NamespacedName)
+		status = metav1.ConditionFalse
+		reason = "CloudEventSourceCheckFailed"
+		message = err.Error()
+	}
+
+	return ctrl.Result{}, r.updateReadyCondition(ctx, cloudEventSource, status, reason, message)
+}
+
+func (r *CloudEventSourceReconciler) updateReadyCondition(ctx context.Context, cloudEventSource *kedav1alpha1.CloudEventSource, status metav1.ConditionStatus, reason string, message string) error {
+	readyCondition := cloudEventSource.Status.Conditions.GetReadyCondition()
+	if readyCondition.Status == status && readyCondition.Reason == reason && readyCondition.Message == message {
+		return nil
+	}
+
+	patch := client.MergeFrom(cloudEventSource.DeepCopy())
+	if len(cloudEventSource.Status.Conditions) == 0 {
+		cloudEventSource.Status.Conditions = kedav1alpha1.Conditions{{Type: kedav1alpha1.ConditionReady}}
+	}
+	cloudEventSource.Status.Conditions.SetReadyCondition(status, reason, message)
+	return r.Client.Status().Patch(ctx, cloudEventSource, patch)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CloudEventSourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kedav1alpha1.CloudEventSource{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -18,6 +18,7 @@ package keda
 
 import (
 	"context"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -26,18 +27,29 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 )
 
 // ClusterTriggerAuthenticationReconciler reconciles a ClusterTriggerAuthentication object
 type ClusterTriggerAuthenticationReconciler struct {
 	client.Client
-	Scheme   *runtime.Scheme
-	Recorder record.EventRecorder
+	Scheme            *runtime.Scheme
+	Recorder          record.EventRecorder
+	ScalersRefreshers []ScalersRefresher
+	// RefreshScalersOnly only refreshes the scalers using the ClusterTriggerAuthentication without recording Events
+	// or updating its status, for KEDA Metrics Server which refreshes its own scalers
+	RefreshScalersOnly bool
+
+	clusterTriggerAuthenticationsGenerations *sync.Map
 }
 
 // +kubebuilder:rbac:groups=keda.sh,resources=clustertriggerauthentications;clustertriggerauthentications/status,verbs="*"
@@ -50,6 +62,7 @@ func (r *ClusterTriggerAuthenticationReconciler) Reconcile(ctx context.Context,
 	err := r.Client.Get(ctx, req.NamespacedName, clusterTriggerAuthentication)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			r.clusterTriggerAuthenticationsGenerations.Delete(req.NamespacedName.String())
 			return ctrl.Result{}, nil
 		}
 		reqLogger.Error(err, "Failed ot get ClusterTriggerAuthentication")
@@ -57,19 +70,86 @@ func (r *ClusterTriggerAuthenticationReconciler) Reconcile(ctx context.Context,
 	}
 
 	if clusterTriggerAuthentication.GetDeletionTimestamp() != nil {
+		r.clusterTriggerAuthenticationsGenerations.Delete(req.NamespacedName.String())
+		if r.RefreshScalersOnly {
+			return ctrl.Result{}, nil
+		}
 		r.Recorder.Event(clusterTriggerAuthentication, corev1.EventTypeNormal, eventreason.ClusterTriggerAuthenticationDeleted, "ClusterTriggerAuthentication was deleted")
 		return ctrl.Result{}, nil
 	}
 
-	if clusterTriggerAuthentication.ObjectMeta.Generation == 1 {
+	// the scalers are built with the current spec if the ClusterTriggerAuthentication wasn't observed yet, eg. after restart
+	previousGeneration, loaded := r.clusterTriggerAuthenticationsGenerations.Load(req.NamespacedName.String())
+	r.clusterTriggerAuthenticationsGenerations.Store(req.NamespacedName.String(), clusterTriggerAuthentication.Generation)
+	if !loaded && clusterTriggerAuthentication.ObjectMeta.Generation == 1 && !r.RefreshScalersOnly {
 		r.Recorder.Event(clusterTriggerAuthentication, corev1.EventTypeNormal, eventreason.ClusterTriggerAuthenticationAdded, "New ClusterTriggerAuthentication configured")
 	}
+	if loaded && previousGeneration.(int64) != clusterTriggerAuthentication.Generation {
+		reqLogger.V(1).Info("ClusterTriggerAuthentication was updated, refreshing scalers using it")
+		refreshScalersUsingAuth(ctx, r.ScalersRefreshers, clusterTriggerAuthenticationKind, "", clusterTriggerAuthentication.Name)
+	}
+	if r.RefreshScalersOnly {
+		return ctrl.Result{}, nil
+	}
+
+	clusterObjectNamespace, err := resolver.GetClusterObjectNamespace()
+	if err != nil {
+		reqLogger.Error(err, "Failed to get the namespace of cluster objects")
+		return ctrl.Result{}, err
+	}
+	err = updateTriggerAuthenticationStatus(ctx, r.Client, clusterTriggerAuthentication, clusterTriggerAuthenticationKind,
+		&clusterTriggerAuthentication.Spec, &clusterTriggerAuthentication.Status, clusterObjectNamespace)
+	if err != nil {
+		reqLogger.Error(err, "Failed to update ClusterTriggerAuthentication status")
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterTriggerAuthenticationReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&kedav1alpha1.ClusterTriggerAuthentication{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+	r.clusterTriggerAuthenticationsGenerations = &sync.Map{}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&kedav1alpha1.ClusterTriggerAuthentication{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	if r.RefreshScalersOnly {
+		return controllerBuilder.Complete(r)
+	}
+
+	if err := indexScalableObjectsByAuthRef(context.Background(), mgr.GetFieldIndexer(), clusterTriggerAuthenticationKind); err != nil {
+		return err
+	}
+	return controllerBuilder.
+		// The status reports the references of the parameters to the Secrets and the ScaledObjects and ScaledJobs
+		// referencing the ClusterTriggerAuthentication
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.clusterTriggerAuthenticationsReferencingSecret),
+			builder.WithPredicates(kedacontrollerutil.SecretDataChangedPredicate{})).
+		Watches(&source.Kind{Type: &kedav1alpha1.ScaledObject{}}, handler.EnqueueRequestsFromMapFunc(clusterTriggerAuthenticationsOfScalableObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &kedav1alpha1.ScaledJob{}}, handler.EnqueueRequestsFromMapFunc(clusterTriggerAuthenticationsOfScalableObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
+
+func (r *ClusterTriggerAuthenticationReconciler) clusterTriggerAuthenticationsReferencingSecret(secret client.Object) []reconcile.Request {
+	clusterObjectNamespace, err := resolver.GetClusterObjectNamespace()
+	if err != nil || clusterObjectNamespace != secret.GetNamespace() {
+		return nil
+	}
+	clusterTriggerAuthentications := &kedav1alpha1.ClusterTriggerAuthenticationList{}
+	if err := r.Client.List(context.Background(), clusterTriggerAuthentications); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, clusterTriggerAuthentication := range clusterTriggerAuthentications.Items {
+		if kedacontrollerutil.TriggerAuthenticationReferencesSecret(&clusterTriggerAuthentication.Spec, secret.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clusterTriggerAuthentication)})
+		}
+	}
+	return requests
+}
+
+func clusterTriggerAuthenticationsOfScalableObject(object client.Object) []reconcile.Request {
+	return kedacontrollerutil.TriggerAuthenticationRequests(kedacontrollerutil.ScalableObjectTriggers(object), object.GetNamespace(), clusterTriggerAuthenticationKind)
+}
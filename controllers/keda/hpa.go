@@ -26,20 +26,19 @@ import (
 	"github.com/go-logr/logr"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
 	version "github.com/kedacore/keda/v2/version"
 )
 
-const (
-	defaultHPAMinReplicas int32 = 1
-	defaultHPAMaxReplicas int32 = 100
-)
-
 // createAndDeployNewHPA creates and deploy HPA in the cluster for specified ScaledObject
 func (r *ScaledObjectReconciler) createAndDeployNewHPA(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) error {
 	hpaName := getHPAName(scaledObject)
@@ -101,8 +100,8 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 		labels[key] = value
 	}
 
-	minReplicas := getHPAMinReplicas(scaledObject)
-	maxReplicas := getHPAMaxReplicas(scaledObject)
+	minReplicas := scaledObject.GetHPAMinReplicas()
+	maxReplicas := scaledObject.GetHPAMaxReplicas()
 
 	pausedCount, err := executor.GetPausedReplicaCount(scaledObject)
 	if err != nil {
@@ -193,6 +192,30 @@ func (r *ScaledObjectReconciler) renameHPA(ctx context.Context, logger logr.Logg
 	return r.createAndDeployNewHPA(ctx, logger, scaledObject, gvkr)
 }
 
+// deleteHPA deletes the HPA of the ScaledObject, if it exists
+func (r *ScaledObjectReconciler) deleteHPA(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	hpaName := scaledObject.Status.HpaName
+	if hpaName == "" {
+		hpaName = getHPAName(scaledObject)
+	}
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: hpaName, Namespace: scaledObject.Namespace}, hpa)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		logger.Error(err, "Failed to get HPA from cluster")
+		return err
+	}
+
+	logger.Info("Deleting HPA", "HPA.Namespace", hpa.Namespace, "HPA.Name", hpa.Name)
+	if err := r.Client.Delete(ctx, hpa); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete HPA", "HPA.Namespace", hpa.Namespace, "HPA.Name", hpa.Name)
+		return err
+	}
+	return nil
+}
+
 // getScaledObjectMetricSpecs returns MetricSpec for HPA, generater from Triggers defitinion in ScaledObject
 func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) ([]autoscalingv2beta2.MetricSpec, error) {
 	var scaledObjectMetricSpecs []autoscalingv2beta2.MetricSpec
@@ -207,6 +230,15 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 
 	metricSpecs := cache.GetMetricSpecForScaling(ctx)
 
+	// replace metrics of the triggers referenced in the formula by a single composite metric
+	if scaledObject.IsUsingModifiers() {
+		metricSpecs, err = getCompositeMetricSpecs(ctx, scaledObject, cache)
+		if err != nil {
+			logger.Error(err, "Error getting composite metric spec")
+			return nil, err
+		}
+	}
+
 	for _, metricSpec := range metricSpecs {
 		if metricSpec.Resource != nil {
 			resourceMetricNames = append(resourceMetricNames, string(metricSpec.Resource.Name))
@@ -248,6 +280,29 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 	return scaledObjectMetricSpecs, nil
 }
 
+// getCompositeMetricSpecs returns the composite External MetricSpec defined by ScalingModifiers and MetricSpecs of the scalers,
+// except External MetricSpecs of the triggers referenced in the formula which are combined into the composite metric
+func getCompositeMetricSpecs(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, scalersCache *cache.ScalersCache) ([]autoscalingv2beta2.MetricSpec, error) {
+	compositeMetricSpec, err := modifiers.GetMetricSpec(scaledObject)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []autoscalingv2beta2.MetricSpec{compositeMetricSpec}
+	for _, s := range scalersCache.GetScalerBuilders() {
+		isReferenced, err := modifiers.IsReferenced(scaledObject, s.ScalerConfig.TriggerName)
+		if err != nil {
+			return nil, err
+		}
+		for _, metricSpec := range s.Scaler.GetMetricSpecForScaling(ctx) {
+			if metricSpec.External == nil || !isReferenced {
+				result = append(result, metricSpec)
+			}
+		}
+	}
+	return result, nil
+}
+
 func updateHealthStatus(scaledObject *kedav1alpha1.ScaledObject, externalMetricNames []string, status *kedav1alpha1.ScaledObjectStatus) {
 	health := scaledObject.Status.Health
 	newHealth := make(map[string]kedav1alpha1.HealthStatus)
@@ -280,20 +335,3 @@ func getHPAName(scaledObject *kedav1alpha1.ScaledObject) string {
 func getDefaultHpaName(scaledObject *kedav1alpha1.ScaledObject) string {
 	return fmt.Sprintf("keda-hpa-%s", scaledObject.Name)
 }
-
-// getHPAMinReplicas returns MinReplicas based on definition in ScaledObject or default value if not defined
-func getHPAMinReplicas(scaledObject *kedav1alpha1.ScaledObject) *int32 {
-	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
-		return scaledObject.Spec.MinReplicaCount
-	}
-	tmp := defaultHPAMinReplicas
-	return &tmp
-}
-
-// getHPAMaxReplicas returns MaxReplicas based on definition in ScaledObject or default value if not defined
-func getHPAMaxReplicas(scaledObject *kedav1alpha1.ScaledObject) int32 {
-	if scaledObject.Spec.MaxReplicaCount != nil {
-		return *scaledObject.Spec.MaxReplicaCount
-	}
-	return defaultHPAMaxReplicas
-}
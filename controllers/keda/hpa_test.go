@@ -24,7 +24,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
@@ -118,6 +120,31 @@ var _ = Describe("hpa", func() {
 		Expect(capturedScaledObject.Status.Health).To(Equal(expectedHealth))
 	})
 
+	It("should delete HPA of paused ScaledObject", func() {
+		scaledObject := &v1alpha1.ScaledObject{
+			ObjectMeta: v1.ObjectMeta{Name: "so", Namespace: "test"},
+			Status:     v1alpha1.ScaledObjectStatus{HpaName: "keda-hpa-so"},
+		}
+
+		client.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "keda-hpa-so", Namespace: "test"}, gomock.Any()).Return(nil)
+		client.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := reconciler.deleteHPA(context.Background(), logger, scaledObject)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not fail when HPA of paused ScaledObject is already deleted", func() {
+		scaledObject := &v1alpha1.ScaledObject{
+			ObjectMeta: v1.ObjectMeta{Name: "so", Namespace: "test"},
+		}
+
+		client.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "keda-hpa-so", Namespace: "test"}, gomock.Any()).
+			Return(errors.NewNotFound(v2beta2.Resource("horizontalpodautoscalers"), "keda-hpa-so"))
+
+		err := reconciler.deleteHPA(context.Background(), logger, scaledObject)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 })
 
 func setupTest(health map[string]v1alpha1.HealthStatus, scaler *mock_scalers.MockScaler, scaleHandler *mock_scaling.MockScaleHandler) *v1alpha1.ScaledObject {
@@ -133,8 +160,8 @@ func setupTest(health map[string]v1alpha1.HealthStatus, scaler *mock_scalers.Moc
 	scalersCache := cache.ScalersCache{
 		Scalers: []cache.ScalerBuilder{{
 			Scaler: scaler,
-			Factory: func() (scalers.Scaler, error) {
-				return scaler, nil
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return scaler, &scalers.ScalerConfig{}, nil
 			},
 		}},
 		Logger:   logr.Discard(),
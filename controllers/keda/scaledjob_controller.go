@@ -19,6 +19,7 @@ package keda
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -56,13 +57,18 @@ type ScaledJobReconciler struct {
 
 // SetupWithManager initializes the ScaledJobReconciler instance and starts a new controller managed by the passed Manager instance.
 func (r *ScaledJobReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
-	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, mgr.GetEventRecorderFor("scale-handler"))
+	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, r.Recorder)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// Ignore updates to ScaledJob Status (in this case metadata.Generation does not change)
 		// so reconcile loop is not started on Status updates
-		For(&kedav1alpha1.ScaledJob{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&kedav1alpha1.ScaledJob{}, builder.WithPredicates(
+			predicate.Or(
+				kedacontrollerutil.PausedPredicate{},
+				predicate.GenerationChangedPredicate{},
+			),
+		)).
 		Complete(r)
 }
 
@@ -113,8 +119,32 @@ func (r *ScaledJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		reqLogger.Error(err, "scaledJob.spec.jobTargetRef not found")
 		return ctrl.Result{}, err
 	}
+
+	paused, err := kedacontrollerutil.IsPaused(scaledJob)
+	if err != nil {
+		msg := fmt.Sprintf("invalid value of %s annotation", kedacontrollerutil.PausedAnnotation)
+		reqLogger.Error(err, msg)
+		conditions := scaledJob.Status.Conditions.DeepCopy()
+		conditions.SetReadyCondition(metav1.ConditionFalse, "ScaledJobCheckFailed", msg)
+		r.Recorder.Event(scaledJob, corev1.EventTypeWarning, eventreason.ScaledJobCheckFailed, msg)
+		if err := kedacontrollerutil.SetStatusConditions(ctx, r.Client, reqLogger, scaledJob, &conditions); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, err
+	}
+	if paused {
+		return ctrl.Result{}, r.pauseScaledJob(ctx, reqLogger, scaledJob)
+	}
+
+	wasPaused := scaledJob.Status.Conditions.GetPausedCondition()
 	msg, err := r.reconcileScaledJob(ctx, reqLogger, scaledJob)
 	conditions := scaledJob.Status.Conditions.DeepCopy()
+	if !wasPaused.IsFalse() {
+		if wasPaused.IsTrue() {
+			r.Recorder.Event(scaledJob, corev1.EventTypeNormal, eventreason.ScaledJobResumed, "ScaledJob scaling is resumed")
+		}
+		conditions.SetPausedCondition(metav1.ConditionFalse, "ScaledJobNotPaused", "ScaledJob scaling is not paused")
+	}
 	if err != nil {
 		reqLogger.Error(err, msg)
 		conditions.SetReadyCondition(metav1.ConditionFalse, "ScaledJobCheckFailed", msg)
@@ -137,13 +167,21 @@ func (r *ScaledJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 // reconcileScaledJob implements reconciler logic for K8s Jobs based ScaledJob
 func (r *ScaledJobReconciler) reconcileScaledJob(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) (string, error) {
-	msg, err := r.deletePreviousVersionScaleJobs(ctx, logger, scaledJob)
-	if err != nil {
-		return msg, err
+	// Jobs are only rolled out once the ScaledJob was modified, not when it's reconciled because it was paused or resumed
+	if needsRollout(scaledJob) {
+		msg, err := r.deletePreviousVersionScaleJobs(ctx, logger, scaledJob)
+		if err != nil {
+			return msg, err
+		}
+	}
+	if scaledJob.Status.ObservedGeneration != scaledJob.Generation {
+		if err := r.updateObservedGeneration(ctx, logger, scaledJob); err != nil {
+			return "Failed to update the observed generation of the ScaledJob", err
+		}
 	}
 
 	// Check ScaledJob is Ready or not
-	_, err = r.scaleHandler.GetScalersCache(ctx, scaledJob)
+	_, err := r.scaleHandler.GetScalersCache(ctx, scaledJob)
 	if err != nil {
 		logger.Error(err, "Error getting scalers")
 		return "Failed to ensure ScaledJob is correctly created", err
@@ -166,6 +204,24 @@ func (r *ScaledJobReconciler) reconcileScaledJob(ctx context.Context, logger log
 	return "ScaledJob is defined correctly and is ready to scaling", nil
 }
 
+// pauseScaledJob stops the ScaleLoop of the ScaledJob, so new Jobs are not created, while the existing Jobs keep running
+func (r *ScaledJobReconciler) pauseScaledJob(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) error {
+	if err := r.stopScaleLoop(ctx, logger, scaledJob); err != nil {
+		return err
+	}
+
+	pausedCondition := scaledJob.Status.Conditions.GetPausedCondition()
+	if pausedCondition.IsTrue() {
+		return nil
+	}
+	logger.Info("ScaledJob is paused, stopped the scale loop")
+	r.Recorder.Event(scaledJob, corev1.EventTypeNormal, eventreason.ScaledJobPaused, "ScaledJob scaling is paused")
+	conditions := scaledJob.Status.Conditions.DeepCopy()
+	conditions.SetPausedCondition(metav1.ConditionTrue, "ScaledJobPaused", fmt.Sprintf("ScaledJob scaling is paused by %s annotation", kedacontrollerutil.PausedAnnotation))
+	conditions.SetActiveCondition(metav1.ConditionFalse, "ScaledJobPaused", "Scaling is not performed because ScaledJob is paused")
+	return kedacontrollerutil.SetStatusConditions(ctx, r.Client, logger, scaledJob, &conditions)
+}
+
 // Delete Jobs owned by the previous version of the scaledJob based on the rolloutStrategy given for this scaledJob, if any
 func (r *ScaledJobReconciler) deletePreviousVersionScaleJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) (string, error) {
 	var rolloutStrategy string
@@ -179,6 +235,15 @@ func (r *ScaledJobReconciler) deletePreviousVersionScaleJobs(ctx context.Context
 	switch rolloutStrategy {
 	case "gradual":
 		logger.Info("RolloutStrategy: gradual, Not deleting jobs owned by the previous version of the scaleJob")
+	case "drain":
+		drainedJobs, err := r.drainPreviousVersionScaleJobs(ctx, scaledJob)
+		if err != nil {
+			return "Not able to drain jobs owned by the previous version of the scaledJob", err
+		}
+		if drainedJobs > 0 {
+			logger.Info("RolloutStrategy: drain, Draining jobs owned by the previous version of the scaledJob", "numJobsToDrain", drainedJobs)
+		}
+		return fmt.Sprintf("RolloutStrategy: drain, draining jobs owned by the previous version of the scaleJob: %d jobs drained", drainedJobs), nil
 	default:
 		opts := []client.ListOption{
 			client.InNamespace(scaledJob.GetNamespace()),
@@ -210,6 +275,72 @@ func (r *ScaledJobReconciler) deletePreviousVersionScaleJobs(ctx context.Context
 	return fmt.Sprintf("RolloutStrategy: %s", scaledJob.Spec.RolloutStrategy), nil
 }
 
+// drainPreviousVersionScaleJobs marks the unfinished Jobs created by a previous generation of the scaledJob as draining with the time of the rollout,
+// so that they stop counting toward maxReplicaCount after rollout.drainDeadlineSeconds and are terminated after rollout.activeDeadlineSeconds
+func (r *ScaledJobReconciler) drainPreviousVersionScaleJobs(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (int, error) {
+	opts := []client.ListOption{
+		client.InNamespace(scaledJob.GetNamespace()),
+		client.MatchingLabels(map[string]string{"scaledjob.keda.sh/name": scaledJob.GetName()}),
+	}
+	jobs := &batchv1.JobList{}
+	err := r.Client.List(ctx, jobs, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	drainStart := time.Now().UTC().Format(time.RFC3339)
+	var drainedJobs int
+	for _, job := range jobs.Items {
+		job := job
+		if isJobFinished(&job) || !isPreviousVersionJob(scaledJob, &job) || job.Labels[kedav1alpha1.ScaledJobDrainingLabel] == "true" {
+			continue
+		}
+
+		patch := client.MergeFrom(job.DeepCopy())
+		job.Labels[kedav1alpha1.ScaledJobDrainingLabel] = "true"
+		if job.Annotations == nil {
+			job.Annotations = map[string]string{}
+		}
+		job.Annotations[kedav1alpha1.ScaledJobDrainStartAnnotation] = drainStart
+		if err := r.Client.Patch(ctx, &job, patch); err != nil {
+			return drainedJobs, err
+		}
+		drainedJobs++
+	}
+	return drainedJobs, nil
+}
+
+// needsRollout returns whether the ScaledJob was modified since its Jobs were last rolled out, the Jobs of a ScaledJob
+// which wasn't reconciled with an observed generation yet are kept as it's unknown which generation created them
+func needsRollout(scaledJob *kedav1alpha1.ScaledJob) bool {
+	return scaledJob.Status.ObservedGeneration != 0 && scaledJob.Status.ObservedGeneration != scaledJob.Generation
+}
+
+// updateObservedGeneration records the generation of the ScaledJob its Jobs were rolled out for
+func (r *ScaledJobReconciler) updateObservedGeneration(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) error {
+	patch := client.MergeFrom(scaledJob.DeepCopy())
+	scaledJob.Status.ObservedGeneration = scaledJob.Generation
+	err := r.Client.Status().Patch(ctx, scaledJob, patch)
+	if err != nil {
+		logger.Error(err, "Failed to patch the observed generation of the ScaledJob")
+	}
+	return err
+}
+
+// isPreviousVersionJob returns whether the Job wasn't created by the current generation of the ScaledJob
+func isPreviousVersionJob(scaledJob *kedav1alpha1.ScaledJob, job *batchv1.Job) bool {
+	return job.Labels[kedav1alpha1.ScaledJobGenerationLabel] != strconv.FormatInt(scaledJob.Generation, 10)
+}
+
+func isJobFinished(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 // requestScaleLoop request ScaleLoop handler for the respective ScaledJob
 func (r *ScaledJobReconciler) requestScaleLoop(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) error {
 	logger.V(1).Info("Starting a new ScaleLoop")
@@ -221,3 +352,11 @@ func (r *ScaledJobReconciler) stopScaleLoop(ctx context.Context, logger logr.Log
 	logger.V(1).Info("Stopping a ScaleLoop")
 	return r.scaleHandler.DeleteScalableObject(ctx, scaledJob)
 }
+
+// RefreshScalersUsingAuth rebuilds the cached scalers of the ScaledJobs which use the TriggerAuthentication or ClusterTriggerAuthentication
+func (r *ScaledJobReconciler) RefreshScalersUsingAuth(ctx context.Context, authRefKind, namespace, name string) {
+	if r.scaleHandler == nil {
+		return
+	}
+	r.scaleHandler.RefreshScalersUsingAuth(ctx, authRefKind, namespace, name)
+}
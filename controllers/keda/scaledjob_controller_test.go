@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var _ = Describe("ScaledJobController", func() {
+	Describe("needsRollout", func() {
+		var scaledJob *kedav1alpha1.ScaledJob
+
+		BeforeEach(func() {
+			scaledJob = &kedav1alpha1.ScaledJob{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "consumer", Generation: 2},
+			}
+		})
+
+		It("is false when the Jobs were rolled out for the current generation", func() {
+			scaledJob.Status.ObservedGeneration = 2
+			Expect(needsRollout(scaledJob)).To(BeFalse())
+		})
+
+		It("is true when the ScaledJob was modified since the Jobs were rolled out", func() {
+			scaledJob.Status.ObservedGeneration = 1
+			Expect(needsRollout(scaledJob)).To(BeTrue())
+		})
+
+		It("is false when no generation was observed yet", func() {
+			Expect(needsRollout(scaledJob)).To(BeFalse())
+		})
+	})
+
+	Describe("drainPreviousVersionScaleJobs", func() {
+		It("only drains the unfinished Jobs created by a previous generation", func() {
+			scaledJob := &kedav1alpha1.ScaledJob{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "consumer", Generation: 2},
+			}
+			reconciler := &ScaledJobReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+				newScaledJobJob("current", "2", false),
+				newScaledJobJob("previous", "1", false),
+				newScaledJobJob("finished", "1", true),
+			).Build()}
+
+			Expect(reconciler.drainPreviousVersionScaleJobs(context.Background(), scaledJob)).To(Equal(1))
+
+			job := &batchv1.Job{}
+			Expect(reconciler.Client.Get(context.Background(), client.ObjectKey{Namespace: "app", Name: "previous"}, job)).To(Succeed())
+			Expect(job.Labels).To(HaveKeyWithValue(kedav1alpha1.ScaledJobDrainingLabel, "true"))
+			Expect(reconciler.Client.Get(context.Background(), client.ObjectKey{Namespace: "app", Name: "current"}, job)).To(Succeed())
+			Expect(job.Labels).NotTo(HaveKey(kedav1alpha1.ScaledJobDrainingLabel))
+		})
+	})
+})
+
+func newScaledJobJob(name, generation string, finished bool) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "app",
+			Name:      name,
+			Labels: map[string]string{
+				"scaledjob.keda.sh/name":              "consumer",
+				kedav1alpha1.ScaledJobGenerationLabel: generation,
+			},
+		},
+	}
+	if finished {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	}
+	return job
+}
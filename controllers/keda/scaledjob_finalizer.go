@@ -25,6 +25,7 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
 )
 
 const (
@@ -40,6 +41,8 @@ func (r *ScaledJobReconciler) finalizeScaledJob(ctx context.Context, logger logr
 		if err := r.stopScaleLoop(ctx, logger, scaledJob); err != nil {
 			return err
 		}
+		// the series of a paused ScaledJob are kept, they are only removed once it's deleted
+		prommetrics.DeleteScalableObjectMetrics(scaledJob.Namespace, prommetrics.ScaledJobType, scaledJob.Name)
 
 		// Remove scaledJobFinalizer. Once all finalizers have been
 		// removed, the object will be deleted.
@@ -48,7 +48,9 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/metricscache"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -61,6 +63,8 @@ import (
 // +kubebuilder:rbac:groups="*",resources="*",verbs=get
 // +kubebuilder:rbac:groups="apps",resources=deployments;statefulsets,verbs=list;watch
 // +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs="*"
+// +kubebuilder:rbac:groups="authentication.k8s.io",resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups="authorization.k8s.io",resources=subjectaccessreviews,verbs=create
 
 // ScaledObjectReconciler reconciles a ScaledObject object
 type ScaledObjectReconciler struct {
@@ -68,6 +72,10 @@ type ScaledObjectReconciler struct {
 	Scheme            *runtime.Scheme
 	GlobalHTTPTimeout time.Duration
 	Recorder          record.EventRecorder
+	// CachedMetricsAddress is the address the cached metrics are served to KEDA Metrics Server on
+	CachedMetricsAddress string
+	// CachedMetricsCertDir is the directory with the serving certificate of the cached metrics
+	CachedMetricsCertDir string
 
 	scaleClient              scale.ScalesGetter
 	restMapper               meta.RESTMapper
@@ -116,6 +124,15 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 	r.scaledObjectsGenerations = &sync.Map{}
 	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), r.scaleClient, mgr.GetScheme(), r.GlobalHTTPTimeout, r.Recorder)
 
+	// Serve metrics of triggers with useCachedMetrics enabled to KEDA Metrics Server, only the leader caches them
+	if r.CachedMetricsAddress != "" {
+		server := metricscache.NewServer(r.CachedMetricsAddress, r.CachedMetricsCertDir, metricscache.NewHandler(r.scaleHandler.GetMetricsCache(), mgr.GetClient()))
+		if err := mgr.Add(server); err != nil {
+			setupLog.Error(err, "Not able to add server for cached metrics")
+			return err
+		}
+	}
+
 	// Start controller
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
@@ -125,6 +142,7 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 		For(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(
 			predicate.Or(
 				kedacontrollerutil.PausedReplicasPredicate{},
+				kedacontrollerutil.PausedPredicate{},
 				kedacontrollerutil.ScaleObjectReadyConditionPredicate{},
 				predicate.GenerationChangedPredicate{},
 			),
@@ -182,9 +200,33 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 
+	// paused-replicas annotation takes precedence, the scale target is scaled to the paused replica count by the scale loop
+	paused, err := kedacontrollerutil.IsPaused(scaledObject)
+	if err != nil {
+		msg := fmt.Sprintf("invalid value of %s annotation", kedacontrollerutil.PausedAnnotation)
+		reqLogger.Error(err, msg)
+		conditions := scaledObject.Status.Conditions.DeepCopy()
+		conditions.SetReadyCondition(metav1.ConditionFalse, "ScaledObjectCheckFailed", msg)
+		r.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.ScaledObjectCheckFailed, msg)
+		if err := kedacontrollerutil.SetStatusConditions(ctx, r.Client, reqLogger, scaledObject, &conditions); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, err
+	}
+	if _, pausedReplicas := scaledObject.GetAnnotations()[kedacontrollerutil.PausedReplicasAnnotation]; paused && !pausedReplicas {
+		return ctrl.Result{}, r.pauseScaledObject(ctx, reqLogger, scaledObject)
+	}
+
 	// reconcile ScaledObject and set status appropriately
+	wasPaused := scaledObject.Status.Conditions.GetPausedCondition()
 	msg, err := r.reconcileScaledObject(ctx, reqLogger, scaledObject)
 	conditions := scaledObject.Status.Conditions.DeepCopy()
+	if !wasPaused.IsFalse() {
+		if wasPaused.IsTrue() {
+			r.Recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectResumed, "ScaledObject scaling is resumed")
+		}
+		conditions.SetPausedCondition(metav1.ConditionFalse, "ScaledObjectNotPaused", "ScaledObject scaling is not paused")
+	}
 	if err != nil {
 		reqLogger.Error(err, msg)
 		conditions.SetReadyCondition(metav1.ConditionFalse, "ScaledObjectCheckFailed", msg)
@@ -226,11 +268,16 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "ScaledObject doesn't have correct scaleTargetRef specification", err
 	}
 
-	err = r.checkReplicaCountBoundsAreValid(scaledObject)
+	err = kedav1alpha1.CheckReplicaCountBoundsAreValid(scaledObject)
 	if err != nil {
 		return "ScaledObject doesn't have correct Idle/Min/Max Replica Counts specification", err
 	}
 
+	err = modifiers.Validate(scaledObject)
+	if err != nil {
+		return "ScaledObject doesn't have correct scalingModifiers specification", err
+	}
+
 	// Create a new HPA or update existing one according to ScaledObject
 	newHPACreated, err := r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
 	if err != nil {
@@ -257,6 +304,27 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 	return kedav1alpha1.ScaledObjectConditionReadySuccessMessage, nil
 }
 
+// pauseScaledObject stops the ScaleLoop and deletes the HPA of the ScaledObject, so the replica count of the scale target is left untouched
+func (r *ScaledObjectReconciler) pauseScaledObject(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	if err := r.stopScaleLoop(ctx, logger, scaledObject); err != nil {
+		return err
+	}
+	if err := r.deleteHPA(ctx, logger, scaledObject); err != nil {
+		return err
+	}
+
+	pausedCondition := scaledObject.Status.Conditions.GetPausedCondition()
+	if pausedCondition.IsTrue() {
+		return nil
+	}
+	logger.Info("ScaledObject is paused, stopped the scale loop and deleted the HPA")
+	r.Recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectPaused, "ScaledObject scaling is paused")
+	conditions := scaledObject.Status.Conditions.DeepCopy()
+	conditions.SetPausedCondition(metav1.ConditionTrue, "ScaledObjectPaused", fmt.Sprintf("ScaledObject scaling is paused by %s annotation", kedacontrollerutil.PausedAnnotation))
+	conditions.SetActiveCondition(metav1.ConditionFalse, "ScaledObjectPaused", "Scaling is not performed because ScaledObject is paused")
+	return kedacontrollerutil.SetStatusConditions(ctx, r.Client, logger, scaledObject, &conditions)
+}
+
 // ensureScaledObjectLabel ensures that scaledobject.keda.sh/name=<scaledObject.Name> label exist in the ScaledObject
 // This is how the MetricsAdapter will know which ScaledObject a metric is for when the HPA queries it.
 func (r *ScaledObjectReconciler) ensureScaledObjectLabel(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
@@ -342,26 +410,6 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 	return gvkr, nil
 }
 
-// checkReplicaCountBoundsAreValid checks that Idle/Min/Max ReplicaCount defined in ScaledObject are correctly specified
-// ie. that Min is not greater then Max or Idle greater or equal to Min
-func (r *ScaledObjectReconciler) checkReplicaCountBoundsAreValid(scaledObject *kedav1alpha1.ScaledObject) error {
-	min := int32(0)
-	if scaledObject.Spec.MinReplicaCount != nil {
-		min = *getHPAMinReplicas(scaledObject)
-	}
-	max := getHPAMaxReplicas(scaledObject)
-
-	if min > max {
-		return fmt.Errorf("MinReplicaCount=%d must be less than MaxReplicaCount=%d", min, max)
-	}
-
-	if scaledObject.Spec.IdleReplicaCount != nil && *scaledObject.Spec.IdleReplicaCount >= min {
-		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *scaledObject.Spec.IdleReplicaCount, min)
-	}
-
-	return nil
-}
-
 // ensureHPAForScaledObjectExists ensures that in cluster exist up-to-date HPA for specified ScaledObject, returns true if a new HPA was created
 func (r *ScaledObjectReconciler) ensureHPAForScaledObjectExists(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) (bool, error) {
 	var hpaName string
@@ -455,6 +503,14 @@ func (r *ScaledObjectReconciler) stopScaleLoop(ctx context.Context, logger logr.
 	return nil
 }
 
+// RefreshScalersUsingAuth rebuilds the cached scalers of the ScaledObjects which use the TriggerAuthentication or ClusterTriggerAuthentication
+func (r *ScaledObjectReconciler) RefreshScalersUsingAuth(ctx context.Context, authRefKind, namespace, name string) {
+	if r.scaleHandler == nil {
+		return
+	}
+	r.scaleHandler.RefreshScalersUsingAuth(ctx, authRefKind, namespace, name)
+}
+
 // scaledObjectGenerationChanged returns true if ScaledObject's Generation was changed, ie. ScaledObject.Spec was changed
 func (r *ScaledObjectReconciler) scaledObjectGenerationChanged(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) (bool, error) {
 	key, err := cache.MetaNamespaceKeyFunc(scaledObject)
@@ -105,8 +105,9 @@ var _ = Describe("ScaledObjectController", func() {
 
 					testScalers = append(testScalers, cache.ScalerBuilder{
 						Scaler: s,
-						Factory: func() (scalers.Scaler, error) {
-							return scalers.NewPrometheusScaler(config)
+						Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+							scaler, err := scalers.NewPrometheusScaler(config)
+							return scaler, config, err
 						},
 					})
 					for _, metricSpec := range s.GetMetricSpecForScaling(context.Background()) {
@@ -161,8 +162,8 @@ var _ = Describe("ScaledObjectController", func() {
 				scalersCache := cache.ScalersCache{
 					Scalers: []cache.ScalerBuilder{{
 						Scaler: s,
-						Factory: func() (scalers.Scaler, error) {
-							return s, nil
+						Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+							return s, &scalers.ScalerConfig{}, nil
 						},
 					}},
 				}
@@ -205,8 +206,8 @@ var _ = Describe("ScaledObjectController", func() {
 
 					testScalers = append(testScalers, cache.ScalerBuilder{
 						Scaler: s,
-						Factory: func() (scalers.Scaler, error) {
-							return s, nil
+						Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+							return s, &scalers.ScalerConfig{}, nil
 						},
 					})
 				}
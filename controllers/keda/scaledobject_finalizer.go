@@ -27,6 +27,7 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
 )
 
 const (
@@ -42,6 +43,8 @@ func (r *ScaledObjectReconciler) finalizeScaledObject(ctx context.Context, logge
 		if err := r.stopScaleLoop(ctx, logger, scaledObject); err != nil {
 			return err
 		}
+		// the series of a paused ScaledObject are kept, they are only removed once it's deleted
+		prommetrics.DeleteScalableObjectMetrics(scaledObject.Namespace, prommetrics.ScaledObjectType, scaledObject.Name)
 
 		// if enabled, scale scaleTarget back to the original replica count (to the state it was before scaling with KEDA)
 		if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.RestoreToOriginalReplicaCount {
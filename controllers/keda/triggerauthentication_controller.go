@@ -18,29 +18,59 @@ package keda
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 )
 
+const (
+	triggerAuthenticationKind        = "TriggerAuthentication"
+	clusterTriggerAuthenticationKind = "ClusterTriggerAuthentication"
+)
+
+// ScalersRefresher rebuilds the cached scalers which reference a TriggerAuthentication or ClusterTriggerAuthentication
+type ScalersRefresher interface {
+	RefreshScalersUsingAuth(ctx context.Context, authRefKind, namespace, name string)
+}
+
 // TriggerAuthenticationReconciler reconciles a TriggerAuthentication object
 type TriggerAuthenticationReconciler struct {
 	client.Client
-	Scheme   *runtime.Scheme
-	Recorder record.EventRecorder
+	Scheme            *runtime.Scheme
+	Recorder          record.EventRecorder
+	ScalersRefreshers []ScalersRefresher
+	// RefreshScalersOnly only refreshes the scalers using the TriggerAuthentication without recording Events
+	// or updating its status, for KEDA Metrics Server which refreshes its own scalers
+	RefreshScalersOnly bool
+
+	triggerAuthenticationsGenerations *sync.Map
 }
 
 // +kubebuilder:rbac:groups=keda.sh,resources=triggerauthentications;triggerauthentications/status,verbs="*"
+// +kubebuilder:rbac:groups=keda.sh,resources=triggerauthenticationgrants,verbs=get;list;watch
 
 // Reconcile performs reconciliation on the identified TriggerAuthentication resource based on the request information passed, returns the result and an error (if any).
 func (r *TriggerAuthenticationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -50,6 +80,7 @@ func (r *TriggerAuthenticationReconciler) Reconcile(ctx context.Context, req ctr
 	err := r.Client.Get(ctx, req.NamespacedName, triggerAuthentication)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			r.triggerAuthenticationsGenerations.Delete(req.NamespacedName.String())
 			return ctrl.Result{}, nil
 		}
 		reqLogger.Error(err, "Failed ot get TriggerAuthentication")
@@ -57,20 +88,235 @@ func (r *TriggerAuthenticationReconciler) Reconcile(ctx context.Context, req ctr
 	}
 
 	if triggerAuthentication.GetDeletionTimestamp() != nil {
+		r.triggerAuthenticationsGenerations.Delete(req.NamespacedName.String())
+		if r.RefreshScalersOnly {
+			return ctrl.Result{}, nil
+		}
 		r.Recorder.Event(triggerAuthentication, corev1.EventTypeNormal, eventreason.TriggerAuthenticationDeleted, "TriggerAuthentication was deleted")
 		return ctrl.Result{}, nil
 	}
 
-	if triggerAuthentication.ObjectMeta.Generation == 1 {
+	// the scalers are built with the current spec if the TriggerAuthentication wasn't observed yet, eg. after restart
+	previousGeneration, loaded := r.triggerAuthenticationsGenerations.Load(req.NamespacedName.String())
+	r.triggerAuthenticationsGenerations.Store(req.NamespacedName.String(), triggerAuthentication.Generation)
+	if !loaded && triggerAuthentication.ObjectMeta.Generation == 1 && !r.RefreshScalersOnly {
 		r.Recorder.Event(triggerAuthentication, corev1.EventTypeNormal, eventreason.TriggerAuthenticationAdded, "New TriggerAuthentication configured")
 	}
+	if loaded && previousGeneration.(int64) != triggerAuthentication.Generation {
+		reqLogger.V(1).Info("TriggerAuthentication was updated, refreshing scalers using it")
+		refreshScalersUsingAuth(ctx, r.ScalersRefreshers, triggerAuthenticationKind, triggerAuthentication.Namespace, triggerAuthentication.Name)
+	}
+	if r.RefreshScalersOnly {
+		return ctrl.Result{}, nil
+	}
+
+	err = updateTriggerAuthenticationStatus(ctx, r.Client, triggerAuthentication, triggerAuthenticationKind,
+		&triggerAuthentication.Spec, &triggerAuthentication.Status, triggerAuthentication.Namespace)
+	if err != nil {
+		reqLogger.Error(err, "Failed to update TriggerAuthentication status")
+		return ctrl.Result{}, err
+	}
 
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *TriggerAuthenticationReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	r.triggerAuthenticationsGenerations = &sync.Map{}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&kedav1alpha1.TriggerAuthentication{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Rotation of the Secrets referenced by TriggerAuthentications and ClusterTriggerAuthentications
+		// refreshes the scalers using them, the Secrets aren't reconciled
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.Funcs{UpdateFunc: r.onSecretUpdate},
+			builder.WithPredicates(kedacontrollerutil.SecretDataChangedPredicate{})).
+		// Created, changed or removed TriggerAuthenticationGrants refresh the scalers using the TriggerAuthentications
+		// in their namespace, so that granted cross-namespace references are used and revoked ones stop being used
+		Watches(&source.Kind{Type: &kedav1alpha1.TriggerAuthenticationGrant{}}, handler.Funcs{
+			CreateFunc: r.onTriggerAuthenticationGrantCreate,
+			UpdateFunc: r.onTriggerAuthenticationGrantUpdate,
+			DeleteFunc: r.onTriggerAuthenticationGrantDelete,
+		})
+	if r.RefreshScalersOnly {
+		return controllerBuilder.Complete(r)
+	}
+
+	if err := indexScalableObjectsByAuthRef(context.Background(), mgr.GetFieldIndexer(), triggerAuthenticationKind); err != nil {
+		return err
+	}
+	return controllerBuilder.
+		// The status reports the references of the parameters to the Secrets and the ScaledObjects and ScaledJobs
+		// referencing the TriggerAuthentication
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.triggerAuthenticationsReferencingSecret),
+			builder.WithPredicates(kedacontrollerutil.SecretDataChangedPredicate{})).
+		Watches(&source.Kind{Type: &kedav1alpha1.ScaledObject{}}, handler.EnqueueRequestsFromMapFunc(triggerAuthenticationsOfScalableObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &kedav1alpha1.ScaledJob{}}, handler.EnqueueRequestsFromMapFunc(triggerAuthenticationsOfScalableObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
+
+func (r *TriggerAuthenticationReconciler) triggerAuthenticationsReferencingSecret(secret client.Object) []reconcile.Request {
+	triggerAuthentications := &kedav1alpha1.TriggerAuthenticationList{}
+	if err := r.Client.List(context.Background(), triggerAuthentications, client.InNamespace(secret.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, triggerAuthentication := range triggerAuthentications.Items {
+		if kedacontrollerutil.TriggerAuthenticationReferencesSecret(&triggerAuthentication.Spec, secret.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&triggerAuthentication)})
+		}
+	}
+	return requests
+}
+
+func triggerAuthenticationsOfScalableObject(object client.Object) []reconcile.Request {
+	return kedacontrollerutil.TriggerAuthenticationRequests(kedacontrollerutil.ScalableObjectTriggers(object), object.GetNamespace(), triggerAuthenticationKind)
+}
+
+func (r *TriggerAuthenticationReconciler) onSecretUpdate(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	go r.refreshScalersUsingSecret(context.Background(), e.ObjectNew.GetNamespace(), e.ObjectNew.GetName())
+}
+
+func (r *TriggerAuthenticationReconciler) onTriggerAuthenticationGrantCreate(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	go r.refreshScalersUsingGrant(context.Background(), e.Object.GetNamespace())
+}
+
+func (r *TriggerAuthenticationReconciler) onTriggerAuthenticationGrantUpdate(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	go r.refreshScalersUsingGrant(context.Background(), e.ObjectNew.GetNamespace())
+}
+
+func (r *TriggerAuthenticationReconciler) onTriggerAuthenticationGrantDelete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	go r.refreshScalersUsingGrant(context.Background(), e.Object.GetNamespace())
+}
+
+// refreshScalersUsingGrant refreshes the scalers using the TriggerAuthentications in the namespace of a TriggerAuthenticationGrant
+func (r *TriggerAuthenticationReconciler) refreshScalersUsingGrant(ctx context.Context, namespace string) {
+	logger := log.FromContext(ctx).WithValues("triggerAuthenticationGrant.namespace", namespace)
+
+	triggerAuthentications := &kedav1alpha1.TriggerAuthenticationList{}
+	if err := r.Client.List(ctx, triggerAuthentications, client.InNamespace(namespace)); err != nil {
+		logger.Error(err, "Failed to list TriggerAuthentications in the namespace of the TriggerAuthenticationGrant")
+		return
+	}
+	for _, triggerAuthentication := range triggerAuthentications.Items {
+		logger.V(1).Info("TriggerAuthenticationGrant was changed, refreshing scalers using TriggerAuthentication", "triggerAuthentication", triggerAuthentication.Name)
+		refreshScalersUsingAuth(ctx, r.ScalersRefreshers, triggerAuthenticationKind, triggerAuthentication.Namespace, triggerAuthentication.Name)
+	}
+}
+
+// refreshScalersUsingSecret refreshes the scalers using the TriggerAuthentications in the namespace of the Secret,
+// or the ClusterTriggerAuthentications if the Secret is in the namespace of cluster objects, which reference the Secret
+func (r *TriggerAuthenticationReconciler) refreshScalersUsingSecret(ctx context.Context, namespace, name string) {
+	logger := log.FromContext(ctx).WithValues("secret.namespace", namespace, "secret.name", name)
+
+	triggerAuthentications := &kedav1alpha1.TriggerAuthenticationList{}
+	if err := r.Client.List(ctx, triggerAuthentications, client.InNamespace(namespace)); err != nil {
+		logger.Error(err, "Failed to list TriggerAuthentications referencing the Secret")
+		return
+	}
+	for _, triggerAuthentication := range triggerAuthentications.Items {
+		if kedacontrollerutil.TriggerAuthenticationReferencesSecret(&triggerAuthentication.Spec, name) {
+			logger.V(1).Info("Secret was updated, refreshing scalers using TriggerAuthentication", "triggerAuthentication", triggerAuthentication.Name)
+			refreshScalersUsingAuth(ctx, r.ScalersRefreshers, triggerAuthenticationKind, triggerAuthentication.Namespace, triggerAuthentication.Name)
+		}
+	}
+
+	clusterObjectNamespace, err := resolver.GetClusterObjectNamespace()
+	if err != nil || clusterObjectNamespace != namespace {
+		return
+	}
+	clusterTriggerAuthentications := &kedav1alpha1.ClusterTriggerAuthenticationList{}
+	if err := r.Client.List(ctx, clusterTriggerAuthentications); err != nil {
+		logger.Error(err, "Failed to list ClusterTriggerAuthentications referencing the Secret")
+		return
+	}
+	for _, clusterTriggerAuthentication := range clusterTriggerAuthentications.Items {
+		if kedacontrollerutil.TriggerAuthenticationReferencesSecret(&clusterTriggerAuthentication.Spec, name) {
+			logger.V(1).Info("Secret was updated, refreshing scalers using ClusterTriggerAuthentication", "clusterTriggerAuthentication", clusterTriggerAuthentication.Name)
+			refreshScalersUsingAuth(ctx, r.ScalersRefreshers, clusterTriggerAuthenticationKind, "", clusterTriggerAuthentication.Name)
+		}
+	}
+}
+
+func refreshScalersUsingAuth(ctx context.Context, refreshers []ScalersRefresher, authRefKind, namespace, name string) {
+	for _, refresher := range refreshers {
+		refresher.RefreshScalersUsingAuth(ctx, authRefKind, namespace, name)
+	}
+}
+
+// authRefIndexField returns the field indexing the ScaledObjects and ScaledJobs by the TriggerAuthentications,
+// or ClusterTriggerAuthentications depending on authRefKind, referenced by their triggers
+func authRefIndexField(authRefKind string) string {
+	return "spec.triggers.authenticationRef." + authRefKind
+}
+
+// indexScalableObjectsByAuthRef indexes the ScaledObjects and ScaledJobs by the namespaced names of the TriggerAuthentications,
+// or ClusterTriggerAuthentications depending on authRefKind, referenced by their triggers
+func indexScalableObjectsByAuthRef(ctx context.Context, indexer client.FieldIndexer, authRefKind string) error {
+	extractValue := func(object client.Object) []string {
+		var values []string
+		for _, request := range kedacontrollerutil.TriggerAuthenticationRequests(kedacontrollerutil.ScalableObjectTriggers(object), object.GetNamespace(), authRefKind) {
+			values = append(values, request.NamespacedName.String())
+		}
+		return values
+	}
+	for _, object := range []client.Object{&kedav1alpha1.ScaledObject{}, &kedav1alpha1.ScaledJob{}} {
+		if err := indexer.IndexField(ctx, object, authRefIndexField(authRefKind), extractValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateTriggerAuthenticationStatus checks the references of the parameters of the TriggerAuthentication or ClusterTriggerAuthentication
+// and lists the ScaledObjects and ScaledJobs referencing it, the status is patched if it changed
+func updateTriggerAuthenticationStatus(ctx context.Context, c client.Client, object client.Object, authRefKind string,
+	spec *kedav1alpha1.TriggerAuthenticationSpec, status *kedav1alpha1.TriggerAuthenticationStatus, triggerNamespace string) error {
+	newStatus := status.DeepCopy()
+	newStatus.Parameters = resolver.CheckTriggerAuthenticationParameters(ctx, c, spec, triggerNamespace)
+
+	var failures []string
+	for _, parameter := range newStatus.Parameters {
+		if !parameter.Resolved {
+			failures = append(failures, fmt.Sprintf("parameter %s from %s: %s", parameter.Parameter, parameter.Source, parameter.Message))
+		}
+	}
+	if len(newStatus.Conditions) == 0 {
+		newStatus.Conditions = kedav1alpha1.Conditions{{Type: kedav1alpha1.ConditionReady}}
+	}
+	if len(failures) > 0 {
+		newStatus.LastResolutionError = strings.Join(failures, "; ")
+		newStatus.Conditions.SetReadyCondition(metav1.ConditionFalse, kedav1alpha1.TriggerAuthenticationConditionReadyFailedReason, newStatus.LastResolutionError)
+	} else {
+		newStatus.Conditions.SetReadyCondition(metav1.ConditionTrue, kedav1alpha1.TriggerAuthenticationConditionReadySuccessReason, kedav1alpha1.TriggerAuthenticationConditionReadySuccessMessage)
+	}
+
+	referencing := client.MatchingFields{authRefIndexField(authRefKind): client.ObjectKeyFromObject(object).String()}
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := c.List(ctx, scaledObjects, referencing); err != nil {
+		return err
+	}
+	newStatus.ScaledObjects = nil
+	for _, scaledObject := range scaledObjects.Items {
+		newStatus.ScaledObjects = append(newStatus.ScaledObjects, scaledObject.Namespace+"/"+scaledObject.Name)
+	}
+	sort.Strings(newStatus.ScaledObjects)
+
+	scaledJobs := &kedav1alpha1.ScaledJobList{}
+	if err := c.List(ctx, scaledJobs, referencing); err != nil {
+		return err
+	}
+	newStatus.ScaledJobs = nil
+	for _, scaledJob := range scaledJobs.Items {
+		newStatus.ScaledJobs = append(newStatus.ScaledJobs, scaledJob.Namespace+"/"+scaledJob.Name)
+	}
+	sort.Strings(newStatus.ScaledJobs)
+
+	if equality.Semantic.DeepEqual(status, newStatus) {
+		return nil
+	}
+	patch := client.MergeFrom(object.DeepCopyObject().(client.Object))
+	*status = *newStatus
+	return c.Status().Patch(ctx, object, patch)
+}
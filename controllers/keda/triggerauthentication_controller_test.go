@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// recordingScalersRefresher records the TriggerAuthentications the scalers were refreshed for
+type recordingScalersRefresher struct {
+	refreshed []string
+}
+
+func (r *recordingScalersRefresher) RefreshScalersUsingAuth(_ context.Context, authRefKind, namespace, name string) {
+	r.refreshed = append(r.refreshed, authRefKind+":"+namespace+"/"+name)
+}
+
+var _ = Describe("TriggerAuthenticationController", func() {
+	Describe("refreshScalersUsingSecret", func() {
+		var (
+			refresher  *recordingScalersRefresher
+			reconciler *TriggerAuthenticationReconciler
+		)
+
+		secretRef := []kedav1alpha1.AuthSecretTargetRef{{Parameter: "password", Name: "credentials", Key: "password"}}
+		otherSecretRef := []kedav1alpha1.AuthSecretTargetRef{{Parameter: "password", Name: "other", Key: "password"}}
+
+		BeforeEach(func() {
+			Expect(os.Setenv("KEDA_CLUSTER_OBJECT_NAMESPACE", "keda")).To(Succeed())
+			refresher = &recordingScalersRefresher{}
+			reconciler = &TriggerAuthenticationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+					&kedav1alpha1.TriggerAuthentication{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "using-secret"},
+						Spec:       kedav1alpha1.TriggerAuthenticationSpec{SecretTargetRef: secretRef},
+					},
+					&kedav1alpha1.TriggerAuthentication{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "using-other-secret"},
+						Spec:       kedav1alpha1.TriggerAuthenticationSpec{SecretTargetRef: otherSecretRef},
+					},
+					&kedav1alpha1.TriggerAuthentication{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "keda", Name: "in-cluster-namespace"},
+						Spec:       kedav1alpha1.TriggerAuthenticationSpec{SecretTargetRef: secretRef},
+					},
+					&kedav1alpha1.ClusterTriggerAuthentication{
+						ObjectMeta: metav1.ObjectMeta{Name: "cluster-using-secret"},
+						Spec:       kedav1alpha1.TriggerAuthenticationSpec{SecretTargetRef: secretRef},
+					},
+				).Build(),
+				ScalersRefreshers: []ScalersRefresher{refresher},
+			}
+		})
+
+		AfterEach(func() {
+			Expect(os.Unsetenv("KEDA_CLUSTER_OBJECT_NAMESPACE")).To(Succeed())
+		})
+
+		It("refreshes the scalers using TriggerAuthentications referencing the Secret", func() {
+			reconciler.refreshScalersUsingSecret(context.Background(), "app", "credentials")
+			Expect(refresher.refreshed).To(ConsistOf("TriggerAuthentication:app/using-secret"))
+		})
+
+		It("refreshes the scalers using ClusterTriggerAuthentications referencing a Secret in the namespace of cluster objects", func() {
+			reconciler.refreshScalersUsingSecret(context.Background(), "keda", "credentials")
+			Expect(refresher.refreshed).To(ConsistOf("TriggerAuthentication:keda/in-cluster-namespace", "ClusterTriggerAuthentication:/cluster-using-secret"))
+		})
+
+		It("doesn't refresh scalers for a Secret which isn't referenced", func() {
+			reconciler.refreshScalersUsingSecret(context.Background(), "app", "unused")
+			Expect(refresher.refreshed).To(BeEmpty())
+		})
+	})
+})
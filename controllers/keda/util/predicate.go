@@ -1,6 +1,11 @@
 package util
 
 import (
+	"reflect"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -9,6 +14,9 @@ import (
 
 const PausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
 
+// PausedAnnotation pauses the scaling of a ScaledObject or ScaledJob if it is set to true
+const PausedAnnotation = "autoscaling.keda.sh/paused"
+
 type PausedReplicasPredicate struct {
 	predicate.Funcs
 }
@@ -31,6 +39,30 @@ func (PausedReplicasPredicate) Update(e event.UpdateEvent) bool {
 	return false
 }
 
+// PausedPredicate triggers reconciliation when PausedAnnotation is added, removed or changed
+type PausedPredicate struct {
+	predicate.Funcs
+}
+
+func (PausedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	oldVal, oldOk := e.ObjectOld.GetAnnotations()[PausedAnnotation]
+	newVal, newOk := e.ObjectNew.GetAnnotations()[PausedAnnotation]
+	return oldOk != newOk || oldVal != newVal
+}
+
+// IsPaused returns whether the object has PausedAnnotation set to true
+func IsPaused(object client.Object) (bool, error) {
+	value, ok := object.GetAnnotations()[PausedAnnotation]
+	if !ok {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
 type ScaleObjectReadyConditionPredicate struct {
 	predicate.Funcs
 }
@@ -61,3 +93,17 @@ func (ScaleObjectReadyConditionPredicate) Update(e event.UpdateEvent) bool {
 
 	return false
 }
+
+// SecretDataChangedPredicate triggers on updates of the data of a Secret, ignoring updates of its metadata
+type SecretDataChangedPredicate struct {
+	predicate.Funcs
+}
+
+func (SecretDataChangedPredicate) Update(e event.UpdateEvent) bool {
+	oldSecret, ok1 := e.ObjectOld.(*corev1.Secret)
+	newSecret, ok2 := e.ObjectNew.(*corev1.Secret)
+	if !ok1 || !ok2 {
+		return false
+	}
+	return !reflect.DeepEqual(oldSecret.Data, newSecret.Data) || !reflect.DeepEqual(oldSecret.StringData, newSecret.StringData)
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func newScaledJob(annotations map[string]string) *kedav1alpha1.ScaledJob {
+	return &kedav1alpha1.ScaledJob{ObjectMeta: metav1.ObjectMeta{Name: "sj", Namespace: "test", Annotations: annotations}}
+}
+
+func TestPausedPredicate(t *testing.T) {
+	paused := map[string]string{PausedAnnotation: "true"}
+	unpaused := map[string]string{PausedAnnotation: "false"}
+	other := map[string]string{"other": "true"}
+
+	p := PausedPredicate{}
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: newScaledJob(nil), ObjectNew: newScaledJob(paused)}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: newScaledJob(paused), ObjectNew: newScaledJob(nil)}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: newScaledJob(paused), ObjectNew: newScaledJob(unpaused)}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: newScaledJob(paused), ObjectNew: newScaledJob(paused)}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: newScaledJob(nil), ObjectNew: newScaledJob(other)}))
+}
+
+func TestIsPaused(t *testing.T) {
+	paused, err := IsPaused(newScaledJob(map[string]string{PausedAnnotation: "true"}))
+	assert.NoError(t, err)
+	assert.True(t, paused)
+
+	paused, err = IsPaused(newScaledJob(nil))
+	assert.NoError(t, err)
+	assert.False(t, paused)
+
+	_, err = IsPaused(newScaledJob(map[string]string{PausedAnnotation: "yes"}))
+	assert.Error(t, err)
+}
+
+func TestSecretDataChangedPredicate(t *testing.T) {
+	newSecret := func(data map[string][]byte, labels map[string]string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "test", Labels: labels}, Data: data}
+	}
+	data := map[string][]byte{"password": []byte("old")}
+	rotatedData := map[string][]byte{"password": []byte("new")}
+
+	p := SecretDataChangedPredicate{}
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: newSecret(data, nil), ObjectNew: newSecret(rotatedData, nil)}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: newSecret(data, nil), ObjectNew: newSecret(data, map[string]string{"label": "value"})}))
+}
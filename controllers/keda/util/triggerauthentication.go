@@ -0,0 +1,95 @@
+package util
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const clusterTriggerAuthenticationKind = "ClusterTriggerAuthentication"
+
+// TriggerAuthenticationReferencesSecret returns whether the TriggerAuthentication or ClusterTriggerAuthentication
+// resolves parameters or the credentials of a secret manager from the Secret
+func TriggerAuthenticationReferencesSecret(spec *kedav1alpha1.TriggerAuthenticationSpec, secretName string) bool {
+	for _, secretRef := range spec.SecretTargetRef {
+		if secretRef.Name == secretName {
+			return true
+		}
+	}
+
+	if spec.AzureKeyVault != nil && spec.AzureKeyVault.Credentials != nil && spec.AzureKeyVault.Credentials.ClientSecret != nil &&
+		spec.AzureKeyVault.Credentials.ClientSecret.ValueFrom.SecretKeyRef.Name == secretName {
+		return true
+	}
+
+	if spec.HashiCorpVault != nil && spec.HashiCorpVault.Credential != nil {
+		credential := spec.HashiCorpVault.Credential
+		for _, value := range []*kedav1alpha1.VaultCredentialValue{credential.SecretID, credential.JWT} {
+			if value != nil && value.ValueFrom.SecretKeyRef.Name == secretName {
+				return true
+			}
+		}
+	}
+
+	if spec.AwsSecretManager != nil && spec.AwsSecretManager.Credentials != nil {
+		credentials := spec.AwsSecretManager.Credentials
+		for _, value := range []*kedav1alpha1.AwsSecretManagerValue{credentials.AccessKey, credentials.AccessSecretKey, credentials.AccessToken} {
+			if value != nil && value.ValueFrom.SecretKeyRef.Name == secretName {
+				return true
+			}
+		}
+	}
+
+	if spec.GcpSecretManager != nil && spec.GcpSecretManager.Credentials != nil &&
+		spec.GcpSecretManager.Credentials.ClientSecret.ValueFrom.SecretKeyRef.Name == secretName {
+		return true
+	}
+
+	return false
+}
+
+// ScalableObjectTriggers returns the triggers of a ScaledObject or ScaledJob
+func ScalableObjectTriggers(object client.Object) []kedav1alpha1.ScaleTriggers {
+	switch obj := object.(type) {
+	case *kedav1alpha1.ScaledObject:
+		return obj.Spec.Triggers
+	case *kedav1alpha1.ScaledJob:
+		return obj.Spec.Triggers
+	default:
+		return nil
+	}
+}
+
+// TriggerAuthenticationRequests returns the requests to reconcile the TriggerAuthentications, or ClusterTriggerAuthentications
+// depending on authRefKind, referenced by the triggers of a ScaledObject or ScaledJob in the namespace
+func TriggerAuthenticationRequests(triggers []kedav1alpha1.ScaleTriggers, namespace, authRefKind string) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, trigger := range triggers {
+		authRef := trigger.AuthenticationRef
+		if authRef == nil || authRef.Name == "" || authRefKindOrDefault(authRef) != authRefKind {
+			continue
+		}
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Name: authRef.Name}}
+		if authRefKind != clusterTriggerAuthenticationKind {
+			request.Namespace = authRefNamespaceOrDefault(authRef, namespace)
+		}
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+func authRefKindOrDefault(authRef *kedav1alpha1.ScaledObjectAuthRef) string {
+	if authRef.Kind == "" {
+		return "TriggerAuthentication"
+	}
+	return authRef.Kind
+}
+
+func authRefNamespaceOrDefault(authRef *kedav1alpha1.ScaledObjectAuthRef, namespace string) string {
+	if authRef.Namespace == "" {
+		return namespace
+	}
+	return authRef.Namespace
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestTriggerAuthenticationReferencesSecret(t *testing.T) {
+	secretKeyRef := kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "credentials", Key: "key"}}
+
+	assert.True(t, TriggerAuthenticationReferencesSecret(&kedav1alpha1.TriggerAuthenticationSpec{
+		SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "password", Name: "credentials", Key: "password"}},
+	}, "credentials"))
+	assert.True(t, TriggerAuthenticationReferencesSecret(&kedav1alpha1.TriggerAuthenticationSpec{
+		AzureKeyVault: &kedav1alpha1.AzureKeyVault{Credentials: &kedav1alpha1.AzureKeyVaultCredentials{
+			ClientSecret: &kedav1alpha1.AzureKeyVaultClientSecret{ValueFrom: secretKeyRef},
+		}},
+	}, "credentials"))
+	assert.True(t, TriggerAuthenticationReferencesSecret(&kedav1alpha1.TriggerAuthenticationSpec{
+		HashiCorpVault: &kedav1alpha1.HashiCorpVault{Credential: &kedav1alpha1.Credential{
+			SecretID: &kedav1alpha1.VaultCredentialValue{ValueFrom: secretKeyRef},
+		}},
+	}, "credentials"))
+	assert.True(t, TriggerAuthenticationReferencesSecret(&kedav1alpha1.TriggerAuthenticationSpec{
+		AwsSecretManager: &kedav1alpha1.AwsSecretManager{Credentials: &kedav1alpha1.AwsSecretManagerCredentials{
+			AccessKey:       &kedav1alpha1.AwsSecretManagerValue{ValueFrom: kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "other"}}},
+			AccessSecretKey: &kedav1alpha1.AwsSecretManagerValue{ValueFrom: secretKeyRef},
+		}},
+	}, "credentials"))
+	assert.True(t, TriggerAuthenticationReferencesSecret(&kedav1alpha1.TriggerAuthenticationSpec{
+		GcpSecretManager: &kedav1alpha1.GcpSecretManager{Credentials: &kedav1alpha1.GcpCredentials{
+			ClientSecret: kedav1alpha1.GcpSecretManagerValue{ValueFrom: secretKeyRef},
+		}},
+	}, "credentials"))
+
+	assert.False(t, TriggerAuthenticationReferencesSecret(&kedav1alpha1.TriggerAuthenticationSpec{
+		SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "password", Name: "other", Key: "password"}},
+		AzureKeyVault:   &kedav1alpha1.AzureKeyVault{},
+	}, "credentials"))
+}
+
+func TestTriggerAuthenticationRequests(t *testing.T) {
+	triggers := []kedav1alpha1.ScaleTriggers{
+		{Type: "cpu"},
+		{Type: "prometheus", AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "local"}},
+		{Type: "prometheus", AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "shared", Namespace: "credentials"}},
+		{Type: "prometheus", AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "global", Kind: "ClusterTriggerAuthentication"}},
+	}
+
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "app", Name: "local"}},
+		{NamespacedName: types.NamespacedName{Namespace: "credentials", Name: "shared"}},
+	}, TriggerAuthenticationRequests(triggers, "app", "TriggerAuthentication"))
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "global"}},
+	}, TriggerAuthenticationRequests(triggers, "app", "ClusterTriggerAuthentication"))
+}
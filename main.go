@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -34,7 +35,11 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollers "github.com/kedacore/keda/v2/controllers/keda"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
+	"github.com/kedacore/keda/v2/pkg/metricscache"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
+	"github.com/kedacore/keda/v2/pkg/webhooks"
 	"github.com/kedacore/keda/v2/version"
 	//nolint:gci
 	//+kubebuilder:scaffold:imports
@@ -66,11 +71,27 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var enableWebhooks bool
+	var cachedMetricsAddr string
+	var cachedMetricsCertDir string
+	var tracingConfig tracing.Config
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Enable validating admission webhooks for ScaledObjects, ScaledJobs and TriggerAuthentications. "+
+			"Webhook server requires the serving certificate to be mounted to /tmp/k8s-webhook-server/serving-certs.")
+	flag.StringVar(&cachedMetricsAddr, "cached-metrics-bind-address", "",
+		"The address the cached metrics of triggers with useCachedMetrics enabled are served to KEDA Metrics Server on, eg. :9666. "+
+			"They are served over TLS with the certificate tls.crt and key tls.key found in --cached-metrics-cert-dir.")
+	flag.StringVar(&cachedMetricsCertDir, "cached-metrics-cert-dir", "/certs", "The directory with the serving certificate of the cached metrics.")
+	flag.StringVar(&tracingConfig.Endpoint, "otlp-endpoint", "",
+		"The address of OTLP gRPC receiver the spans of scaler calls are exported to, eg. otel-collector.monitoring:4317. "+
+			"Tracing is disabled if it is not set.")
+	flag.BoolVar(&tracingConfig.Insecure, "otlp-insecure", false, "Disable TLS for the connection to OTLP receiver.")
+	flag.Float64Var(&tracingConfig.SamplingRatio, "otlp-sampling-ratio", 1.0, "The ratio of traces that are sampled, between 0 and 1.")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 
@@ -78,28 +99,47 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	ctx := ctrl.SetupSignalHandler()
+
+	shutdownTracerProvider, err := tracing.InitTracerProvider(ctx, "keda-operator", tracingConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	flushSpans := func() {
+		if err := shutdownTracerProvider(context.Background()); err != nil {
+			setupLog.Error(err, "unable to flush spans")
+		}
+	}
+	defer flushSpans()
+	// os.Exit doesn't run the deferred functions, so the spans are flushed before exiting
+	exit := func() {
+		flushSpans()
+		os.Exit(1)
+	}
+
 	namespace, err := getWatchNamespace()
 	if err != nil {
 		setupLog.Error(err, "failed to get watch namespace")
-		os.Exit(1)
+		exit()
 	}
 
 	leaseDuration, err := kedautil.ResolveOsEnvDuration("KEDA_OPERATOR_LEADER_ELECTION_LEASE_DURATION")
 	if err != nil {
 		setupLog.Error(err, "invalid KEDA_OPERATOR_LEADER_ELECTION_LEASE_DURATION")
-		os.Exit(1)
+		exit()
 	}
 
 	renewDeadline, err := kedautil.ResolveOsEnvDuration("KEDA_OPERATOR_LEADER_ELECTION_RENEW_DEADLINE")
 	if err != nil {
 		setupLog.Error(err, "invalid KEDA_OPERATOR_LEADER_ELECTION_RENEW_DEADLINE")
-		os.Exit(1)
+		exit()
 	}
 
 	retryPeriod, err := kedautil.ResolveOsEnvDuration("KEDA_OPERATOR_LEADER_ELECTION_RETRY_PERIOD")
 	if err != nil {
 		setupLog.Error(err, "invalid KEDA_OPERATOR_LEADER_ELECTION_RETRY_PERIOD")
-		os.Exit(1)
+		exit()
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
@@ -108,7 +148,7 @@ func main() {
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "operator.keda.sh",
+		LeaderElectionID:       metricscache.OperatorLeaderElectionID,
 		LeaseDuration:          leaseDuration,
 		RenewDeadline:          renewDeadline,
 		RetryPeriod:            retryPeriod,
@@ -116,74 +156,98 @@ func main() {
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
-		os.Exit(1)
+		exit()
 	}
 
 	// default to 3 seconds if they don't pass the env var
 	globalHTTPTimeoutMS, err := kedautil.ResolveOsEnvInt("KEDA_HTTP_DEFAULT_TIMEOUT", 3000)
 	if err != nil {
 		setupLog.Error(err, "Invalid KEDA_HTTP_DEFAULT_TIMEOUT")
-		os.Exit(1)
+		exit()
 	}
 
 	scaledObjectMaxReconciles, err := kedautil.ResolveOsEnvInt("KEDA_SCALEDOBJECT_CTRL_MAX_RECONCILES", 5)
 	if err != nil {
 		setupLog.Error(err, "Invalid KEDA_SCALEDOBJECT_CTRL_MAX_RECONCILES")
-		os.Exit(1)
+		exit()
 	}
 
 	scaledJobMaxReconciles, err := kedautil.ResolveOsEnvInt("KEDA_SCALEDJOB_CTRL_MAX_RECONCILES", 1)
 	if err != nil {
 		setupLog.Error(err, "Invalid KEDA_SCALEDJOB_CTRL_MAX_RECONCILES")
-		os.Exit(1)
+		exit()
 	}
 
 	globalHTTPTimeout := time.Duration(globalHTTPTimeoutMS) * time.Millisecond
-	eventRecorder := mgr.GetEventRecorderFor("keda-operator")
+	eventEmitter := eventemitter.NewEventEmitter(mgr.GetEventRecorderFor("keda-operator"), mgr.GetScheme(), globalHTTPTimeout)
 
-	if err = (&kedacontrollers.ScaledObjectReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		GlobalHTTPTimeout: globalHTTPTimeout,
-		Recorder:          eventRecorder,
-	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: scaledObjectMaxReconciles}); err != nil {
+	scaledObjectReconciler := &kedacontrollers.ScaledObjectReconciler{
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		GlobalHTTPTimeout:    globalHTTPTimeout,
+		Recorder:             eventEmitter,
+		CachedMetricsAddress: cachedMetricsAddr,
+		CachedMetricsCertDir: cachedMetricsCertDir,
+	}
+	if err = scaledObjectReconciler.SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: scaledObjectMaxReconciles}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledObject")
-		os.Exit(1)
+		exit()
 	}
-	if err = (&kedacontrollers.ScaledJobReconciler{
+	scaledJobReconciler := &kedacontrollers.ScaledJobReconciler{
 		Client:            mgr.GetClient(),
 		Scheme:            mgr.GetScheme(),
 		GlobalHTTPTimeout: globalHTTPTimeout,
-		Recorder:          eventRecorder,
-	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: scaledJobMaxReconciles}); err != nil {
+		Recorder:          eventEmitter,
+	}
+	if err = scaledJobReconciler.SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: scaledJobMaxReconciles}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledJob")
-		os.Exit(1)
+		exit()
 	}
 	if err = (&kedacontrollers.TriggerAuthenticationReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: eventRecorder,
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Recorder:          eventEmitter,
+		ScalersRefreshers: []kedacontrollers.ScalersRefresher{scaledObjectReconciler, scaledJobReconciler},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "TriggerAuthentication")
-		os.Exit(1)
+		exit()
 	}
 	if err = (&kedacontrollers.ClusterTriggerAuthenticationReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: eventRecorder,
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Recorder:          eventEmitter,
+		ScalersRefreshers: []kedacontrollers.ScalersRefresher{scaledObjectReconciler, scaledJobReconciler},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterTriggerAuthentication")
-		os.Exit(1)
+		exit()
+	}
+	if err = (&kedacontrollers.CloudEventSourceReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		EventEmitter: eventEmitter,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CloudEventSource")
+		exit()
+	}
+	if err = mgr.Add(eventEmitter); err != nil {
+		setupLog.Error(err, "unable to add the CloudEvents emitter")
+		exit()
+	}
+	if enableWebhooks {
+		if err = webhooks.SetupWebhooksWithManager(mgr, globalHTTPTimeout); err != nil {
+			setupLog.Error(err, "unable to set up webhooks")
+			exit()
+		}
 	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
-		os.Exit(1)
+		exit()
 	}
 	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+		exit()
 	}
 
 	setupLog.Info("Starting manager")
@@ -192,8 +256,8 @@ func main() {
 	setupLog.Info(fmt.Sprintf("Go Version: %s", runtime.Version()))
 	setupLog.Info(fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
-		os.Exit(1)
+		exit()
 	}
 }
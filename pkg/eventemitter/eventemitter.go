@@ -0,0 +1,309 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	cloudEventSpecVersion = "1.0"
+	cloudEventContentType = "application/cloudevents+json"
+	defaultClusterName    = "kubernetes-default"
+
+	// cloudEventQueueSize is the number of CloudEvents waiting to be sent, the CloudEvents are dropped when the queue is full
+	cloudEventQueueSize = 1000
+	cloudEventWorkers   = 4
+	maxSendAttempts     = 3
+	sendRetryBackoff    = time.Second
+)
+
+// EventEmitter records Kubernetes Events of KEDA resources and emits the events
+// as CloudEvents to the sinks of CloudEventSources in the namespace of the resource
+type EventEmitter struct {
+	record.EventRecorder
+	scheme     *runtime.Scheme
+	httpClient *http.Client
+	logger     logr.Logger
+
+	cloudEventSources     map[types.NamespacedName]kedav1alpha1.CloudEventSourceSpec
+	cloudEventSourcesLock sync.RWMutex
+
+	queue chan cloudEventDelivery
+}
+
+// cloudEventDelivery is a CloudEvent to send to the sink of a CloudEventSource
+type cloudEventDelivery struct {
+	cloudEventSource types.NamespacedName
+	uri              string
+	event            CloudEvent
+}
+
+// CloudEvent is the representation of a CloudEvent in the structured content mode
+type CloudEvent struct {
+	SpecVersion     string         `json:"specversion"`
+	ID              string         `json:"id"`
+	Source          string         `json:"source"`
+	Type            string         `json:"type"`
+	Subject         string         `json:"subject"`
+	Time            time.Time      `json:"time"`
+	DataContentType string         `json:"datacontenttype"`
+	Data            CloudEventData `json:"data"`
+}
+
+// CloudEventData is the payload of a CloudEvent emitted by KEDA
+type CloudEventData struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// NewEventEmitter creates an EventEmitter wrapping the recorder of Kubernetes Events
+func NewEventEmitter(recorder record.EventRecorder, scheme *runtime.Scheme, httpTimeout time.Duration) *EventEmitter {
+	return &EventEmitter{
+		EventRecorder:     recorder,
+		scheme:            scheme,
+		httpClient:        kedautil.CreateHTTPClient(httpTimeout, false),
+		logger:            logf.Log.WithName("eventemitter"),
+		cloudEventSources: map[types.NamespacedName]kedav1alpha1.CloudEventSourceSpec{},
+		queue:             make(chan cloudEventDelivery, cloudEventQueueSize),
+	}
+}
+
+// Start sends the emitted CloudEvents with a fixed number of workers until the context is done
+func (e *EventEmitter) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < cloudEventWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case delivery := <-e.queue:
+					e.deliver(ctx, delivery)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// RegisterCloudEventSource starts emitting CloudEvents to the sink of the CloudEventSource, or updates the sink if it is already registered
+func (e *EventEmitter) RegisterCloudEventSource(cloudEventSource *kedav1alpha1.CloudEventSource) error {
+	if err := validateCloudEventSourceSpec(&cloudEventSource.Spec); err != nil {
+		return err
+	}
+
+	e.cloudEventSourcesLock.Lock()
+	defer e.cloudEventSourcesLock.Unlock()
+	e.cloudEventSources[types.NamespacedName{Namespace: cloudEventSource.Namespace, Name: cloudEventSource.Name}] = *cloudEventSource.Spec.DeepCopy()
+	return nil
+}
+
+// UnregisterCloudEventSource stops emitting CloudEvents to the sink of the CloudEventSource
+func (e *EventEmitter) UnregisterCloudEventSource(namespacedName types.NamespacedName) {
+	e.cloudEventSourcesLock.Lock()
+	defer e.cloudEventSourcesLock.Unlock()
+	delete(e.cloudEventSources, namespacedName)
+}
+
+// Event records the Kubernetes Event and emits it as CloudEvent
+func (e *EventEmitter) Event(object runtime.Object, eventtype, reason, message string) {
+	e.EventRecorder.Event(object, eventtype, reason, message)
+	e.emit(object, reason, message)
+}
+
+// Eventf records the Kubernetes Event and emits it as CloudEvent
+func (e *EventEmitter) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	e.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	e.emit(object, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf records the Kubernetes Event and emits it as CloudEvent
+func (e *EventEmitter) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	e.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	e.emit(object, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (e *EventEmitter) emit(object runtime.Object, reason, message string) {
+	eventType, ok := getCloudEventType(reason)
+	if !ok {
+		return
+	}
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		e.logger.Error(err, "error getting metadata of the object of event", "reason", reason)
+		return
+	}
+
+	e.cloudEventSourcesLock.RLock()
+	defer e.cloudEventSourcesLock.RUnlock()
+	for namespacedName, spec := range e.cloudEventSources {
+		if namespacedName.Namespace != accessor.GetNamespace() || !spec.IsSubscribed(eventType) {
+			continue
+		}
+
+		clusterName := spec.ClusterName
+		if clusterName == "" {
+			clusterName = defaultClusterName
+		}
+		event := CloudEvent{
+			SpecVersion:     cloudEventSpecVersion,
+			ID:              uuid.New().String(),
+			Source:          fmt.Sprintf("/%s/%s/keda", clusterName, accessor.GetNamespace()),
+			Type:            string(eventType),
+			Subject:         fmt.Sprintf("/%s/%s/%s/%s", clusterName, accessor.GetNamespace(), strings.ToLower(e.getKind(object)), accessor.GetName()),
+			Time:            time.Now().UTC(),
+			DataContentType: "application/json",
+			Data:            CloudEventData{Reason: reason, Message: message},
+		}
+		select {
+		case e.queue <- cloudEventDelivery{cloudEventSource: namespacedName, uri: spec.Destination.HTTP.URI, event: event}:
+		default:
+			e.logger.Info("Dropping CloudEvent, too many CloudEvents are waiting to be sent", "cloudEventSource", namespacedName, "type", event.Type, "subject", event.Subject)
+		}
+	}
+}
+
+// NeedLeaderElection returns false, the CloudEvents emitted by any KEDA operator replica are sent
+func (e *EventEmitter) NeedLeaderElection() bool {
+	return false
+}
+
+// deliver sends the CloudEvent, it's sent again with an exponential backoff after the failures which may be temporary
+func (e *EventEmitter) deliver(ctx context.Context, delivery cloudEventDelivery) {
+	logger := e.logger.WithValues("cloudEventSource", delivery.cloudEventSource, "type", delivery.event.Type, "subject", delivery.event.Subject)
+
+	backoff := sendRetryBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := e.send(ctx, delivery.uri, delivery.event)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= maxSendAttempts {
+			logger.Error(err, "error sending CloudEvent", "attempts", attempt)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send posts the CloudEvent to the sink, it returns whether sending it again may succeed on error
+func (e *EventEmitter) send(ctx context.Context, uri string, event CloudEvent) (bool, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return false, fmt.Errorf("error marshaling CloudEvent: %s", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("error creating request to CloudEvent sink: %s", err)
+	}
+	req.Header.Set("Content-Type", cloudEventContentType)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return retry, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+// getKind returns the kind of the object, the objects retrieved from cache don't need to have their TypeMeta populated
+func (e *EventEmitter) getKind(object runtime.Object) string {
+	switch object.(type) {
+	case *kedav1alpha1.ScaledObject:
+		return "ScaledObject"
+	case *kedav1alpha1.ScaledJob:
+		return "ScaledJob"
+	}
+	if kind := object.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	if gvk, err := apiutil.GVKForObject(object, e.scheme); err == nil {
+		return gvk.Kind
+	}
+	return ""
+}
+
+func getCloudEventType(reason string) (kedav1alpha1.CloudEventType, bool) {
+	switch reason {
+	case eventreason.ScaledObjectReady:
+		return kedav1alpha1.CloudEventScaledObjectReady, true
+	case eventreason.ScaledObjectCheckFailed:
+		return kedav1alpha1.CloudEventScaledObjectFailed, true
+	case eventreason.KEDAScalerFailed:
+		return kedav1alpha1.CloudEventScalerFailed, true
+	case eventreason.KEDAScaleTargetFallbackEntered:
+		return kedav1alpha1.CloudEventScaledObjectFallbackEntered, true
+	case eventreason.KEDAScaleTargetFallbackExited:
+		return kedav1alpha1.CloudEventScaledObjectFallbackExited, true
+	case eventreason.KEDAScaleTargetDeactivated:
+		return kedav1alpha1.CloudEventScaledObjectScaledToZero, true
+	case eventreason.KEDAScaleTargetActivated:
+		return kedav1alpha1.CloudEventScaledObjectScaledFromZero, true
+	case eventreason.KEDAJobsCreated:
+		return kedav1alpha1.CloudEventScaledJobJobCreated, true
+	default:
+		return "", false
+	}
+}
+
+func validateCloudEventSourceSpec(spec *kedav1alpha1.CloudEventSourceSpec) error {
+	if spec.Destination.HTTP == nil || spec.Destination.HTTP.URI == "" {
+		return fmt.Errorf("no destination is defined, destination.http.uri must be set")
+	}
+	if _, err := url.ParseRequestURI(spec.Destination.HTTP.URI); err != nil {
+		return fmt.Errorf("invalid destination.http.uri: %s", err)
+	}
+	if len(spec.EventSubscription.IncludedEventTypes) > 0 && len(spec.EventSubscription.ExcludedEventTypes) > 0 {
+		return fmt.Errorf("includedEventTypes and excludedEventTypes can't be set both")
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemitter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+type cloudEventSourceSpecTestData struct {
+	name    string
+	spec    kedav1alpha1.CloudEventSourceSpec
+	isError bool
+}
+
+var cloudEventSourceSpecTestDataset = []cloudEventSourceSpecTestData{
+	{
+		name: "valid http destination",
+		spec: kedav1alpha1.CloudEventSourceSpec{Destination: kedav1alpha1.CloudEventDestination{HTTP: &kedav1alpha1.CloudEventHTTP{URI: "http://sink:8080"}}},
+	},
+	{
+		name:    "missing destination",
+		spec:    kedav1alpha1.CloudEventSourceSpec{},
+		isError: true,
+	},
+	{
+		name:    "invalid uri",
+		spec:    kedav1alpha1.CloudEventSourceSpec{Destination: kedav1alpha1.CloudEventDestination{HTTP: &kedav1alpha1.CloudEventHTTP{URI: "sink"}}},
+		isError: true,
+	},
+	{
+		name: "both included and excluded event types",
+		spec: kedav1alpha1.CloudEventSourceSpec{
+			Destination: kedav1alpha1.CloudEventDestination{HTTP: &kedav1alpha1.CloudEventHTTP{URI: "http://sink:8080"}},
+			EventSubscription: kedav1alpha1.CloudEventSubscription{
+				IncludedEventTypes: []kedav1alpha1.CloudEventType{kedav1alpha1.CloudEventScalerFailed},
+				ExcludedEventTypes: []kedav1alpha1.CloudEventType{kedav1alpha1.CloudEventScaledObjectReady},
+			},
+		},
+		isError: true,
+	},
+}
+
+func TestValidateCloudEventSourceSpec(t *testing.T) {
+	for _, testData := range cloudEventSourceSpecTestDataset {
+		spec := testData.spec
+		err := validateCloudEventSourceSpec(&spec)
+		if err != nil && !testData.isError {
+			t.Errorf("Test %q: expected success but got error %s", testData.name, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Test %q: expected error but got success", testData.name)
+		}
+	}
+}
+
+func TestEventEmitterSendsSubscribedCloudEvents(t *testing.T) {
+	events := make(chan CloudEvent, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, cloudEventContentType, r.Header.Get("Content-Type"))
+		event := CloudEvent{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events <- event
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	_ = kedav1alpha1.AddToScheme(scheme)
+	recorder := record.NewFakeRecorder(10)
+	emitter := NewEventEmitter(recorder, scheme, time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = emitter.Start(ctx) }()
+
+	err := emitter.RegisterCloudEventSource(&kedav1alpha1.CloudEventSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "test"},
+		Spec: kedav1alpha1.CloudEventSourceSpec{
+			ClusterName: "cluster",
+			Destination: kedav1alpha1.CloudEventDestination{HTTP: &kedav1alpha1.CloudEventHTTP{URI: server.URL}},
+			EventSubscription: kedav1alpha1.CloudEventSubscription{
+				ExcludedEventTypes: []kedav1alpha1.CloudEventType{kedav1alpha1.CloudEventScaledObjectReady},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	scaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: "test"}}
+	otherNamespaceScaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: "other"}}
+
+	// excluded event type, event of unsubscribed namespace and Kubernetes Event without CloudEvent type aren't emitted
+	emitter.Event(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectReady, "ScaledObject is ready for scaling")
+	emitter.Event(otherNamespaceScaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled")
+	emitter.Event(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScalersStarted, "Started scalers watch")
+	emitter.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled from %d to %d", 0, 1)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "1.0", event.SpecVersion)
+		assert.Equal(t, string(kedav1alpha1.CloudEventScaledObjectScaledFromZero), event.Type)
+		assert.Equal(t, "/cluster/test/keda", event.Source)
+		assert.Equal(t, "/cluster/test/scaledobject/so", event.Subject)
+		assert.Equal(t, "Scaled from 0 to 1", event.Data.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("CloudEvent wasn't emitted")
+	}
+	select {
+	case event := <-events:
+		t.Errorf("unexpected CloudEvent %s", event.Type)
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, 4, len(recorder.Events))
+
+	emitter.UnregisterCloudEventSource(types.NamespacedName{Name: "source", Namespace: "test"})
+	emitter.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "error")
+	select {
+	case event := <-events:
+		t.Errorf("unexpected CloudEvent %s", event.Type)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEventEmitterRetriesTemporaryFailures(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	emitter := NewEventEmitter(&record.FakeRecorder{}, runtime.NewScheme(), time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the CloudEvent is sent again after a server error, but not after a client error
+	emitter.deliver(ctx, cloudEventDelivery{uri: server.URL, event: CloudEvent{Type: string(kedav1alpha1.CloudEventScalerFailed)}})
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestEventEmitterDropsCloudEventsWhenQueueIsFull(t *testing.T) {
+	emitter := NewEventEmitter(&record.FakeRecorder{}, runtime.NewScheme(), time.Second)
+	err := emitter.RegisterCloudEventSource(&kedav1alpha1.CloudEventSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "test"},
+		Spec:       kedav1alpha1.CloudEventSourceSpec{Destination: kedav1alpha1.CloudEventDestination{HTTP: &kedav1alpha1.CloudEventHTTP{URI: "http://sink:8080"}}},
+	})
+	assert.NoError(t, err)
+
+	scaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: "test"}}
+	for i := 0; i < cloudEventQueueSize+1; i++ {
+		emitter.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "error")
+	}
+	assert.Equal(t, cloudEventQueueSize, len(emitter.queue))
+}
@@ -29,6 +29,18 @@ const (
 	// ScaledJobCheckFailed is for event when ScaledJob validation check fails
 	ScaledJobCheckFailed = "ScaledJobCheckFailed"
 
+	// ScaledObjectPaused is for event when the scaling of ScaledObject is paused
+	ScaledObjectPaused = "ScaledObjectPaused"
+
+	// ScaledObjectResumed is for event when the scaling of paused ScaledObject is resumed
+	ScaledObjectResumed = "ScaledObjectResumed"
+
+	// ScaledJobPaused is for event when the scaling of ScaledJob is paused
+	ScaledJobPaused = "ScaledJobPaused"
+
+	// ScaledJobResumed is for event when the scaling of paused ScaledJob is resumed
+	ScaledJobResumed = "ScaledJobResumed"
+
 	// ScaledObjectDeleted is for event when ScaledObject is deleted
 	ScaledObjectDeleted = "ScaledObjectDeleted"
 
@@ -56,9 +68,21 @@ const (
 	// KEDAScaleTargetDeactivationFailed is for event when the deactivation of the scale target for ScaledObject fails
 	KEDAScaleTargetDeactivationFailed = "KEDAScaleTargetDeactivationFailed"
 
+	// KEDAScaleTargetFallbackEntered is for event when the scale target of ScaledObject was scaled to the fallback replicas
+	KEDAScaleTargetFallbackEntered = "KEDAScaleTargetFallbackEntered"
+
+	// KEDAScaleTargetFallbackExited is for event when the triggers of ScaledObject in fallback recovered
+	KEDAScaleTargetFallbackExited = "KEDAScaleTargetFallbackExited"
+
 	// KEDAJobsCreated is for event when jobs for ScaledJob are created
 	KEDAJobsCreated = "KEDAJobsCreated"
 
+	// ScaledJobDegraded is for event when the creation of jobs for ScaledJob is stopped because the jobs keep failing
+	ScaledJobDegraded = "ScaledJobDegraded"
+
+	// ScaledJobRecovered is for event when the jobs for degraded ScaledJob succeed again
+	ScaledJobRecovered = "ScaledJobRecovered"
+
 	// TriggerAuthenticationDeleted is for event when a TriggerAuthentication is deleted
 	TriggerAuthenticationDeleted = "TriggerAuthenticationDeleted"
 
@@ -28,6 +28,10 @@ type FakeKedaV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeKedaV1alpha1) CloudEventSources(namespace string) v1alpha1.CloudEventSourceInterface {
+	return &FakeCloudEventSources{c, namespace}
+}
+
 func (c *FakeKedaV1alpha1) ClusterTriggerAuthentications() v1alpha1.ClusterTriggerAuthenticationInterface {
 	return &FakeClusterTriggerAuthentications{c}
 }
@@ -44,6 +48,10 @@ func (c *FakeKedaV1alpha1) TriggerAuthentications(namespace string) v1alpha1.Tri
 	return &FakeTriggerAuthentications{c, namespace}
 }
 
+func (c *FakeKedaV1alpha1) TriggerAuthenticationGrants(namespace string) v1alpha1.TriggerAuthenticationGrantInterface {
+	return &FakeTriggerAuthenticationGrants{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKedaV1alpha1) RESTClient() rest.Interface {
@@ -18,6 +18,8 @@ limitations under the License.
 
 package v1alpha1
 
+type CloudEventSourceExpansion interface{}
+
 type ClusterTriggerAuthenticationExpansion interface{}
 
 type ScaledJobExpansion interface{}
@@ -25,3 +27,5 @@ type ScaledJobExpansion interface{}
 type ScaledObjectExpansion interface{}
 
 type TriggerAuthenticationExpansion interface{}
+
+type TriggerAuthenticationGrantExpansion interface{}
@@ -28,10 +28,12 @@ import (
 
 type KedaV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	CloudEventSourcesGetter
 	ClusterTriggerAuthenticationsGetter
 	ScaledJobsGetter
 	ScaledObjectsGetter
 	TriggerAuthenticationsGetter
+	TriggerAuthenticationGrantsGetter
 }
 
 // KedaV1alpha1Client is used to interact with features provided by the keda group.
@@ -39,6 +41,10 @@ type KedaV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *KedaV1alpha1Client) CloudEventSources(namespace string) CloudEventSourceInterface {
+	return newCloudEventSources(c, namespace)
+}
+
 func (c *KedaV1alpha1Client) ClusterTriggerAuthentications() ClusterTriggerAuthenticationInterface {
 	return newClusterTriggerAuthentications(c)
 }
@@ -55,6 +61,10 @@ func (c *KedaV1alpha1Client) TriggerAuthentications(namespace string) TriggerAut
 	return newTriggerAuthentications(c, namespace)
 }
 
+func (c *KedaV1alpha1Client) TriggerAuthenticationGrants(namespace string) TriggerAuthenticationGrantInterface {
+	return newTriggerAuthenticationGrants(c, namespace)
+}
+
 // NewForConfig creates a new KedaV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -40,6 +40,7 @@ type TriggerAuthenticationsGetter interface {
 type TriggerAuthenticationInterface interface {
 	Create(ctx context.Context, triggerAuthentication *v1alpha1.TriggerAuthentication, opts v1.CreateOptions) (*v1alpha1.TriggerAuthentication, error)
 	Update(ctx context.Context, triggerAuthentication *v1alpha1.TriggerAuthentication, opts v1.UpdateOptions) (*v1alpha1.TriggerAuthentication, error)
+	UpdateStatus(ctx context.Context, triggerAuthentication *v1alpha1.TriggerAuthentication, opts v1.UpdateOptions) (*v1alpha1.TriggerAuthentication, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.TriggerAuthentication, error)
@@ -135,6 +136,22 @@ func (c *triggerAuthentications) Update(ctx context.Context, triggerAuthenticati
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *triggerAuthentications) UpdateStatus(ctx context.Context, triggerAuthentication *v1alpha1.TriggerAuthentication, opts v1.UpdateOptions) (result *v1alpha1.TriggerAuthentication, err error) {
+	result = &v1alpha1.TriggerAuthentication{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("triggerauthentications").
+		Name(triggerAuthentication.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(triggerAuthentication).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the triggerAuthentication and deletes it. Returns an error if one occurs.
 func (c *triggerAuthentications) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
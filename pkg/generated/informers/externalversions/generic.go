@@ -53,6 +53,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=keda, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("cloudeventsources"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().CloudEventSources().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clustertriggerauthentications"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ClusterTriggerAuthentications().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("scaledjobs"):
@@ -61,6 +63,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScaledObjects().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("triggerauthentications"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().TriggerAuthentications().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("triggerauthenticationgrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().TriggerAuthenticationGrants().Informer()}, nil
 
 	}
 
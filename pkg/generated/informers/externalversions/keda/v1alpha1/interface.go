@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// CloudEventSources returns a CloudEventSourceInformer.
+	CloudEventSources() CloudEventSourceInformer
 	// ClusterTriggerAuthentications returns a ClusterTriggerAuthenticationInformer.
 	ClusterTriggerAuthentications() ClusterTriggerAuthenticationInformer
 	// ScaledJobs returns a ScaledJobInformer.
@@ -32,6 +34,8 @@ type Interface interface {
 	ScaledObjects() ScaledObjectInformer
 	// TriggerAuthentications returns a TriggerAuthenticationInformer.
 	TriggerAuthentications() TriggerAuthenticationInformer
+	// TriggerAuthenticationGrants returns a TriggerAuthenticationGrantInformer.
+	TriggerAuthenticationGrants() TriggerAuthenticationGrantInformer
 }
 
 type version struct {
@@ -45,6 +49,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// CloudEventSources returns a CloudEventSourceInformer.
+func (v *version) CloudEventSources() CloudEventSourceInformer {
+	return &cloudEventSourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterTriggerAuthentications returns a ClusterTriggerAuthenticationInformer.
 func (v *version) ClusterTriggerAuthentications() ClusterTriggerAuthenticationInformer {
 	return &clusterTriggerAuthenticationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -64,3 +73,8 @@ func (v *version) ScaledObjects() ScaledObjectInformer {
 func (v *version) TriggerAuthentications() TriggerAuthenticationInformer {
 	return &triggerAuthenticationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// TriggerAuthenticationGrants returns a TriggerAuthenticationGrantInformer.
+func (v *version) TriggerAuthenticationGrants() TriggerAuthenticationGrantInformer {
+	return &triggerAuthenticationGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// CloudEventSourceListerExpansion allows custom methods to be added to
+// CloudEventSourceLister.
+type CloudEventSourceListerExpansion interface{}
+
+// CloudEventSourceNamespaceListerExpansion allows custom methods to be added to
+// CloudEventSourceNamespaceLister.
+type CloudEventSourceNamespaceListerExpansion interface{}
+
 // ClusterTriggerAuthenticationListerExpansion allows custom methods to be added to
 // ClusterTriggerAuthenticationLister.
 type ClusterTriggerAuthenticationListerExpansion interface{}
@@ -45,3 +53,11 @@ type TriggerAuthenticationListerExpansion interface{}
 // TriggerAuthenticationNamespaceListerExpansion allows custom methods to be added to
 // TriggerAuthenticationNamespaceLister.
 type TriggerAuthenticationNamespaceListerExpansion interface{}
+
+// TriggerAuthenticationGrantListerExpansion allows custom methods to be added to
+// TriggerAuthenticationGrantLister.
+type TriggerAuthenticationGrantListerExpansion interface{}
+
+// TriggerAuthenticationGrantNamespaceListerExpansion allows custom methods to be added to
+// TriggerAuthenticationGrantNamespaceLister.
+type TriggerAuthenticationGrantNamespaceListerExpansion interface{}
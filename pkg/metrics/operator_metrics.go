@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Types of the scalable objects reported in the type label
+const (
+	ScaledObjectType = "ScaledObject"
+	ScaledJobType    = "ScaledJob"
+)
+
+// Events of the ScaledObject scale target recorded by KEDA Operator
+const (
+	ScaleEventActivated   = "activated"
+	ScaleEventDeactivated = "deactivated"
+	ScaleEventFallback    = "fallback"
+	ScaleEventMinReplicas = "min_replicas"
+	ScaleEventPaused      = "paused"
+)
+
+// scaleEvents are the values of the event label, so the series of a deleted ScaledObject can be removed
+var scaleEvents = []string{ScaleEventActivated, ScaleEventDeactivated, ScaleEventFallback, ScaleEventMinReplicas, ScaleEventPaused}
+
+var (
+	scalableObjectLabels = []string{"namespace", "type", "name"}
+	operatorScalerLabels = []string{"namespace", "type", "name", "scaler", "scalerIndex"}
+	scaleLoopLatency     = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scale_loop",
+			Name:      "latency_seconds",
+			Help:      "Duration of a single check of all scalers of a ScaledObject or ScaledJob",
+			Buckets:   prometheus.DefBuckets,
+		},
+		scalableObjectLabels,
+	)
+	scalerLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scaler",
+			Name:      "latency_seconds",
+			Help:      "Duration of retrieving the activity of a scaler",
+			Buckets:   prometheus.DefBuckets,
+		},
+		operatorScalerLabels,
+	)
+	scalerActive = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scaler",
+			Name:      "active",
+			Help:      "Activity of a scaler, 1 if the scaler is active and 0 if it is not",
+		},
+		operatorScalerLabels,
+	)
+	scaledJobJobs = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scaled_job",
+			Name:      "jobs",
+			Help:      "Number of Jobs of a ScaledJob in the running or pending state",
+		},
+		[]string{"namespace", "scaledJob", "state"},
+	)
+	scaledJobJobsCreated = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scaled_job",
+			Name:      "jobs_created_total",
+			Help:      "Total number of Jobs created for a ScaledJob",
+		},
+		[]string{"namespace", "scaledJob"},
+	)
+	scaledJobJobsSkipped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scaled_job",
+			Name:      "jobs_skipped_total",
+			Help:      "Total number of Jobs requested by the scalers of a ScaledJob, but not created because of the effective maximum scale or an error",
+		},
+		[]string{"namespace", "scaledJob"},
+	)
+	scaledObjectScaleEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scaled_object",
+			Name:      "scale_events_total",
+			Help:      "Total number of replica count changes applied on the scale target of a ScaledObject by KEDA Operator",
+		},
+		[]string{"namespace", "scaledObject", "event"},
+	)
+	scaledObjectReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scaled_object",
+			Name:      "replicas",
+			Help:      "Replica count applied on the scale target of a ScaledObject by the last scale event of KEDA Operator",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+
+	// recordedScalerLabels tracks the scaler series of each ScaledObject and ScaledJob, so they can be removed on deletion
+	recordedScalerLabels     = map[string]map[string]prometheus.Labels{}
+	recordedScalerLabelsLock sync.Mutex
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(scaleLoopLatency)
+	ctrlmetrics.Registry.MustRegister(scalerLatency)
+	ctrlmetrics.Registry.MustRegister(scalerActive)
+	ctrlmetrics.Registry.MustRegister(scaledJobJobs)
+	ctrlmetrics.Registry.MustRegister(scaledJobJobsCreated)
+	ctrlmetrics.Registry.MustRegister(scaledJobJobsSkipped)
+	ctrlmetrics.Registry.MustRegister(scaledObjectScaleEvents)
+	ctrlmetrics.Registry.MustRegister(scaledObjectReplicas)
+}
+
+// RecordScaleLoopLatency records the duration of a single check of all scalers of a ScaledObject or ScaledJob
+func RecordScaleLoopLatency(namespace string, scalableObjectType string, name string, latency time.Duration) {
+	scaleLoopLatency.With(prometheus.Labels{"namespace": namespace, "type": scalableObjectType, "name": name}).Observe(latency.Seconds())
+}
+
+// RecordScalerLatency records the duration of retrieving the activity of a scaler
+func RecordScalerLatency(namespace string, scalableObjectType string, name string, scaler string, scalerIndex int, latency time.Duration) {
+	labels := getOperatorScalerLabels(namespace, scalableObjectType, name, scaler, scalerIndex)
+	scalerLatency.With(labels).Observe(latency.Seconds())
+}
+
+// RecordScalerActive records whether a scaler is active
+func RecordScalerActive(namespace string, scalableObjectType string, name string, scaler string, scalerIndex int, active bool) {
+	value := float64(0)
+	if active {
+		value = 1
+	}
+	scalerActive.With(getOperatorScalerLabels(namespace, scalableObjectType, name, scaler, scalerIndex)).Set(value)
+}
+
+// RecordScaledJobJobs records the number of running and pending Jobs of a ScaledJob
+func RecordScaledJobJobs(namespace string, scaledJob string, running int64, pending int64) {
+	scaledJobJobs.With(prometheus.Labels{"namespace": namespace, "scaledJob": scaledJob, "state": "running"}).Set(float64(running))
+	scaledJobJobs.With(prometheus.Labels{"namespace": namespace, "scaledJob": scaledJob, "state": "pending"}).Set(float64(pending))
+}
+
+// RecordScaledJobJobsCreated counts the Jobs created and the Jobs skipped for a ScaledJob in a single scale
+func RecordScaledJobJobsCreated(namespace string, scaledJob string, created int64, skipped int64) {
+	labels := prometheus.Labels{"namespace": namespace, "scaledJob": scaledJob}
+	scaledJobJobsCreated.With(labels).Add(float64(created))
+	scaledJobJobsSkipped.With(labels).Add(float64(skipped))
+}
+
+// RecordScaleEvent counts the scale events of a ScaledObject and records the replica count applied on the scale target
+func RecordScaleEvent(namespace string, scaledObject string, event string, replicas int32) {
+	scaledObjectScaleEvents.With(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "event": event}).Inc()
+	scaledObjectReplicas.With(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject}).Set(float64(replicas))
+}
+
+// DeleteScalableObjectMetrics removes the series of a deleted ScaledObject or ScaledJob, so they aren't reported anymore
+func DeleteScalableObjectMetrics(namespace string, scalableObjectType string, name string) {
+	recordedScalerLabelsLock.Lock()
+	key := scalableObjectType + "/" + namespace + "/" + name
+	for _, labels := range recordedScalerLabels[key] {
+		scalerActive.Delete(labels)
+		scalerLatency.Delete(labels)
+	}
+	delete(recordedScalerLabels, key)
+	recordedScalerLabelsLock.Unlock()
+
+	scaleLoopLatency.Delete(prometheus.Labels{"namespace": namespace, "type": scalableObjectType, "name": name})
+	switch scalableObjectType {
+	case ScaledObjectType:
+		scaledObjectReplicas.Delete(prometheus.Labels{"namespace": namespace, "scaledObject": name})
+		for _, event := range scaleEvents {
+			scaledObjectScaleEvents.Delete(prometheus.Labels{"namespace": namespace, "scaledObject": name, "event": event})
+		}
+	case ScaledJobType:
+		scaledJobJobs.Delete(prometheus.Labels{"namespace": namespace, "scaledJob": name, "state": "running"})
+		scaledJobJobs.Delete(prometheus.Labels{"namespace": namespace, "scaledJob": name, "state": "pending"})
+		scaledJobJobsCreated.Delete(prometheus.Labels{"namespace": namespace, "scaledJob": name})
+		scaledJobJobsSkipped.Delete(prometheus.Labels{"namespace": namespace, "scaledJob": name})
+	}
+}
+
+func getOperatorScalerLabels(namespace string, scalableObjectType string, name string, scaler string, scalerIndex int) prometheus.Labels {
+	labels := prometheus.Labels{"namespace": namespace, "type": scalableObjectType, "name": name, "scaler": scaler, "scalerIndex": strconv.Itoa(scalerIndex)}
+
+	recordedScalerLabelsLock.Lock()
+	defer recordedScalerLabelsLock.Unlock()
+	key := scalableObjectType + "/" + namespace + "/" + name
+	if _, ok := recordedScalerLabels[key]; !ok {
+		recordedScalerLabels[key] = map[string]prometheus.Labels{}
+	}
+	recordedScalerLabels[key][scaler+"/"+labels["scalerIndex"]] = labels
+	return labels
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperatorMetricsAreDeletedWithScalableObject(t *testing.T) {
+	RecordScaleLoopLatency("test", ScaledObjectType, "so", time.Second)
+	RecordScalerLatency("test", ScaledObjectType, "so", "prometheusScaler", 0, time.Second)
+	RecordScalerActive("test", ScaledObjectType, "so", "prometheusScaler", 0, true)
+	RecordScalerActive("test", ScaledObjectType, "so", "cronScaler", 1, false)
+	RecordScaleEvent("test", "so", ScaleEventFallback, 1)
+	RecordScaleEvent("test", "so", ScaleEventActivated, 3)
+	RecordScalerActive("test", ScaledJobType, "sj", "prometheusScaler", 0, true)
+	RecordScaledJobJobs("test", "sj", 2, 1)
+	RecordScaledJobJobsCreated("test", "sj", 2, 1)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(scalerActive.WithLabelValues("test", ScaledObjectType, "so", "prometheusScaler", "0")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(scalerActive.WithLabelValues("test", ScaledObjectType, "so", "cronScaler", "1")))
+	assert.Equal(t, 3.0, testutil.ToFloat64(scaledObjectReplicas.WithLabelValues("test", "so")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(scaledObjectScaleEvents.WithLabelValues("test", "so", ScaleEventActivated)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(scaledJobJobs.WithLabelValues("test", "sj", "running")))
+	assert.Equal(t, 3, testutil.CollectAndCount(scalerActive))
+
+	DeleteScalableObjectMetrics("test", ScaledObjectType, "so")
+
+	// only the series of the ScaledJob remain
+	assert.Equal(t, 1, testutil.CollectAndCount(scalerActive))
+	assert.Equal(t, 0, testutil.CollectAndCount(scaledObjectReplicas))
+	assert.Equal(t, 0, testutil.CollectAndCount(scaleLoopLatency))
+	assert.Equal(t, 0, testutil.CollectAndCount(scaledObjectScaleEvents))
+	assert.Equal(t, 2, testutil.CollectAndCount(scaledJobJobs))
+	assert.Equal(t, 1, testutil.CollectAndCount(scaledJobJobsCreated))
+	assert.Equal(t, 1, testutil.CollectAndCount(scaledJobJobsSkipped))
+
+	DeleteScalableObjectMetrics("test", ScaledJobType, "sj")
+	assert.Equal(t, 0, testutil.CollectAndCount(scalerActive))
+	assert.Equal(t, 0, testutil.CollectAndCount(scaledJobJobs))
+	assert.Equal(t, 0, testutil.CollectAndCount(scaledJobJobsCreated))
+	assert.Equal(t, 0, testutil.CollectAndCount(scaledJobJobsSkipped))
+}
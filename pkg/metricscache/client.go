@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricscache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Client reads cached metrics from KEDA Operator
+type Client struct {
+	address    string
+	tokenFile  string
+	httpClient *http.Client
+}
+
+// NewClient creates Client for KEDA Operator listening on the address, eg. https://keda-operator.keda.svc:9666,
+// requests are authenticated with the service account token bound to TokenAudience read from the tokenFile.
+// As only the leader of KEDA Operator caches metrics, the requests are sent to the leader found with the leader election
+// Lease in the namespace of KEDA Operator, and its certificate is verified for the host of the address with the CA read from the caFile
+func NewClient(address, tokenFile, caFile, namespace string, reader client.Reader, timeout time.Duration) (*Client, error) {
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the CA of KEDA Operator: %s", err)
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificate found in the CA of KEDA Operator %s", caFile)
+	}
+
+	dialer := &leaderDialer{reader: reader, namespace: namespace, dialer: &net.Dialer{Timeout: timeout}}
+	transport := &http.Transport{
+		DialContext:     dialer.DialContext,
+		TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+	}
+	return &Client{
+		address:    strings.TrimSuffix(address, "/"),
+		tokenFile:  tokenFile,
+		httpClient: &http.Client{Timeout: timeout, Transport: transport},
+	}, nil
+}
+
+// GetMetrics returns cached metrics of the ScaledObject trigger
+func (c *Client) GetMetrics(ctx context.Context, namespace, scaledObjectName, metricName string) ([]external_metrics.ExternalMetricValue, error) {
+	query := url.Values{}
+	query.Set("namespace", namespace)
+	query.Set("scaledObject", scaledObjectName)
+	query.Set("metricName", metricName)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?%s", c.address, CachedMetricsPath, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// the token is read on each request, as the kubelet rotates projected service account tokens
+	token, err := os.ReadFile(c.tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading service account token: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error getting cached metric %s, KEDA Operator responded with status code %d", metricName, resp.StatusCode)
+	}
+
+	var metrics []external_metrics.ExternalMetricValue
+	if err := json.NewDecoder(resp.Body).Decode(&metrics); err != nil {
+		return nil, fmt.Errorf("error decoding cached metric %s: %s", metricName, err)
+	}
+	return metrics, nil
+}
+
+// leaderDialer dials the pod of the leader of KEDA Operator on the port of the address, the leader is looked up
+// when a connection is opened, so the connections kept alive don't query the Kubernetes API on each request
+type leaderDialer struct {
+	reader    client.Reader
+	namespace string
+	dialer    *net.Dialer
+}
+
+func (d *leaderDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	leaderIP, err := d.getLeaderIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return d.dialer.DialContext(ctx, network, net.JoinHostPort(leaderIP, port))
+}
+
+// getLeaderIP returns the IP of the pod holding the leader election Lease of KEDA Operator
+func (d *leaderDialer) getLeaderIP(ctx context.Context) (string, error) {
+	lease := &coordinationv1.Lease{}
+	if err := d.reader.Get(ctx, types.NamespacedName{Namespace: d.namespace, Name: OperatorLeaderElectionID}, lease); err != nil {
+		return "", fmt.Errorf("error getting the leader of KEDA Operator: %s", err)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+		return "", fmt.Errorf("KEDA Operator has no leader")
+	}
+
+	// the identity of the leader is the name of its pod followed by a unique suffix
+	podName := strings.SplitN(*lease.Spec.HolderIdentity, "_", 2)[0]
+	pod := &corev1.Pod{}
+	if err := d.reader.Get(ctx, types.NamespacedName{Namespace: d.namespace, Name: podName}, pod); err != nil {
+		return "", fmt.Errorf("error getting the pod of the leader of KEDA Operator: %s", err)
+	}
+	if pod.Status.PodIP == "" {
+		return "", fmt.Errorf("the pod %s of the leader of KEDA Operator has no IP", podName)
+	}
+	return pod.Status.PodIP, nil
+}
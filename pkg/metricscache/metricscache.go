@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricscache
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/metrics/pkg/apis/external_metrics"
+)
+
+// MetricsRecord holds metric values of a trigger obtained during the last polling of the ScaledObject
+type MetricsRecord struct {
+	Metrics   []external_metrics.ExternalMetricValue
+	ExpiresAt time.Time
+}
+
+// MetricsCache stores MetricsRecords for ScaledObjects, records are indexed by ScaledObject identifier and metric name
+type MetricsCache struct {
+	metricRecords map[string]map[string]MetricsRecord
+	lock          *sync.RWMutex
+}
+
+// NewMetricsCache creates an empty MetricsCache
+func NewMetricsCache() *MetricsCache {
+	return &MetricsCache{
+		metricRecords: map[string]map[string]MetricsRecord{},
+		lock:          &sync.RWMutex{},
+	}
+}
+
+// ReadRecord returns the record for the metric of the ScaledObject, expired records are not returned
+func (mc *MetricsCache) ReadRecord(scaledObjectIdentifier, metricName string) (MetricsRecord, bool) {
+	mc.lock.RLock()
+	defer mc.lock.RUnlock()
+	record, ok := mc.metricRecords[scaledObjectIdentifier][metricName]
+	if !ok || time.Now().After(record.ExpiresAt) {
+		return MetricsRecord{}, false
+	}
+	return record, true
+}
+
+// StoreRecords replaces all records of the ScaledObject
+func (mc *MetricsCache) StoreRecords(scaledObjectIdentifier string, metricsRecords map[string]MetricsRecord) {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+	if len(metricsRecords) == 0 {
+		delete(mc.metricRecords, scaledObjectIdentifier)
+		return
+	}
+	mc.metricRecords[scaledObjectIdentifier] = metricsRecords
+}
+
+// Delete removes all records of the ScaledObject
+func (mc *MetricsCache) Delete(scaledObjectIdentifier string) {
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+	delete(mc.metricRecords, scaledObjectIdentifier)
+}
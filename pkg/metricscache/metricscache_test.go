@@ -0,0 +1,246 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricscache
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+)
+
+func TestMetricsCache(t *testing.T) {
+	mc := NewMetricsCache()
+	id := ScaledObjectIdentifier("default", "so")
+	metrics := []external_metrics.ExternalMetricValue{{MetricName: "s0-queue", Value: *resource.NewQuantity(5, resource.DecimalSI)}}
+
+	mc.StoreRecords(id, map[string]MetricsRecord{
+		"s0-queue":   {Metrics: metrics, ExpiresAt: time.Now().Add(time.Minute)},
+		"s1-expired": {Metrics: metrics, ExpiresAt: time.Now().Add(-time.Minute)},
+	})
+
+	record, ok := mc.ReadRecord(id, "s0-queue")
+	assert.True(t, ok)
+	assert.Equal(t, metrics, record.Metrics)
+
+	_, ok = mc.ReadRecord(id, "s1-expired")
+	assert.False(t, ok)
+
+	_, ok = mc.ReadRecord(id, "s2-unknown")
+	assert.False(t, ok)
+
+	mc.Delete(id)
+	_, ok = mc.ReadRecord(id, "s0-queue")
+	assert.False(t, ok)
+}
+
+func TestClientReadsFromHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mc := NewMetricsCache()
+	mc.StoreRecords(ScaledObjectIdentifier("default", "so"), map[string]MetricsRecord{
+		"s0-queue": {
+			Metrics:   []external_metrics.ExternalMetricValue{{MetricName: "s0-queue", Value: *resource.NewQuantity(5, resource.DecimalSI)}},
+			ExpiresAt: time.Now().Add(time.Minute),
+		},
+	})
+
+	kubeClient := mock_client.NewMockClient(ctrl)
+	mockReviews(kubeClient, "keda-token", map[string]bool{"default": true})
+
+	server := httptest.NewTLSServer(NewHandler(mc, kubeClient))
+	defer server.Close()
+	client := newTestClient(t, server, "keda-token")
+
+	metrics, err := client.GetMetrics(context.TODO(), "default", "so", "s0-queue")
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, "s0-queue", metrics[0].MetricName)
+	assert.Equal(t, int64(5), metrics[0].Value.Value())
+
+	_, err = client.GetMetrics(context.TODO(), "default", "so", "s1-unknown")
+	assert.Error(t, err)
+
+	// the caller isn't allowed to get ScaledObjects in the namespace
+	_, err = client.GetMetrics(context.TODO(), "other", "so", "s0-queue")
+	assert.Error(t, err)
+}
+
+func TestHandlerRejectsUnauthenticatedRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mc := NewMetricsCache()
+	mc.StoreRecords(ScaledObjectIdentifier("default", "so"), map[string]MetricsRecord{
+		"s0-queue": {
+			Metrics:   []external_metrics.ExternalMetricValue{{MetricName: "s0-queue", Value: *resource.NewQuantity(5, resource.DecimalSI)}},
+			ExpiresAt: time.Now().Add(time.Minute),
+		},
+	})
+
+	kubeClient := mock_client.NewMockClient(ctrl)
+	mockReviews(kubeClient, "keda-token", map[string]bool{"default": true})
+
+	server := httptest.NewTLSServer(NewHandler(mc, kubeClient))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL + CachedMetricsPath + "?namespace=default&scaledObject=so&metricName=s0-queue")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	_, err = newTestClient(t, server, "other-token").GetMetrics(context.TODO(), "default", "so", "s0-queue")
+	assert.Error(t, err)
+}
+
+func TestHandlerCachesReviewsUntilTokenExpires(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mc := NewMetricsCache()
+	mc.StoreRecords(ScaledObjectIdentifier("default", "so"), map[string]MetricsRecord{
+		"s0-queue": {
+			Metrics:   []external_metrics.ExternalMetricValue{{MetricName: "s0-queue", Value: *resource.NewQuantity(5, resource.DecimalSI)}},
+			ExpiresAt: time.Now().Add(time.Minute),
+		},
+	})
+
+	token := newTestToken(time.Now().Add(time.Hour))
+	kubeClient := mock_client.NewMockClient(ctrl)
+	reviews := mockReviews(kubeClient, token, map[string]bool{"default": true})
+
+	server := httptest.NewTLSServer(NewHandler(mc, kubeClient))
+	defer server.Close()
+	client := newTestClient(t, server, token)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetMetrics(context.TODO(), "default", "so", "s0-queue")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(reviews), "the token is only reviewed by the first request")
+
+	// the user authenticated with the token is reviewed again for another ScaledObject
+	_, err := client.GetMetrics(context.TODO(), "default", "other-so", "s0-queue")
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(reviews))
+}
+
+func TestGetTokenExpiration(t *testing.T) {
+	now := time.Now()
+	exp := now.Add(time.Hour).Truncate(time.Second)
+
+	assert.True(t, exp.Equal(getTokenExpiration(newTestToken(exp), now)))
+	assert.True(t, now.Add(defaultReviewTTL).Equal(getTokenExpiration("opaque-token", now)))
+}
+
+func TestClientFailsWithoutLeader(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client, err := NewClient(server.URL, writeTokenFile(t, "keda-token"), writeCAFile(t, server), "keda", fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), time.Second)
+	assert.NoError(t, err)
+	_, err = client.GetMetrics(context.TODO(), "default", "so", "s0-queue")
+	assert.ErrorContains(t, err, "error getting the leader of KEDA Operator")
+}
+
+// mockReviews authenticates the token bound to TokenAudience, and allows to get the ScaledObject "so" in the namespaces.
+// It returns the counter of the reviews
+func mockReviews(kubeClient *mock_client.MockClient, validToken string, allowedNamespaces map[string]bool) *int32 {
+	var reviews int32
+	kubeClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, obj runtimeclient.Object, _ ...runtimeclient.CreateOption) error {
+		atomic.AddInt32(&reviews, 1)
+		switch review := obj.(type) {
+		case *authenticationv1.TokenReview:
+			review.Status.Authenticated = review.Spec.Token == validToken && len(review.Spec.Audiences) == 1 && review.Spec.Audiences[0] == TokenAudience
+			review.Status.User = authenticationv1.UserInfo{Username: "system:serviceaccount:keda:keda-operator"}
+		case *authorizationv1.SubjectAccessReview:
+			review.Status.Allowed = allowedNamespaces[review.Spec.ResourceAttributes.Namespace] && review.Spec.ResourceAttributes.Name == "so"
+		}
+		return nil
+	}).AnyTimes()
+	return &reviews
+}
+
+// newTestClient creates Client for the server, the server is the leader of KEDA Operator in the keda namespace
+func newTestClient(t *testing.T, server *httptest.Server, token string) *Client {
+	reader := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "keda", Name: OperatorLeaderElectionID},
+			Spec:       coordinationv1.LeaseSpec{HolderIdentity: stringPtr("keda-operator-6b8c9_0f1e2d3c")},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "keda", Name: "keda-operator-6b8c9"},
+			Status:     corev1.PodStatus{PodIP: "127.0.0.1"},
+		},
+	).Build()
+
+	client, err := NewClient(server.URL, writeTokenFile(t, token), writeCAFile(t, server), "keda", reader, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func writeCAFile(t *testing.T, server *httptest.Server) string {
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, ca, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return caFile
+}
+
+// newTestToken returns an unsigned JWT expiring at exp
+func newTestToken(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"aud":["%s"],"exp":%d}`, TokenAudience, exp.Unix())))
+	return "eyJhbGciOiJub25lIn0." + payload + ".signature"
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func writeTokenFile(t *testing.T, token string) string {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte(token), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return tokenFile
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricscache
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// CachedMetricsPath is the path on which KEDA Operator serves cached metrics to KEDA Metrics Server
+	CachedMetricsPath = "/cached-metrics"
+
+	// TokenAudience is the audience of the service account token KEDA Metrics Server authenticates with to KEDA Operator,
+	// the token is bound to KEDA Operator so it can't be used against the Kubernetes API
+	TokenAudience = "keda-operator"
+
+	// OperatorLeaderElectionID is the name of the Lease of KEDA Operator leader election,
+	// the cached metrics are only served by the leader as only the leader runs the scale loops
+	OperatorLeaderElectionID = "operator.keda.sh"
+
+	// defaultReviewTTL is how long the reviews of a bearer token without expiration are cached
+	defaultReviewTTL = 5 * time.Minute
+)
+
+var log = logf.Log.WithName("metricscache")
+
+// ScaledObjectIdentifier returns identifier of the ScaledObject used as a key in MetricsCache
+func ScaledObjectIdentifier(namespace, name string) string {
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+// ScaledObjectMetricSelector returns the selector of the external metrics the HPA of the ScaledObject queries,
+// only metrics queried with this selector are cached
+func ScaledObjectMetricSelector(name string) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{"scaledobject.keda.sh/name": name})
+}
+
+// NewHandler returns http.Handler serving metrics stored in the MetricsCache. Requests are authenticated with a service
+// account token bound to TokenAudience, and the caller has to be allowed to get the ScaledObject the metrics belong to.
+// The reviews of the token are cached until the token expires
+func NewHandler(mc *MetricsCache, kubeClient client.Client) http.Handler {
+	reviews := newReviewCache()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := r.URL.Query()
+		namespace, name, metricName := query.Get("namespace"), query.Get("scaledObject"), query.Get("metricName")
+		if namespace == "" || name == "" || metricName == "" {
+			http.Error(w, "namespace, scaledObject and metricName query parameters are required", http.StatusBadRequest)
+			return
+		}
+
+		if status, err := reviews.authorize(r, kubeClient, namespace, name); err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		record, ok := mc.ReadRecord(ScaledObjectIdentifier(namespace, name), metricName)
+		if !ok {
+			http.Error(w, fmt.Sprintf("no cached metric %s found for ScaledObject %s/%s", metricName, namespace, name), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(record.Metrics); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// reviewCache caches the TokenReviews and the allowed SubjectAccessReviews of the bearer tokens until the tokens expire,
+// so the requests of KEDA Metrics Server aren't reviewed with the Kubernetes API on each poll of the HPAs.
+// The tokens are indexed by their hash, so they aren't kept in memory
+type reviewCache struct {
+	lock sync.Mutex
+	// users are the users authenticated with the tokens
+	users map[string]cachedUser
+	// decisions are the expirations of the tokens allowed to get the ScaledObjects
+	decisions map[string]time.Time
+}
+
+type cachedUser struct {
+	user      authenticationv1.UserInfo
+	expiresAt time.Time
+}
+
+func newReviewCache() *reviewCache {
+	return &reviewCache{
+		users:     map[string]cachedUser{},
+		decisions: map[string]time.Time{},
+	}
+}
+
+// authorize authenticates the bearer token of the request with a TokenReview and checks with a SubjectAccessReview
+// that the authenticated user can get the ScaledObject, it returns the HTTP status code to respond with on error
+func (c *reviewCache) authorize(r *http.Request, kubeClient client.Client, namespace, name string) (int, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		return http.StatusUnauthorized, fmt.Errorf("bearer token is required")
+	}
+
+	tokenHash := sha256.Sum256([]byte(token))
+	tokenKey := hex.EncodeToString(tokenHash[:])
+	decisionKey := tokenKey + "/" + ScaledObjectIdentifier(namespace, name)
+	now := time.Now()
+	if c.isAllowed(decisionKey, now) {
+		return http.StatusOK, nil
+	}
+
+	cached, ok := c.getUser(tokenKey, now)
+	if !ok {
+		tokenReview := &authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: []string{TokenAudience}},
+		}
+		if err := kubeClient.Create(r.Context(), tokenReview); err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("error authenticating the request: %s", err)
+		}
+		if !tokenReview.Status.Authenticated {
+			return http.StatusUnauthorized, fmt.Errorf("bearer token is not valid")
+		}
+		cached = cachedUser{user: tokenReview.Status.User, expiresAt: getTokenExpiration(token, now)}
+		c.storeUser(tokenKey, cached, now)
+	}
+
+	user := cached.user
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "get",
+				Group:     "keda.sh",
+				Resource:  "scaledobjects",
+				Name:      name,
+			},
+		},
+	}
+	if err := kubeClient.Create(r.Context(), accessReview); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error authorizing the request: %s", err)
+	}
+	if !accessReview.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("user %s is not allowed to get ScaledObject %s/%s", user.Username, namespace, name)
+	}
+	c.allow(decisionKey, cached.expiresAt, now)
+	return http.StatusOK, nil
+}
+
+func (c *reviewCache) isAllowed(decisionKey string, now time.Time) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	expiresAt, ok := c.decisions[decisionKey]
+	return ok && now.Before(expiresAt)
+}
+
+func (c *reviewCache) getUser(tokenKey string, now time.Time) (cachedUser, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cached, ok := c.users[tokenKey]
+	return cached, ok && now.Before(cached.expiresAt)
+}
+
+func (c *reviewCache) storeUser(tokenKey string, cached cachedUser, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.deleteExpired(now)
+	c.users[tokenKey] = cached
+}
+
+func (c *reviewCache) allow(decisionKey string, expiresAt time.Time, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.deleteExpired(now)
+	c.decisions[decisionKey] = expiresAt
+}
+
+// deleteExpired deletes the reviews of the expired tokens, so the cache is bounded by the tokens in use
+func (c *reviewCache) deleteExpired(now time.Time) {
+	for key, cached := range c.users {
+		if !now.Before(cached.expiresAt) {
+			delete(c.users, key)
+		}
+	}
+	for key, expiresAt := range c.decisions {
+		if !now.Before(expiresAt) {
+			delete(c.decisions, key)
+		}
+	}
+}
+
+// getTokenExpiration returns the expiration of the JWT bearer token authenticated by a TokenReview, or defaultReviewTTL from now
+// if the token doesn't expire. The claims are read without verifying the signature, as the token was verified by the TokenReview
+func getTokenExpiration(token string, now time.Time) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) == 3 {
+		if payload, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil {
+			var claims struct {
+				Exp int64 `json:"exp"`
+			}
+			if err := json.Unmarshal(payload, &claims); err == nil && claims.Exp > 0 {
+				return time.Unix(claims.Exp, 0)
+			}
+		}
+	}
+	return now.Add(defaultReviewTTL)
+}
+
+// Server serves the cached metrics to KEDA Metrics Server over TLS with the certificate read from tls.crt and tls.key in the certDir,
+// the certificate is reloaded when it's rotated. It only runs on the leader of KEDA Operator, as only the leader fills the MetricsCache
+type Server struct {
+	address string
+	certDir string
+	handler http.Handler
+}
+
+// NewServer creates Server listening on the address, eg. :9666
+func NewServer(address, certDir string, handler http.Handler) *Server {
+	return &Server{address: address, certDir: certDir, handler: handler}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so the Server is only started once KEDA Operator was elected leader
+func (s *Server) NeedLeaderElection() bool {
+	return true
+}
+
+// Start serves the cached metrics until the context is done
+func (s *Server) Start(ctx context.Context) error {
+	watcher, err := certwatcher.New(filepath.Join(s.certDir, "tls.crt"), filepath.Join(s.certDir, "tls.key"))
+	if err != nil {
+		return fmt.Errorf("error reading the certificate of the cached metrics server: %s", err)
+	}
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			log.Error(err, "error watching the certificate of the cached metrics server")
+		}
+	}()
+
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(CachedMetricsPath, s.handler)
+	server := &http.Server{
+		Handler:           mux,
+		TLSConfig:         &tls.Config{GetCertificate: watcher.GetCertificate, MinVersion: tls.VersionTLS12},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.Background()); err != nil {
+			log.Error(err, "error shutting down the cached metrics server")
+		}
+	}()
+
+	log.Info("Serving cached metrics", "address", s.address)
+	if err := server.ServeTLS(listener, "", ""); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
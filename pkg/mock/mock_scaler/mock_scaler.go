@@ -95,6 +95,103 @@ func (mr *MockScalerMockRecorder) IsActive(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsActive", reflect.TypeOf((*MockScaler)(nil).IsActive), ctx)
 }
 
+// MockMetricsAndActivityScaler is a mock of MetricsAndActivityScaler interface.
+type MockMetricsAndActivityScaler struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsAndActivityScalerMockRecorder
+}
+
+// MockMetricsAndActivityScalerMockRecorder is the mock recorder for MockMetricsAndActivityScaler.
+type MockMetricsAndActivityScalerMockRecorder struct {
+	mock *MockMetricsAndActivityScaler
+}
+
+// NewMockMetricsAndActivityScaler creates a new mock instance.
+func NewMockMetricsAndActivityScaler(ctrl *gomock.Controller) *MockMetricsAndActivityScaler {
+	mock := &MockMetricsAndActivityScaler{ctrl: ctrl}
+	mock.recorder = &MockMetricsAndActivityScalerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsAndActivityScaler) EXPECT() *MockMetricsAndActivityScalerMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockMetricsAndActivityScaler) Close(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockMetricsAndActivityScalerMockRecorder) Close(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockMetricsAndActivityScaler)(nil).Close), ctx)
+}
+
+// GetMetricSpecForScaling mocks base method.
+func (m *MockMetricsAndActivityScaler) GetMetricSpecForScaling(ctx context.Context) []v2beta2.MetricSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricSpecForScaling", ctx)
+	ret0, _ := ret[0].([]v2beta2.MetricSpec)
+	return ret0
+}
+
+// GetMetricSpecForScaling indicates an expected call of GetMetricSpecForScaling.
+func (mr *MockMetricsAndActivityScalerMockRecorder) GetMetricSpecForScaling(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricSpecForScaling", reflect.TypeOf((*MockMetricsAndActivityScaler)(nil).GetMetricSpecForScaling), ctx)
+}
+
+// GetMetrics mocks base method.
+func (m *MockMetricsAndActivityScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetrics", ctx, metricName, metricSelector)
+	ret0, _ := ret[0].([]external_metrics.ExternalMetricValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetrics indicates an expected call of GetMetrics.
+func (mr *MockMetricsAndActivityScalerMockRecorder) GetMetrics(ctx, metricName, metricSelector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetrics", reflect.TypeOf((*MockMetricsAndActivityScaler)(nil).GetMetrics), ctx, metricName, metricSelector)
+}
+
+// GetMetricsAndActivity mocks base method.
+func (m *MockMetricsAndActivityScaler) GetMetricsAndActivity(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricsAndActivity", ctx, metricName, metricSelector)
+	ret0, _ := ret[0].([]external_metrics.ExternalMetricValue)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMetricsAndActivity indicates an expected call of GetMetricsAndActivity.
+func (mr *MockMetricsAndActivityScalerMockRecorder) GetMetricsAndActivity(ctx, metricName, metricSelector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricsAndActivity", reflect.TypeOf((*MockMetricsAndActivityScaler)(nil).GetMetricsAndActivity), ctx, metricName, metricSelector)
+}
+
+// IsActive mocks base method.
+func (m *MockMetricsAndActivityScaler) IsActive(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsActive", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsActive indicates an expected call of IsActive.
+func (mr *MockMetricsAndActivityScalerMockRecorder) IsActive(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsActive", reflect.TypeOf((*MockMetricsAndActivityScaler)(nil).IsActive), ctx)
+}
+
 // MockPushScaler is a mock of PushScaler interface.
 type MockPushScaler struct {
 	ctrl     *gomock.Controller
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	metricscache "github.com/kedacore/keda/v2/pkg/metricscache"
 	cache "github.com/kedacore/keda/v2/pkg/scaling/cache"
 )
 
@@ -63,6 +64,20 @@ func (mr *MockScaleHandlerMockRecorder) DeleteScalableObject(ctx, scalableObject
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScalableObject", reflect.TypeOf((*MockScaleHandler)(nil).DeleteScalableObject), ctx, scalableObject)
 }
 
+// GetMetricsCache mocks base method.
+func (m *MockScaleHandler) GetMetricsCache() *metricscache.MetricsCache {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricsCache")
+	ret0, _ := ret[0].(*metricscache.MetricsCache)
+	return ret0
+}
+
+// GetMetricsCache indicates an expected call of GetMetricsCache.
+func (mr *MockScaleHandlerMockRecorder) GetMetricsCache() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricsCache", reflect.TypeOf((*MockScaleHandler)(nil).GetMetricsCache))
+}
+
 // GetScalersCache mocks base method.
 func (m *MockScaleHandler) GetScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, error) {
 	m.ctrl.T.Helper()
@@ -91,3 +106,15 @@ func (mr *MockScaleHandlerMockRecorder) HandleScalableObject(ctx, scalableObject
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleScalableObject", reflect.TypeOf((*MockScaleHandler)(nil).HandleScalableObject), ctx, scalableObject)
 }
+
+// RefreshScalersUsingAuth mocks base method.
+func (m *MockScaleHandler) RefreshScalersUsingAuth(ctx context.Context, authRefKind, namespace, name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RefreshScalersUsingAuth", ctx, authRefKind, namespace, name)
+}
+
+// RefreshScalersUsingAuth indicates an expected call of RefreshScalersUsingAuth.
+func (mr *MockScaleHandlerMockRecorder) RefreshScalersUsingAuth(ctx, authRefKind, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshScalersUsingAuth", reflect.TypeOf((*MockScaleHandler)(nil).RefreshScalersUsingAuth), ctx, authRefKind, namespace, name)
+}
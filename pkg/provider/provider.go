@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,7 +34,12 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
+	"github.com/kedacore/keda/v2/pkg/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
 // KedaProvider implements External Metrics Provider
@@ -44,6 +50,7 @@ type KedaProvider struct {
 	ctx                     context.Context
 	externalMetricsInfo     *[]provider.ExternalMetricInfo
 	externalMetricsInfoLock *sync.RWMutex
+	metricsCacheClient      *metricscache.Client
 }
 
 var (
@@ -52,7 +59,7 @@ var (
 )
 
 // NewProvider returns an instance of KedaProvider
-func NewProvider(ctx context.Context, adapterLogger logr.Logger, scaleHandler scaling.ScaleHandler, client client.Client, watchedNamespace string, externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex) provider.MetricsProvider {
+func NewProvider(ctx context.Context, adapterLogger logr.Logger, scaleHandler scaling.ScaleHandler, client client.Client, watchedNamespace string, externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex, metricsCacheClient *metricscache.Client) provider.MetricsProvider {
 	provider := &KedaProvider{
 		client:                  client,
 		scaleHandler:            scaleHandler,
@@ -60,6 +67,7 @@ func NewProvider(ctx context.Context, adapterLogger logr.Logger, scaleHandler sc
 		ctx:                     ctx,
 		externalMetricsInfo:     externalMetricsInfo,
 		externalMetricsInfoLock: externalMetricsInfoLock,
+		metricsCacheClient:      metricsCacheClient,
 	}
 	logger = adapterLogger.WithName("provider")
 	logger.Info("starting")
@@ -70,7 +78,10 @@ func NewProvider(ctx context.Context, adapterLogger logr.Logger, scaleHandler sc
 // Metric is normally identified by a name and a set of labels/tags. It is up to a specific
 // implementation how to translate metricSelector to a filter for metric values.
 // Namespace can be used by the implementation for metric identification, access control or ignored.
-func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string, metricSelector labels.Selector, info provider.ExternalMetricInfo) (*external_metrics.ExternalMetricValueList, error) {
+func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string, metricSelector labels.Selector, info provider.ExternalMetricInfo) (_ *external_metrics.ExternalMetricValueList, err error) {
+	ctx, span := tracing.StartSpan(ctx, "KedaProvider.GetExternalMetric", tracing.NamespaceKey.String(namespace), attribute.String("metricName", info.Metric))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	// Note:
 	//		metric name and namespace is used to lookup for the CRD which contains configuration
 	// 		if not found then ignored and label selector is parsed for all the metrics
@@ -95,6 +106,7 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 	}
 
 	scaledObject := &scaledObjects.Items[0]
+	span.SetAttributes(tracing.ScaledObjectKey.String(scaledObject.Name))
 	var matchingMetrics []external_metrics.ExternalMetricValue
 
 	cache, err := p.scaleHandler.GetScalersCache(ctx, scaledObject)
@@ -105,6 +117,16 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 
 	scalerError := false
 
+	if scaledObject.IsUsingModifiers() && strings.EqualFold(info.Metric, kedav1alpha1.CompositeMetricName) {
+		metrics, err := p.getCompositeMetrics(ctx, cache, info.Metric, scaledObject)
+		if err != nil {
+			scalerError = true
+			logger.Error(err, "error getting composite metric", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+		} else {
+			matchingMetrics = append(matchingMetrics, metrics...)
+		}
+	}
+
 	for scalerIndex, scaler := range cache.GetScalers() {
 		metricSpecs := scaler.GetMetricSpecForScaling(ctx)
 		scalerName := strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
@@ -116,7 +138,7 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 			}
 			// Filter only the desired metric
 			if strings.EqualFold(metricSpec.External.Metric.Name, info.Metric) {
-				metrics, err := cache.GetMetricsForScaler(ctx, scalerIndex, info.Metric, metricSelector)
+				metrics, err := p.getMetricsForScaler(ctx, cache, scalerIndex, info.Metric, metricSelector, scaledObject)
 				metrics, err = p.getMetricsWithFallback(ctx, metrics, err, info.Metric, scaledObject, metricSpec)
 
 				if err != nil {
@@ -153,6 +175,38 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 	}, nil
 }
 
+// getMetricsForScaler returns metrics of the scaler, metrics of triggers with useCachedMetrics enabled are read
+// from KEDA Operator and the scaler is queried directly only if they are not available there, or if they are queried
+// with another selector than the one of the HPA the metrics are cached for
+func (p *KedaProvider) getMetricsForScaler(ctx context.Context, scalersCache *cache.ScalersCache, scalerIndex int, metricName string, metricSelector labels.Selector, scaledObject *kedav1alpha1.ScaledObject) ([]external_metrics.ExternalMetricValue, error) {
+	if p.metricsCacheClient != nil && scalerIndex < len(scaledObject.Spec.Triggers) && scaledObject.Spec.Triggers[scalerIndex].UseCachedMetrics &&
+		metricSelector != nil && metricSelector.String() == metricscache.ScaledObjectMetricSelector(scaledObject.Name).String() {
+		metrics, err := p.metricsCacheClient.GetMetrics(ctx, scaledObject.Namespace, scaledObject.Name, metricName)
+		if err == nil {
+			return metrics, nil
+		}
+		logger.V(1).Info("cached metrics not available, querying scaler", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "metricName", metricName, "reason", err.Error())
+	}
+
+	return scalersCache.GetMetricsForScaler(ctx, scalerIndex, metricName, metricSelector)
+}
+
+// getCompositeMetrics returns the composite metric calculated by the formula defined in ScalingModifiers,
+// fallback is applied on the composite metric if any of the referenced triggers fails
+func (p *KedaProvider) getCompositeMetrics(ctx context.Context, scalersCache *cache.ScalersCache, metricName string, scaledObject *kedav1alpha1.ScaledObject) ([]external_metrics.ExternalMetricValue, error) {
+	metricSpec, err := modifiers.GetMetricSpec(scaledObject)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics []external_metrics.ExternalMetricValue
+	value, err := scalersCache.GetCompositeMetricValue(ctx, scaledObject)
+	if err == nil {
+		metrics = append(metrics, scalers.GenerateMetricInMili(metricName, value))
+	}
+	return p.getMetricsWithFallback(ctx, metrics, err, metricName, scaledObject, metricSpec)
+}
+
 // ListAllExternalMetrics returns the supported external metrics for this provider
 func (p *KedaProvider) ListAllExternalMetrics() []provider.ExternalMetricInfo {
 	logger.V(1).Info("KEDA Metrics Server received request for list of all provided external metrics names")
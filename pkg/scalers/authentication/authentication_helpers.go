@@ -12,6 +12,7 @@ import (
 	libs "github.com/dysnix/predictkube-libs/external/configs"
 	"github.com/dysnix/predictkube-libs/external/http_transport"
 	pConfig "github.com/prometheus/common/config"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -41,9 +42,22 @@ func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMet
 			if out.EnableBasicAuth {
 				return nil, errors.New("beare and basic authentication can not be set both")
 			}
+			if out.BearerTokenFile != nil {
+				return nil, errors.New("bearer token file authentication can not be set with basic or bearer authentication")
+			}
 
 			out.BearerToken = authParams["bearerToken"]
 			out.EnableBearerAuth = true
+		case BearerTokenFileAuthType:
+			if out.EnableBasicAuth || out.EnableBearerAuth {
+				return nil, errors.New("bearer token file authentication can not be set with basic or bearer authentication")
+			}
+
+			out.BearerTokenFile, err = GetBearerTokenFile(authParams)
+			if err != nil {
+				return nil, err
+			}
+			out.EnableBearerAuth = true
 		case BasicAuthType:
 			if len(authParams["username"]) == 0 {
 				return nil, errors.New("no username given")
@@ -57,6 +71,18 @@ func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMet
 			// username as apikey and password as empty
 			out.Password = authParams["password"]
 			out.EnableBasicAuth = true
+		case OAuthType:
+			oauth, err := GetOAuthConfigs(triggerMetadata, authParams)
+			if err != nil {
+				return nil, err
+			}
+
+			out.OauthTokenURI = oauth.OauthTokenURI
+			out.ClientID = oauth.ClientID
+			out.ClientSecret = oauth.ClientSecret
+			out.Scopes = oauth.Scopes
+			out.EndpointParams = oauth.EndpointParams
+			out.EnableOAuth = true
 		case TLSAuthType:
 			if len(authParams["cert"]) == 0 {
 				return nil, errors.New("no cert given")
@@ -74,6 +100,10 @@ func GetAuthConfigs(triggerMetadata, authParams map[string]string) (out *AuthMet
 		}
 	}
 
+	if out.EnableOAuth && (out.EnableBasicAuth || out.EnableBearerAuth) {
+		return nil, errors.New("oauth authentication can not be set with basic or bearer authentication")
+	}
+
 	if len(authParams["ca"]) > 0 {
 		out.CA = authParams["ca"]
 	}
@@ -96,8 +126,8 @@ func CreateHTTPRoundTripper(roundTripperType TransportType, auth *AuthMeta, conf
 
 	switch roundTripperType {
 	case NetHTTP:
-		// from official github.com/prometheus/client_golang/api package
-		return &http.Transport{
+		// from official github.com/prometheus/client_golang/api package, requests are traced with OpenTelemetry
+		return otelhttp.NewTransport(&http.Transport{
 			Proxy: http.ProxyFromEnvironment,
 			DialContext: (&net.Dialer{
 				Timeout:   30 * time.Second,
@@ -105,7 +135,7 @@ func CreateHTTPRoundTripper(roundTripperType TransportType, auth *AuthMeta, conf
 			}).DialContext,
 			TLSHandshakeTimeout: 10 * time.Second,
 			TLSClientConfig:     tlsConfig,
-		}, nil
+		}), nil
 	case FastHTTP:
 		// default configs
 		httpConf := &libs.HTTPTransport{
@@ -139,12 +169,20 @@ func CreateHTTPRoundTripper(roundTripperType TransportType, auth *AuthMeta, conf
 				)
 			}
 
+			if auth.EnableOAuth {
+				rt = NewOAuth2RoundTripper(auth, roundTripper)
+			}
+
 			if auth.EnableBearerAuth {
-				rt = pConfig.NewAuthorizationCredentialsRoundTripper(
-					"Bearer",
-					pConfig.Secret(auth.BearerToken),
-					roundTripper,
-				)
+				if auth.BearerTokenFile != nil {
+					rt = NewBearerTokenFileRoundTripper(auth.BearerTokenFile, roundTripper)
+				} else {
+					rt = pConfig.NewAuthorizationCredentialsRoundTripper(
+						"Bearer",
+						pConfig.Secret(auth.BearerToken),
+						roundTripper,
+					)
+				}
 			}
 		} else {
 			rt = roundTripper
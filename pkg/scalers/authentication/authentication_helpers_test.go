@@ -0,0 +1,14 @@
+package authentication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+func TestNetHTTPRoundTripperIsTraced(t *testing.T) {
+	rt, err := CreateHTTPRoundTripper(NetHTTP, &AuthMeta{EnableTLS: false})
+	assert.NoError(t, err)
+	assert.IsType(t, &otelhttp.Transport{}, rt)
+}
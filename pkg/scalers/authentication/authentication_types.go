@@ -1,6 +1,9 @@
 package authentication
 
-import "time"
+import (
+	"net/url"
+	"time"
+)
 
 // Type describes the authentication type used in a scaler
 type Type string
@@ -14,6 +17,10 @@ const (
 	TLSAuthType Type = "tls"
 	// BearerAuthType is a auth type using a bearer token
 	BearerAuthType Type = "bearer"
+	// BearerTokenFileAuthType is a auth type using a bearer token read from a file, eg. a projected service account token
+	BearerTokenFileAuthType Type = "bearerTokenFile"
+	// OAuthType is a auth type using an OAuth2 access token fetched with the client credentials grant
+	OAuthType Type = "oauth"
 )
 
 // TransportType is type of http transport
@@ -28,12 +35,21 @@ type AuthMeta struct {
 	// bearer auth
 	EnableBearerAuth bool
 	BearerToken      string
+	BearerTokenFile  *TokenFile // +optional, the bearer token is read from the file instead of BearerToken
 
 	// basic auth
 	EnableBasicAuth bool
 	Username        string
 	Password        string // +optional
 
+	// oauth2 client credentials
+	EnableOAuth    bool
+	OauthTokenURI  string
+	ClientID       string
+	ClientSecret   string
+	Scopes         []string   // +optional
+	EndpointParams url.Values // +optional
+
 	// client certification
 	EnableTLS bool
 	Cert      string
@@ -41,6 +57,14 @@ type AuthMeta struct {
 	CA        string
 }
 
+// GetBearerToken returns the bearer token, which is read from the token file if one is configured
+func (a *AuthMeta) GetBearerToken() (string, error) {
+	if a.BearerTokenFile != nil {
+		return a.BearerTokenFile.Token()
+	}
+	return a.BearerToken, nil
+}
+
 type HTTPTransport struct {
 	MaxIdleConnDuration time.Duration
 	ReadTimeout         time.Duration
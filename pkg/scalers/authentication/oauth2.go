@@ -0,0 +1,81 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// GetOAuthConfigs returns the OAuth2 client credentials, the client secret is only read from the auth params
+// while the token URI, client id, scopes and endpoint params can also be set in the trigger metadata
+func GetOAuthConfigs(triggerMetadata, authParams map[string]string) (*AuthMeta, error) {
+	out := &AuthMeta{}
+
+	out.OauthTokenURI = getFromAuthOrMeta(triggerMetadata, authParams, "oauthTokenURI")
+	if out.OauthTokenURI == "" {
+		return nil, errors.New("no oauthTokenURI given")
+	}
+
+	out.ClientID = getFromAuthOrMeta(triggerMetadata, authParams, "clientID")
+	if out.ClientID == "" {
+		return nil, errors.New("no clientID given")
+	}
+
+	out.ClientSecret = authParams["clientSecret"]
+	if out.ClientSecret == "" {
+		return nil, errors.New("no clientSecret given")
+	}
+
+	if scopes := getFromAuthOrMeta(triggerMetadata, authParams, "scopes"); scopes != "" {
+		for _, scope := range strings.Split(scopes, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				out.Scopes = append(out.Scopes, scope)
+			}
+		}
+	}
+
+	if endpointParams := getFromAuthOrMeta(triggerMetadata, authParams, "endpointParams"); endpointParams != "" {
+		values, err := url.ParseQuery(endpointParams)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing endpointParams: %s", err)
+		}
+		out.EndpointParams = values
+	}
+
+	out.EnableOAuth = true
+	return out, nil
+}
+
+// NewOAuth2RoundTripper authenticates the requests with an access token fetched with the client credentials grant,
+// the token is cached and fetched again through the same transport once it expires
+func NewOAuth2RoundTripper(auth *AuthMeta, rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	config := clientcredentials.Config{
+		ClientID:       auth.ClientID,
+		ClientSecret:   auth.ClientSecret,
+		TokenURL:       auth.OauthTokenURI,
+		Scopes:         auth.Scopes,
+		EndpointParams: auth.EndpointParams,
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: rt})
+
+	return &oauth2.Transport{
+		Source: config.TokenSource(ctx),
+		Base:   rt,
+	}
+}
+
+func getFromAuthOrMeta(triggerMetadata, authParams map[string]string, key string) string {
+	if authParams[key] != "" {
+		return authParams[key]
+	}
+	return triggerMetadata[key]
+}
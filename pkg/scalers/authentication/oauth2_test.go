@@ -0,0 +1,66 @@
+package authentication
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOAuthConfigs(t *testing.T) {
+	auth, err := GetOAuthConfigs(
+		map[string]string{"oauthTokenURI": "http://localhost/token", "scopes": "read, write", "endpointParams": "audience=keda"},
+		map[string]string{"clientID": "keda", "clientSecret": "secret"},
+	)
+	assert.NoError(t, err)
+	assert.True(t, auth.EnableOAuth)
+	assert.Equal(t, []string{"read", "write"}, auth.Scopes)
+	assert.Equal(t, "keda", auth.EndpointParams.Get("audience"))
+
+	// the client secret isn't read from the trigger metadata
+	_, err = GetOAuthConfigs(
+		map[string]string{"oauthTokenURI": "http://localhost/token", "clientSecret": "secret"},
+		map[string]string{"clientID": "keda"},
+	)
+	assert.Error(t, err)
+
+	_, err = GetAuthConfigs(
+		map[string]string{authModesKey: "oauth,basic", "oauthTokenURI": "http://localhost/token"},
+		map[string]string{"clientID": "keda", "clientSecret": "secret", "username": "user"},
+	)
+	assert.Error(t, err)
+}
+
+func TestOAuth2RoundTripperCachesToken(t *testing.T) {
+	tokenRequests := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		assert.Equal(t, "read", r.Form.Get("scope"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, tokenRequests)
+	}))
+	defer tokenServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+	}))
+	defer apiServer.Close()
+
+	auth, err := GetAuthConfigs(
+		map[string]string{authModesKey: "oauth", "oauthTokenURI": tokenServer.URL, "scopes": "read"},
+		map[string]string{"clientID": "keda", "clientSecret": "secret"},
+	)
+	assert.NoError(t, err)
+
+	client := &http.Client{Transport: NewOAuth2RoundTripper(auth, nil)}
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(apiServer.URL)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, 1, tokenRequests)
+}
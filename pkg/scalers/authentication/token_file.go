@@ -0,0 +1,148 @@
+package authentication
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	bearerTokenFileKey           = "bearerTokenFile"
+	defaultTokenFileReadInterval = time.Minute
+
+	// BearerTokenFileDirEnv is the directory of the KEDA operator in which bearer token files are allowed to be read,
+	// eg. the mount path of the projected service account token volumes. No file is read if it isn't set
+	BearerTokenFileDirEnv = "KEDA_BEARER_TOKEN_FILE_DIR"
+
+	// serviceAccountDir is the directory of the service account token of the KEDA operator, which is never sent
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// TokenFile reads a bearer token from a file and reads it again periodically,
+// so tokens rotated by the kubelet, eg. projected service account tokens, are used once renewed
+type TokenFile struct {
+	path         string
+	readInterval time.Duration
+
+	mutex    sync.Mutex
+	token    string
+	readTime time.Time
+}
+
+// NewTokenFile creates a TokenFile reading the token from the path
+func NewTokenFile(path string) *TokenFile {
+	return &TokenFile{
+		path:         path,
+		readInterval: defaultTokenFileReadInterval,
+	}
+}
+
+// GetBearerTokenFile returns the TokenFile of the bearerTokenFile auth param. The path is only accepted from
+// the TriggerAuthentication and in the directory set with KEDA_BEARER_TOKEN_FILE_DIR, so KEDA can't be made
+// to send the tokens of its other files, eg. the token of its own service account
+func GetBearerTokenFile(authParams map[string]string) (*TokenFile, error) {
+	path := authParams[bearerTokenFileKey]
+	if path == "" {
+		return nil, errors.New("no bearer token file provided")
+	}
+	if err := checkBearerTokenFilePath(path); err != nil {
+		return nil, err
+	}
+
+	tokenFile := NewTokenFile(path)
+	if _, err := tokenFile.Token(); err != nil {
+		return nil, err
+	}
+	return tokenFile, nil
+}
+
+// checkBearerTokenFilePath checks that the path resolves to a file in the allowed directory, symlinks are
+// resolved first so they can't point out of it
+func checkBearerTokenFilePath(path string) error {
+	allowedDir := os.Getenv(BearerTokenFileDirEnv)
+	if allowedDir == "" {
+		return fmt.Errorf("bearer token files are disabled, %s isn't set", BearerTokenFileDirEnv)
+	}
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("bearer token file %s must be an absolute path", path)
+	}
+
+	resolvedDir, err := filepath.EvalSymlinks(allowedDir)
+	if err != nil {
+		return fmt.Errorf("error resolving %s %s: %w", BearerTokenFileDirEnv, allowedDir, err)
+	}
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return fmt.Errorf("error resolving bearer token file %s: %w", path, err)
+	}
+
+	if !isInDir(resolvedPath, resolvedDir) {
+		return fmt.Errorf("bearer token file %s isn't in %s", path, allowedDir)
+	}
+	resolvedSADir, err := filepath.EvalSymlinks(serviceAccountDir)
+	if err != nil {
+		resolvedSADir = serviceAccountDir
+	}
+	if isInDir(path, serviceAccountDir) || isInDir(resolvedPath, resolvedSADir) {
+		return fmt.Errorf("bearer token file %s is in the service account directory of KEDA", path)
+	}
+	return nil
+}
+
+// isInDir returns whether the path is a file in the directory or in one of its subdirectories
+func isInDir(path, dir string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Token returns the token of the file, which is read again once the read interval elapsed
+func (f *TokenFile) Token() (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.token != "" && time.Since(f.readTime) < f.readInterval {
+		return f.token, nil
+	}
+
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading bearer token file %s: %s", f.path, err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("bearer token file %s is empty", f.path)
+	}
+
+	f.token = token
+	f.readTime = time.Now()
+	return f.token, nil
+}
+
+type bearerTokenFileRoundTripper struct {
+	tokenFile *TokenFile
+	rt        http.RoundTripper
+}
+
+// NewBearerTokenFileRoundTripper adds the token of the file as bearer token to the requests
+func NewBearerTokenFileRoundTripper(tokenFile *TokenFile, rt http.RoundTripper) http.RoundTripper {
+	return &bearerTokenFileRoundTripper{tokenFile: tokenFile, rt: rt}
+}
+
+func (rt *bearerTokenFileRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := rt.tokenFile.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return rt.rt.RoundTrip(req)
+}
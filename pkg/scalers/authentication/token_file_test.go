@@ -0,0 +1,108 @@
+package authentication
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenFileIsReadAgain(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(BearerTokenFileDirEnv, dir)
+	path := filepath.Join(dir, "token")
+	assert.NoError(t, os.WriteFile(path, []byte("first-token\n"), 0600))
+
+	tokenFile, err := GetBearerTokenFile(map[string]string{bearerTokenFileKey: path})
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(path, []byte("rotated-token\n"), 0600))
+	token, err := tokenFile.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "first-token", token, "token is cached until the read interval elapsed")
+
+	tokenFile.readInterval = 0
+	token, err = tokenFile.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated-token", token)
+}
+
+func TestGetBearerTokenFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(BearerTokenFileDirEnv, dir)
+
+	_, err := GetBearerTokenFile(map[string]string{})
+	assert.Error(t, err, "no file is read without bearerTokenFile")
+
+	_, err = GetBearerTokenFile(map[string]string{bearerTokenFileKey: filepath.Join(dir, "missing")})
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "empty")
+	assert.NoError(t, os.WriteFile(path, []byte(" \n"), 0600))
+	_, err = GetBearerTokenFile(map[string]string{bearerTokenFileKey: path})
+	assert.Error(t, err)
+}
+
+func TestGetBearerTokenFileOutOfAllowedDir(t *testing.T) {
+	allowedDir := t.TempDir()
+	otherDir := t.TempDir()
+	path := filepath.Join(otherDir, "token")
+	assert.NoError(t, os.WriteFile(path, []byte("token"), 0600))
+
+	_, err := GetBearerTokenFile(map[string]string{bearerTokenFileKey: path})
+	assert.Error(t, err, "no file is read without KEDA_BEARER_TOKEN_FILE_DIR")
+
+	t.Setenv(BearerTokenFileDirEnv, allowedDir)
+	_, err = GetBearerTokenFile(map[string]string{bearerTokenFileKey: path})
+	assert.Error(t, err, "file out of the allowed directory")
+
+	_, err = GetBearerTokenFile(map[string]string{bearerTokenFileKey: filepath.Join(allowedDir, "..", filepath.Base(otherDir), "token")})
+	assert.Error(t, err, "relative components can't leave the allowed directory")
+
+	link := filepath.Join(allowedDir, "token")
+	assert.NoError(t, os.Symlink(path, link))
+	_, err = GetBearerTokenFile(map[string]string{bearerTokenFileKey: link})
+	assert.Error(t, err, "symlinks can't leave the allowed directory")
+
+	_, err = GetBearerTokenFile(map[string]string{bearerTokenFileKey: serviceAccountDir + "/token"})
+	assert.Error(t, err, "the service account token of KEDA is never read")
+
+	t.Setenv(BearerTokenFileDirEnv, serviceAccountDir)
+	_, err = GetBearerTokenFile(map[string]string{bearerTokenFileKey: serviceAccountDir + "/token"})
+	assert.Error(t, err, "the service account token of KEDA is never read, even in the allowed directory")
+}
+
+func TestGetAuthConfigsBearerTokenFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(BearerTokenFileDirEnv, dir)
+	path := filepath.Join(dir, "token")
+	assert.NoError(t, os.WriteFile(path, []byte("token"), 0600))
+
+	auth, err := GetAuthConfigs(map[string]string{authModesKey: "bearerTokenFile"}, map[string]string{bearerTokenFileKey: path})
+	assert.NoError(t, err)
+	assert.True(t, auth.EnableBearerAuth)
+	token, err := auth.GetBearerToken()
+	assert.NoError(t, err)
+	assert.Equal(t, "token", token)
+
+	_, err = GetAuthConfigs(map[string]string{authModesKey: "bearerTokenFile,bearer"}, map[string]string{bearerTokenFileKey: path, "bearerToken": "token"})
+	assert.Error(t, err)
+}
+
+func TestBearerTokenFileRoundTripper(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(path, []byte("token"), 0600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewBearerTokenFileRoundTripper(NewTokenFile(path), http.DefaultTransport)}
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+}
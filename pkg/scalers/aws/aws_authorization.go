@@ -0,0 +1,125 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const (
+	minSessionDuration = 15 * time.Minute
+	maxSessionDuration = 12 * time.Hour
+)
+
+// AuthorizationMetadata holds the credentials or the role used to authenticate against AWS
+type AuthorizationMetadata struct {
+	AwsRoleArn string
+
+	// AwsChainedRoleArns are assumed in order with the credentials of the previously assumed role, eg. to access another account
+	AwsChainedRoleArns []string
+	// AwsExternalID is passed when assuming the last role
+	AwsExternalID string
+	// AwsSessionDuration of the credentials of the assumed roles, the default of the AWS SDK if zero
+	AwsSessionDuration time.Duration
+	// UseWebIdentity assumes AwsRoleArn with the web identity token of the KEDA operator (IRSA)
+	// rather than with its credentials, it's only enabled by awsUseWebIdentity with aws-eks pod identity
+	UseWebIdentity bool
+
+	AwsAccessKeyID     string
+	AwsSecretAccessKey string
+	AwsSessionToken    string
+
+	PodIdentityOwner bool
+}
+
+// GetAwsAuthorization parses the authorization of an AWS scaler from the auth params, trigger metadata and resolved env.
+// The roles to assume and the external ID are only read from the auth params, so that the owner of a ScaledObject
+// can't make KEDA assume the roles trusting the identity of the KEDA operator
+func GetAwsAuthorization(authParams, metadata, resolvedEnv map[string]string, podIdentity kedav1alpha1.PodIdentityProvider) (AuthorizationMetadata, error) {
+	meta := AuthorizationMetadata{}
+
+	if metadata["identityOwner"] == "operator" {
+		meta.PodIdentityOwner = false
+		// the role is assumed with the identity of the KEDA operator only when it is set explicitly, awsRoleArn
+		// isn't used as it is set to the role of the workload service account with aws-eks pod identity
+		meta.AwsRoleArn = authParams["awsOperatorRoleArn"]
+	} else if metadata["identityOwner"] == "" || metadata["identityOwner"] == "pod" {
+		meta.PodIdentityOwner = true
+		switch {
+		case authParams["awsRoleArn"] != "":
+			meta.AwsRoleArn = authParams["awsRoleArn"]
+			if val := authParams["awsUseWebIdentity"]; val != "" {
+				useWebIdentity, err := strconv.ParseBool(val)
+				if err != nil {
+					return meta, fmt.Errorf("error parsing awsUseWebIdentity: %s", err)
+				}
+				if useWebIdentity && podIdentity != kedav1alpha1.PodIdentityProviderAwsEKS {
+					return meta, fmt.Errorf("awsUseWebIdentity requires %s pod identity", kedav1alpha1.PodIdentityProviderAwsEKS)
+				}
+				meta.UseWebIdentity = useWebIdentity
+			}
+		case (authParams["awsAccessKeyID"] != "" || authParams["awsAccessKeyId"] != "") && authParams["awsSecretAccessKey"] != "":
+			meta.AwsAccessKeyID = authParams["awsAccessKeyID"]
+			if meta.AwsAccessKeyID == "" {
+				meta.AwsAccessKeyID = authParams["awsAccessKeyId"]
+			}
+			meta.AwsSecretAccessKey = authParams["awsSecretAccessKey"]
+			meta.AwsSessionToken = authParams["awsSessionToken"]
+		default:
+			if metadata["awsAccessKeyID"] != "" {
+				meta.AwsAccessKeyID = metadata["awsAccessKeyID"]
+			} else if metadata["awsAccessKeyIDFromEnv"] != "" {
+				meta.AwsAccessKeyID = resolvedEnv[metadata["awsAccessKeyIDFromEnv"]]
+			}
+
+			if len(meta.AwsAccessKeyID) == 0 {
+				return meta, fmt.Errorf("awsAccessKeyID not found")
+			}
+
+			if metadata["awsSecretAccessKeyFromEnv"] != "" {
+				meta.AwsSecretAccessKey = resolvedEnv[metadata["awsSecretAccessKeyFromEnv"]]
+			}
+
+			if len(meta.AwsSecretAccessKey) == 0 {
+				return meta, fmt.Errorf("awsSecretAccessKey not found")
+			}
+		}
+	}
+
+	if val := authParams["awsChainedRoleArns"]; val != "" {
+		for _, roleArn := range strings.Split(val, ",") {
+			if roleArn = strings.TrimSpace(roleArn); roleArn != "" {
+				meta.AwsChainedRoleArns = append(meta.AwsChainedRoleArns, roleArn)
+			}
+		}
+	}
+
+	meta.AwsExternalID = authParams["awsExternalId"]
+	if meta.AwsExternalID != "" && meta.AwsRoleArn == "" && len(meta.AwsChainedRoleArns) == 0 {
+		return meta, fmt.Errorf("awsExternalId requires a role to assume")
+	}
+
+	if val := getParameter(authParams, metadata, "awsSessionDuration"); val != "" {
+		duration, err := time.ParseDuration(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing awsSessionDuration: %s", err)
+		}
+		if duration < minSessionDuration || duration > maxSessionDuration {
+			return meta, fmt.Errorf("awsSessionDuration must be between %s and %s", minSessionDuration, maxSessionDuration)
+		}
+		meta.AwsSessionDuration = duration
+	}
+
+	return meta, nil
+}
+
+// getParameter returns the parameter from the auth params, or from the trigger metadata if it isn't in the auth params
+func getParameter(authParams, metadata map[string]string, name string) string {
+	if val := authParams[name]; val != "" {
+		return val
+	}
+	return metadata[name]
+}
@@ -0,0 +1,64 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestGetAwsAuthorization(t *testing.T) {
+	auth, err := GetAwsAuthorization(map[string]string{"awsRoleArn": "arn:aws:iam::111111111111:role/workload", "awsUseWebIdentity": "true",
+		"awsChainedRoleArns": "arn:aws:iam::222222222222:role/a, arn:aws:iam::333333333333:role/b", "awsExternalId": "keda"},
+		map[string]string{"awsSessionDuration": "1h"}, nil, kedav1alpha1.PodIdentityProviderAwsEKS)
+	assert.NoError(t, err)
+	assert.Equal(t, AuthorizationMetadata{
+		AwsRoleArn:         "arn:aws:iam::111111111111:role/workload",
+		AwsChainedRoleArns: []string{"arn:aws:iam::222222222222:role/a", "arn:aws:iam::333333333333:role/b"},
+		AwsExternalID:      "keda",
+		AwsSessionDuration: time.Hour,
+		UseWebIdentity:     true,
+		PodIdentityOwner:   true,
+	}, auth)
+
+	auth, err = GetAwsAuthorization(map[string]string{"awsRoleArn": "arn:aws:iam::111111111111:role/workload"}, map[string]string{},
+		nil, kedav1alpha1.PodIdentityProviderAwsEKS)
+	assert.NoError(t, err)
+	assert.False(t, auth.UseWebIdentity, "the role should be assumed with sts:AssumeRole by default")
+
+	_, err = GetAwsAuthorization(map[string]string{"awsRoleArn": "arn:aws:iam::111111111111:role/workload", "awsUseWebIdentity": "true"},
+		map[string]string{}, nil, kedav1alpha1.PodIdentityProviderAwsKiam)
+	assert.EqualError(t, err, "awsUseWebIdentity requires aws-eks pod identity")
+
+	auth, err = GetAwsAuthorization(map[string]string{"awsOperatorRoleArn": "arn:aws:iam::111111111111:role/keda"}, map[string]string{"identityOwner": "operator"},
+		nil, kedav1alpha1.PodIdentityProviderNone)
+	assert.NoError(t, err)
+	assert.Equal(t, AuthorizationMetadata{AwsRoleArn: "arn:aws:iam::111111111111:role/keda"}, auth)
+
+	// aws-eks pod identity sets awsRoleArn to the role of the workload service account, the operator uses its own credentials
+	auth, err = GetAwsAuthorization(map[string]string{"awsRoleArn": "arn:aws:iam::111111111111:role/workload"}, map[string]string{"identityOwner": "operator"},
+		nil, kedav1alpha1.PodIdentityProviderAwsEKS)
+	assert.NoError(t, err)
+	assert.Equal(t, AuthorizationMetadata{}, auth, "the role of the workload shouldn't be assumed by the operator")
+
+	auth, err = GetAwsAuthorization(map[string]string{"awsRoleArn": "arn:aws:iam::111111111111:role/workload", "awsOperatorRoleArn": "arn:aws:iam::111111111111:role/keda"},
+		map[string]string{"identityOwner": "operator"}, nil, kedav1alpha1.PodIdentityProviderAwsEKS)
+	assert.NoError(t, err)
+	assert.Equal(t, AuthorizationMetadata{AwsRoleArn: "arn:aws:iam::111111111111:role/keda"}, auth)
+
+	auth, err = GetAwsAuthorization(map[string]string{},
+		map[string]string{"identityOwner": "operator", "awsRoleArn": "arn:aws:iam::111111111111:role/keda", "awsChainedRoleArns": "arn:aws:iam::222222222222:role/a"},
+		nil, kedav1alpha1.PodIdentityProviderNone)
+	assert.NoError(t, err)
+	assert.Equal(t, AuthorizationMetadata{}, auth, "the roles to assume shouldn't be read from the trigger metadata")
+
+	_, err = GetAwsAuthorization(map[string]string{"awsAccessKeyID": "key", "awsSecretAccessKey": "secret", "awsExternalId": "keda"},
+		map[string]string{}, nil, kedav1alpha1.PodIdentityProviderNone)
+	assert.EqualError(t, err, "awsExternalId requires a role to assume")
+
+	_, err = GetAwsAuthorization(map[string]string{"awsRoleArn": "arn:aws:iam::111111111111:role/workload"},
+		map[string]string{"awsSessionDuration": "5m"}, nil, kedav1alpha1.PodIdentityProviderAwsKiam)
+	assert.EqualError(t, err, "awsSessionDuration must be between 15m0s and 12h0m0s")
+}
@@ -0,0 +1,136 @@
+package aws
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+const (
+	// webIdentityTokenFileEnv is set by the EKS pod identity webhook to the projected service account token of the KEDA operator
+	webIdentityTokenFileEnv = "AWS_WEB_IDENTITY_TOKEN_FILE"
+
+	// unusedCredentialsTTL is the time after which the credentials of a role not requested by any scaler are evicted from the cache
+	unusedCredentialsTTL = maxSessionDuration
+)
+
+type cachedCredentials struct {
+	creds    *credentials.Credentials
+	lastUsed time.Time
+}
+
+// assumedRoleCredentials caches the credentials of the assumed roles, so that the scalers using the same role
+// share the credentials which are refreshed before they expire
+var assumedRoleCredentials = struct {
+	sync.Mutex
+	items map[string]*cachedCredentials
+}{items: map[string]*cachedCredentials{}}
+
+// GetAwsConfig returns the session and the config of an AWS client in the region, using the credentials
+// of the authorization and the roles to assume, the identity of the KEDA operator is used if it owns the identity
+func GetAwsConfig(region string, authorization AuthorizationMetadata) (*session.Session, *aws.Config) {
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region: aws.String(region),
+	}))
+
+	config := &aws.Config{
+		Region: aws.String(region),
+	}
+	if creds := getCredentials(sess, authorization); creds != nil {
+		config.Credentials = creds
+	}
+	return sess, config
+}
+
+// getCredentials returns the credentials of the authorization, or nil to use the default credentials of the KEDA operator
+func getCredentials(sess *session.Session, authorization AuthorizationMetadata) *credentials.Credentials {
+	var creds *credentials.Credentials
+	cacheKey := "operator"
+	if authorization.PodIdentityOwner && authorization.AwsRoleArn == "" {
+		creds = credentials.NewStaticCredentials(authorization.AwsAccessKeyID, authorization.AwsSecretAccessKey, authorization.AwsSessionToken)
+		secretHash := sha256.Sum256([]byte(authorization.AwsSecretAccessKey + authorization.AwsSessionToken))
+		cacheKey = fmt.Sprintf("static/%s/%s", authorization.AwsAccessKeyID, hex.EncodeToString(secretHash[:]))
+	}
+
+	roleArns := authorization.AwsChainedRoleArns
+	if authorization.AwsRoleArn != "" {
+		roleArns = append([]string{authorization.AwsRoleArn}, roleArns...)
+	}
+
+	webIdentityTokenFile := os.Getenv(webIdentityTokenFileEnv)
+	for i, roleArn := range roleArns {
+		externalID := ""
+		if i == len(roleArns)-1 {
+			externalID = authorization.AwsExternalID
+		}
+		webIdentity := i == 0 && authorization.UseWebIdentity && webIdentityTokenFile != ""
+
+		cacheKey = fmt.Sprintf("%s|%s/%s/%s/%t", cacheKey, roleArn, externalID, authorization.AwsSessionDuration, webIdentity)
+		baseCreds := creds
+		creds = getAssumedRoleCredentials(cacheKey, func() *credentials.Credentials {
+			if webIdentity {
+				return newWebIdentityCredentials(sess, roleArn, webIdentityTokenFile, authorization.AwsSessionDuration)
+			}
+			return newAssumeRoleCredentials(sess, baseCreds, roleArn, externalID, authorization.AwsSessionDuration)
+		})
+	}
+	return creds
+}
+
+// getAssumedRoleCredentials returns the cached credentials of the key, or caches the new credentials. The credentials which
+// weren't requested for longer than unusedCredentialsTTL are evicted, the scalers built with them keep refreshing them
+func getAssumedRoleCredentials(cacheKey string, newCredentials func() *credentials.Credentials) *credentials.Credentials {
+	assumedRoleCredentials.Lock()
+	defer assumedRoleCredentials.Unlock()
+
+	now := time.Now()
+	for key, item := range assumedRoleCredentials.items {
+		if now.Sub(item.lastUsed) > unusedCredentialsTTL {
+			delete(assumedRoleCredentials.items, key)
+		}
+	}
+
+	item, ok := assumedRoleCredentials.items[cacheKey]
+	if !ok {
+		item = &cachedCredentials{creds: newCredentials()}
+		assumedRoleCredentials.items[cacheKey] = item
+	}
+	item.lastUsed = now
+	return item.creds
+}
+
+// newAssumeRoleCredentials assumes the role with the base credentials, or with the default credentials of the KEDA operator if nil
+func newAssumeRoleCredentials(sess *session.Session, baseCreds *credentials.Credentials, roleArn, externalID string, duration time.Duration) *credentials.Credentials {
+	stsSession := sess
+	if baseCreds != nil {
+		stsSession = sess.Copy(&aws.Config{Credentials: baseCreds})
+	}
+	return stscreds.NewCredentials(stsSession, roleArn, func(p *stscreds.AssumeRoleProvider) {
+		if externalID != "" {
+			p.ExternalID = aws.String(externalID)
+		}
+		if duration > 0 {
+			p.Duration = duration
+		}
+	})
+}
+
+// newWebIdentityCredentials assumes the role with the web identity token of the KEDA operator
+func newWebIdentityCredentials(sess *session.Session, roleArn, tokenFile string, duration time.Duration) *credentials.Credentials {
+	provider := stscreds.NewWebIdentityRoleProviderWithOptions(sts.New(sess), roleArn, "", stscreds.FetchTokenPath(tokenFile),
+		func(p *stscreds.WebIdentityRoleProvider) {
+			if duration > 0 {
+				p.Duration = duration
+			}
+		})
+	return credentials.NewCredentials(provider)
+}
@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAwsConfigCachesAssumedRoleCredentials(t *testing.T) {
+	authorization := AuthorizationMetadata{
+		AwsAccessKeyID:     "key",
+		AwsSecretAccessKey: "secret",
+		AwsChainedRoleArns: []string{"arn:aws:iam::222222222222:role/a"},
+		AwsExternalID:      "keda",
+		PodIdentityOwner:   true,
+	}
+
+	_, config := GetAwsConfig("eu-west-1", authorization)
+	_, otherRegionConfig := GetAwsConfig("us-east-1", authorization)
+	assert.Same(t, config.Credentials, otherRegionConfig.Credentials, "credentials of the same role should be shared")
+
+	authorization.AwsExternalID = "other"
+	_, otherExternalIDConfig := GetAwsConfig("eu-west-1", authorization)
+	assert.NotSame(t, config.Credentials, otherExternalIDConfig.Credentials)
+
+	authorization.AwsSecretAccessKey = "other"
+	authorization.AwsExternalID = "keda"
+	_, otherSecretConfig := GetAwsConfig("eu-west-1", authorization)
+	assert.NotSame(t, config.Credentials, otherSecretConfig.Credentials)
+}
+
+func TestGetAwsConfigOperatorIdentity(t *testing.T) {
+	_, config := GetAwsConfig("eu-west-1", AuthorizationMetadata{})
+	assert.Nil(t, config.Credentials, "default credentials of the KEDA operator should be used")
+
+	_, config = GetAwsConfig("eu-west-1", AuthorizationMetadata{AwsAccessKeyID: "key", AwsSecretAccessKey: "secret", PodIdentityOwner: true})
+	value, err := config.Credentials.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "key", value.AccessKeyID)
+}
+
+func TestGetAssumedRoleCredentialsEvictsUnusedCredentials(t *testing.T) {
+	newCredentials := func() *credentials.Credentials {
+		return credentials.NewStaticCredentials("key", "secret", "")
+	}
+	unused := getAssumedRoleCredentials("unused", newCredentials)
+	used := getAssumedRoleCredentials("used", newCredentials)
+
+	assumedRoleCredentials.Lock()
+	assumedRoleCredentials.items["unused"].lastUsed = time.Now().Add(-unusedCredentialsTTL - time.Minute)
+	assumedRoleCredentials.Unlock()
+
+	assert.Same(t, used, getAssumedRoleCredentials("used", newCredentials))
+	assert.NotSame(t, unused, getAssumedRoleCredentials("unused", newCredentials), "unused credentials should be evicted")
+}
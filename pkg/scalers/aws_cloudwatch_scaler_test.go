@@ -12,6 +12,8 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/labels"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
 )
 
 const (
@@ -376,7 +378,7 @@ var awsCloudwatchGetMetricTestData = []awsCloudwatchMetadata{
 		metricStatPeriod:     60,
 		metricEndTimeOffset:  60,
 		awsRegion:            "us-west-2",
-		awsAuthorization:     awsAuthorizationMetadata{podIdentityOwner: false},
+		awsAuthorization:     awsutils.AuthorizationMetadata{PodIdentityOwner: false},
 		scalerIndex:          0,
 	},
 	{
@@ -392,7 +394,7 @@ var awsCloudwatchGetMetricTestData = []awsCloudwatchMetadata{
 		metricStatPeriod:     60,
 		metricEndTimeOffset:  60,
 		awsRegion:            "us-west-2",
-		awsAuthorization:     awsAuthorizationMetadata{podIdentityOwner: false},
+		awsAuthorization:     awsutils.AuthorizationMetadata{PodIdentityOwner: false},
 		scalerIndex:          0,
 	},
 	{
@@ -408,7 +410,7 @@ var awsCloudwatchGetMetricTestData = []awsCloudwatchMetadata{
 		metricStatPeriod:     60,
 		metricEndTimeOffset:  60,
 		awsRegion:            "us-west-2",
-		awsAuthorization:     awsAuthorizationMetadata{podIdentityOwner: false},
+		awsAuthorization:     awsutils.AuthorizationMetadata{PodIdentityOwner: false},
 		scalerIndex:          0,
 	},
 	{
@@ -424,7 +426,7 @@ var awsCloudwatchGetMetricTestData = []awsCloudwatchMetadata{
 		metricStatPeriod:     60,
 		metricEndTimeOffset:  60,
 		awsRegion:            "us-west-2",
-		awsAuthorization:     awsAuthorizationMetadata{podIdentityOwner: false},
+		awsAuthorization:     awsutils.AuthorizationMetadata{PodIdentityOwner: false},
 		scalerIndex:          0,
 	},
 	{
@@ -439,7 +441,7 @@ var awsCloudwatchGetMetricTestData = []awsCloudwatchMetadata{
 		metricStatPeriod:     60,
 		metricEndTimeOffset:  60,
 		awsRegion:            "us-west-2",
-		awsAuthorization:     awsAuthorizationMetadata{podIdentityOwner: false},
+		awsAuthorization:     awsutils.AuthorizationMetadata{PodIdentityOwner: false},
 		scalerIndex:          0,
 	},
 }
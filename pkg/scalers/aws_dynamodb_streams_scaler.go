@@ -5,10 +5,6 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/dynamodbstreams"
@@ -20,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -41,7 +38,7 @@ type awsDynamoDBStreamsMetadata struct {
 	activationTargetShardCount int64
 	tableName                  string
 	awsRegion                  string
-	awsAuthorization           awsAuthorizationMetadata
+	awsAuthorization           awsutils.AuthorizationMetadata
 	scalerIndex                int
 }
 
@@ -110,7 +107,7 @@ func parseAwsDynamoDBStreamsMetadata(config *ScalerConfig, logger logr.Logger) (
 		}
 	}
 
-	auth, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	auth, err := awsutils.GetAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv, config.PodIdentity.Provider)
 	if err != nil {
 		return nil, err
 	}
@@ -122,35 +119,8 @@ func parseAwsDynamoDBStreamsMetadata(config *ScalerConfig, logger logr.Logger) (
 }
 
 func createClientsForDynamoDBStreamsScaler(metadata *awsDynamoDBStreamsMetadata) (*dynamodb.DynamoDB, *dynamodbstreams.DynamoDBStreams) {
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String(metadata.awsRegion),
-	}))
-
-	var dbClient *dynamodb.DynamoDB
-	var dbStreamClient *dynamodbstreams.DynamoDBStreams
-
-	if metadata.awsAuthorization.podIdentityOwner {
-		creds := credentials.NewStaticCredentials(metadata.awsAuthorization.awsAccessKeyID, metadata.awsAuthorization.awsSecretAccessKey, metadata.awsAuthorization.awsSessionToken)
-		if metadata.awsAuthorization.awsRoleArn != "" {
-			creds = stscreds.NewCredentials(sess, metadata.awsAuthorization.awsRoleArn)
-		}
-		dbClient = dynamodb.New(sess, &aws.Config{
-			Region:      aws.String(metadata.awsRegion),
-			Credentials: creds,
-		})
-		dbStreamClient = dynamodbstreams.New(sess, &aws.Config{
-			Region:      aws.String(metadata.awsRegion),
-			Credentials: creds,
-		})
-	} else {
-		dbClient = dynamodb.New(sess, &aws.Config{
-			Region: aws.String(metadata.awsRegion),
-		})
-		dbStreamClient = dynamodbstreams.New(sess, &aws.Config{
-			Region: aws.String(metadata.awsRegion),
-		})
-	}
-	return dbClient, dbStreamClient
+	sess, config := awsutils.GetAwsConfig(metadata.awsRegion, metadata.awsAuthorization)
+	return dynamodb.New(sess, config), dynamodbstreams.New(sess, config)
 }
 
 func getDynamoDBStreamsArn(ctx context.Context, db dynamodbiface.DynamoDBAPI, tableName *string) (*string, error) {
@@ -17,6 +17,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
 )
 
 const (
@@ -139,10 +141,10 @@ var testAwsDynamoDBStreamMetadata = []parseAwsDynamoDBStreamsMetadataTestData{
 			activationTargetShardCount: 1,
 			tableName:                  testAWSDynamoDBSmallTable,
 			awsRegion:                  testAWSDynamoDBStreamsRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				awsAccessKeyID:     testAWSDynamoDBStreamsAccessKeyID,
-				awsSecretAccessKey: testAWSDynamoDBStreamsSecretAccessKey,
-				podIdentityOwner:   true,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsAccessKeyID:     testAWSDynamoDBStreamsAccessKeyID,
+				AwsSecretAccessKey: testAWSDynamoDBStreamsSecretAccessKey,
+				PodIdentityOwner:   true,
 			},
 			scalerIndex: 0,
 		},
@@ -183,10 +185,10 @@ var testAwsDynamoDBStreamMetadata = []parseAwsDynamoDBStreamsMetadataTestData{
 			activationTargetShardCount: defaultActivationTargetDBStreamsShardCount,
 			tableName:                  testAWSDynamoDBSmallTable,
 			awsRegion:                  testAWSDynamoDBStreamsRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				awsAccessKeyID:     testAWSDynamoDBStreamsAccessKeyID,
-				awsSecretAccessKey: testAWSDynamoDBStreamsSecretAccessKey,
-				podIdentityOwner:   true,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsAccessKeyID:     testAWSDynamoDBStreamsAccessKeyID,
+				AwsSecretAccessKey: testAWSDynamoDBStreamsSecretAccessKey,
+				PodIdentityOwner:   true,
 			},
 			scalerIndex: 3,
 		},
@@ -204,10 +206,10 @@ var testAwsDynamoDBStreamMetadata = []parseAwsDynamoDBStreamsMetadataTestData{
 			targetShardCount: defaultTargetDBStreamsShardCount,
 			tableName:        testAWSDynamoDBSmallTable,
 			awsRegion:        testAWSDynamoDBStreamsRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				awsAccessKeyID:     testAWSDynamoDBStreamsAccessKeyID,
-				awsSecretAccessKey: testAWSDynamoDBStreamsSecretAccessKey,
-				podIdentityOwner:   true,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsAccessKeyID:     testAWSDynamoDBStreamsAccessKeyID,
+				AwsSecretAccessKey: testAWSDynamoDBStreamsSecretAccessKey,
+				PodIdentityOwner:   true,
 			},
 			scalerIndex: 4,
 		},
@@ -256,11 +258,11 @@ var testAwsDynamoDBStreamMetadata = []parseAwsDynamoDBStreamsMetadataTestData{
 			targetShardCount: 2,
 			tableName:        testAWSDynamoDBSmallTable,
 			awsRegion:        testAWSDynamoDBStreamsRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				awsAccessKeyID:     testAWSDynamoDBStreamsAccessKeyID,
-				awsSecretAccessKey: testAWSDynamoDBStreamsSecretAccessKey,
-				awsSessionToken:    testAWSDynamoDBStreamsSessionToken,
-				podIdentityOwner:   true,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsAccessKeyID:     testAWSDynamoDBStreamsAccessKeyID,
+				AwsSecretAccessKey: testAWSDynamoDBStreamsSecretAccessKey,
+				AwsSessionToken:    testAWSDynamoDBStreamsSessionToken,
+				PodIdentityOwner:   true,
 			},
 			scalerIndex: 5,
 		},
@@ -308,9 +310,9 @@ var testAwsDynamoDBStreamMetadata = []parseAwsDynamoDBStreamsMetadataTestData{
 			targetShardCount: 2,
 			tableName:        testAWSDynamoDBSmallTable,
 			awsRegion:        testAWSDynamoDBStreamsRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				awsRoleArn:       testAWSDynamoDBStreamsRoleArn,
-				podIdentityOwner: true,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsRoleArn:       testAWSDynamoDBStreamsRoleArn,
+				PodIdentityOwner: true,
 			},
 			scalerIndex: 7,
 		},
@@ -328,8 +330,8 @@ var testAwsDynamoDBStreamMetadata = []parseAwsDynamoDBStreamsMetadataTestData{
 			targetShardCount: 2,
 			tableName:        testAWSDynamoDBSmallTable,
 			awsRegion:        testAWSDynamoDBStreamsRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				podIdentityOwner: false,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				PodIdentityOwner: false,
 			},
 			scalerIndex: 8,
 		},
@@ -12,6 +12,8 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/labels"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
 )
 
 const (
@@ -79,10 +81,10 @@ var testAWSKinesisMetadata = []parseAWSKinesisMetadataTestData{
 			activationTargetShardCount: 1,
 			streamName:                 testAWSKinesisStreamName,
 			awsRegion:                  testAWSRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				awsAccessKeyID:     testAWSKinesisAccessKeyID,
-				awsSecretAccessKey: testAWSKinesisSecretAccessKey,
-				podIdentityOwner:   true,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsAccessKeyID:     testAWSKinesisAccessKeyID,
+				AwsSecretAccessKey: testAWSKinesisSecretAccessKey,
+				PodIdentityOwner:   true,
 			},
 			scalerIndex: 0,
 		},
@@ -124,10 +126,10 @@ var testAWSKinesisMetadata = []parseAWSKinesisMetadataTestData{
 			activationTargetShardCount: activationTargetShardCountDefault,
 			streamName:                 testAWSKinesisStreamName,
 			awsRegion:                  testAWSRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				awsAccessKeyID:     testAWSKinesisAccessKeyID,
-				awsSecretAccessKey: testAWSKinesisSecretAccessKey,
-				podIdentityOwner:   true,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsAccessKeyID:     testAWSKinesisAccessKeyID,
+				AwsSecretAccessKey: testAWSKinesisSecretAccessKey,
+				PodIdentityOwner:   true,
 			},
 			scalerIndex: 3,
 		},
@@ -145,10 +147,10 @@ var testAWSKinesisMetadata = []parseAWSKinesisMetadataTestData{
 			targetShardCount: 2,
 			streamName:       testAWSKinesisStreamName,
 			awsRegion:        testAWSRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				awsAccessKeyID:     testAWSKinesisAccessKeyID,
-				awsSecretAccessKey: testAWSKinesisSecretAccessKey,
-				podIdentityOwner:   true,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsAccessKeyID:     testAWSKinesisAccessKeyID,
+				AwsSecretAccessKey: testAWSKinesisSecretAccessKey,
+				PodIdentityOwner:   true,
 			},
 			scalerIndex: 4,
 		},
@@ -197,11 +199,11 @@ var testAWSKinesisMetadata = []parseAWSKinesisMetadataTestData{
 			targetShardCount: 2,
 			streamName:       testAWSKinesisStreamName,
 			awsRegion:        testAWSRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				awsAccessKeyID:     testAWSKinesisAccessKeyID,
-				awsSecretAccessKey: testAWSKinesisSecretAccessKey,
-				awsSessionToken:    testAWSKinesisSessionToken,
-				podIdentityOwner:   true,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsAccessKeyID:     testAWSKinesisAccessKeyID,
+				AwsSecretAccessKey: testAWSKinesisSecretAccessKey,
+				AwsSessionToken:    testAWSKinesisSessionToken,
+				PodIdentityOwner:   true,
 			},
 			scalerIndex: 5,
 		},
@@ -249,9 +251,9 @@ var testAWSKinesisMetadata = []parseAWSKinesisMetadataTestData{
 			targetShardCount: 2,
 			streamName:       testAWSKinesisStreamName,
 			awsRegion:        testAWSRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				awsRoleArn:       testAWSKinesisRoleArn,
-				podIdentityOwner: true,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				AwsRoleArn:       testAWSKinesisRoleArn,
+				PodIdentityOwner: true,
 			},
 			scalerIndex: 7,
 		},
@@ -269,8 +271,8 @@ var testAWSKinesisMetadata = []parseAWSKinesisMetadataTestData{
 			targetShardCount: 2,
 			streamName:       testAWSKinesisStreamName,
 			awsRegion:        testAWSRegion,
-			awsAuthorization: awsAuthorizationMetadata{
-				podIdentityOwner: false,
+			awsAuthorization: awsutils.AuthorizationMetadata{
+				PodIdentityOwner: false,
 			},
 			scalerIndex: 8,
 		},
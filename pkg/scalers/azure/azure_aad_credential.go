@@ -0,0 +1,279 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	amqpAuth "github.com/Azure/azure-amqp-common-go/v3/auth"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/confidential"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+// tokenRefreshMargin is the time before the expiration of a token when it is refreshed
+const tokenRefreshMargin = 2 * time.Minute
+
+// aadTokens caches the tokens of the identities per resource, so that the scalers using the same identity
+// share the tokens which are refreshed before they expire
+var aadTokens = struct {
+	sync.Mutex
+	items map[string]AADToken
+}{items: map[string]AADToken{}}
+
+// ErrNoAADCredential is returned by the scalers requiring an Azure AD identity when none is given
+var ErrNoAADCredential = errors.New("no azure AD identity given, either a pod identity or the tenantId, clientId and clientSecret or clientCertificate of an application are required")
+
+// AADCredentialConfig holds the Azure AD identity used to authenticate against Azure, either a pod identity
+// or the client secret or certificate of an application
+type AADCredentialConfig struct {
+	PodIdentity kedav1alpha1.AuthPodIdentity
+
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// ClientCertificate is the PEM encoded certificate and private key of the application
+	ClientCertificate         string
+	ClientCertificatePassword string
+
+	ActiveDirectoryEndpoint string
+}
+
+// ParseAADCredentialConfig parses the Azure AD identity of a scaler from the pod identity, or from the client secret
+// or certificate of an application in the auth params, trigger metadata and resolved env,
+// it returns nil if the scaler doesn't use an Azure AD identity
+func ParseAADCredentialConfig(podIdentity kedav1alpha1.AuthPodIdentity, authParams, metadata, resolvedEnv map[string]string) (*AADCredentialConfig, error) {
+	switch podIdentity.Provider {
+	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+		return &AADCredentialConfig{PodIdentity: podIdentity}, nil
+	case "", kedav1alpha1.PodIdentityProviderNone:
+	default:
+		return nil, fmt.Errorf("azure AD authentication doesn't support pod identity %s", podIdentity.Provider)
+	}
+
+	config := &AADCredentialConfig{
+		TenantID: getParameter(authParams, metadata, resolvedEnv, "tenantId"),
+		// activeDirectoryClientId and activeDirectoryClientPassword are the former names of the parameters
+		ClientID:                  getParameter(authParams, metadata, resolvedEnv, "clientId", "activeDirectoryClientId"),
+		ClientSecret:              getParameter(authParams, metadata, resolvedEnv, "clientSecret", "activeDirectoryClientPassword"),
+		ClientCertificate:         getParameter(authParams, metadata, resolvedEnv, "clientCertificate"),
+		ClientCertificatePassword: getParameter(authParams, metadata, resolvedEnv, "clientCertificatePassword"),
+	}
+
+	switch {
+	case config.ClientSecret == "" && config.ClientCertificate == "":
+		return nil, nil
+	case config.ClientSecret != "" && config.ClientCertificate != "":
+		return nil, fmt.Errorf("clientSecret and clientCertificate can't be provided together")
+	case config.TenantID == "":
+		return nil, fmt.Errorf("no tenantId given")
+	case config.ClientID == "":
+		return nil, fmt.Errorf("no clientId given")
+	}
+
+	activeDirectoryEndpoint, err := ParseActiveDirectoryEndpoint(metadata)
+	if err != nil {
+		return nil, err
+	}
+	config.ActiveDirectoryEndpoint = activeDirectoryEndpoint
+
+	if config.ClientCertificate != "" {
+		if _, _, err := confidential.CertFromPEM([]byte(config.ClientCertificate), config.ClientCertificatePassword); err != nil {
+			return nil, fmt.Errorf("error parsing clientCertificate: %s", err)
+		}
+	}
+
+	return config, nil
+}
+
+// getParameter returns the first of the parameters given in the auth params, the trigger metadata, or the env
+// referenced by the FromEnv suffixed parameter in the trigger metadata
+func getParameter(authParams, metadata, resolvedEnv map[string]string, names ...string) string {
+	for _, name := range names {
+		if val := authParams[name]; val != "" {
+			return val
+		}
+		if val := metadata[name]; val != "" {
+			return val
+		}
+		if val := resolvedEnv[metadata[name+"FromEnv"]]; metadata[name+"FromEnv"] != "" && val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// AADCredential acquires the Azure AD tokens of an identity for the Azure resources,
+// the tokens are shared with the other credentials of the same identity and refreshed before they expire
+type AADCredential struct {
+	config     AADCredentialConfig
+	httpClient util.HTTPDoer
+}
+
+// NewAADCredential returns the credential of the Azure AD identity, the http client is used to get the tokens of the AAD pod identity
+func NewAADCredential(config AADCredentialConfig, httpClient util.HTTPDoer) *AADCredential {
+	return &AADCredential{config: config, httpClient: httpClient}
+}
+
+// GetToken returns a valid token for the resource
+func (c *AADCredential) GetToken(ctx context.Context, resource string) (AADToken, error) {
+	cacheKey := c.cacheKey(resource)
+
+	aadTokens.Lock()
+	token, ok := aadTokens.items[cacheKey]
+	aadTokens.Unlock()
+	if ok && time.Now().Add(tokenRefreshMargin).Before(token.ExpiresOnTimeObject) {
+		return token, nil
+	}
+
+	token, err := c.acquireToken(ctx, resource)
+	if err != nil {
+		return AADToken{}, err
+	}
+
+	aadTokens.Lock()
+	aadTokens.items[cacheKey] = token
+	aadTokens.Unlock()
+	return token, nil
+}
+
+func (c *AADCredential) acquireToken(ctx context.Context, resource string) (AADToken, error) {
+	switch c.config.PodIdentity.Provider {
+	case kedav1alpha1.PodIdentityProviderAzure:
+		token, err := GetAzureADPodIdentityToken(ctx, c.httpClient, c.config.PodIdentity.IdentityID, resource)
+		if err != nil {
+			return AADToken{}, err
+		}
+		expiresOn, err := strconv.ParseInt(token.ExpiresOn, 10, 64)
+		if err != nil {
+			return AADToken{}, fmt.Errorf("error parsing expiration of aad token - %w", err)
+		}
+		token.ExpiresOnTimeObject = time.Unix(expiresOn, 0)
+		return token, nil
+	case kedav1alpha1.PodIdentityProviderAzureWorkload:
+		return GetAzureADWorkloadIdentityToken(ctx, c.config.PodIdentity.IdentityID, resource)
+	}
+
+	var cred confidential.Credential
+	var err error
+	if c.config.ClientCertificate != "" {
+		certs, key, certErr := confidential.CertFromPEM([]byte(c.config.ClientCertificate), c.config.ClientCertificatePassword)
+		if certErr != nil {
+			return AADToken{}, fmt.Errorf("error parsing client certificate - %w", certErr)
+		}
+		cred = confidential.NewCredFromCert(certs[0], key)
+	} else {
+		cred, err = confidential.NewCredFromSecret(c.config.ClientSecret)
+		if err != nil {
+			return AADToken{}, fmt.Errorf("error getting credentials from client secret - %w", err)
+		}
+	}
+
+	authority := fmt.Sprintf("%s/%s", strings.TrimSuffix(c.config.ActiveDirectoryEndpoint, "/"), c.config.TenantID)
+	confidentialClient, err := confidential.New(c.config.ClientID, cred, confidential.WithAuthority(authority))
+	if err != nil {
+		return AADToken{}, fmt.Errorf("error creating confidential client - %w", err)
+	}
+
+	result, err := confidentialClient.AcquireTokenByCredential(ctx, []string{getScopedResource(resource)})
+	if err != nil {
+		return AADToken{}, fmt.Errorf("error acquiring aad token - %w", err)
+	}
+
+	return AADToken{
+		AccessToken:         result.AccessToken,
+		ExpiresOn:           strconv.FormatInt(result.ExpiresOn.Unix(), 10),
+		ExpiresOnTimeObject: result.ExpiresOn,
+		Resource:            resource,
+		GrantedScopes:       result.GrantedScopes,
+		DeclinedScopes:      result.DeclinedScopes,
+	}, nil
+}
+
+// cacheKey identifies the tokens of the identity for the resource, the secrets are hashed
+func (c *AADCredential) cacheKey(resource string) string {
+	if c.config.PodIdentity.Provider != "" && c.config.PodIdentity.Provider != kedav1alpha1.PodIdentityProviderNone {
+		return fmt.Sprintf("%s/%s|%s", c.config.PodIdentity.Provider, c.config.PodIdentity.IdentityID, resource)
+	}
+	secretHash := sha256.Sum256([]byte(c.config.ClientSecret + c.config.ClientCertificate + c.config.ClientCertificatePassword))
+	return fmt.Sprintf("%s%s/%s/%s|%s", c.config.ActiveDirectoryEndpoint, c.config.TenantID, c.config.ClientID, hex.EncodeToString(secretHash[:]), resource)
+}
+
+// Authorizer returns an autorest.Authorizer adding the token for the resource to the requests
+func (c *AADCredential) Authorizer(ctx context.Context, resource string) autorest.Authorizer {
+	return autorest.NewBearerAuthorizer(&aadCredentialTokenProvider{ctx: ctx, credential: c, resource: resource})
+}
+
+// TokenProvider returns the token provider of the AMQP clients of Event Hub and Service Bus for the resource
+func (c *AADCredential) TokenProvider(ctx context.Context, resource string) amqpAuth.TokenProvider {
+	return &aadCredentialTokenProvider{ctx: ctx, credential: c, resource: resource}
+}
+
+// aadCredentialTokenProvider implements the adal.OAuthTokenProvider and adal.Refresher interfaces used by
+// the BearerAuthorizer, and the auth.TokenProvider interface used by the AMQP clients
+type aadCredentialTokenProvider struct {
+	ctx        context.Context
+	credential *AADCredential
+	resource   string
+	token      AADToken
+}
+
+// OAuthToken is for implementing the adal.OAuthTokenProvider interface. It returns the current access token.
+func (p *aadCredentialTokenProvider) OAuthToken() string {
+	return p.token.AccessToken
+}
+
+// Refresh is for implementing the adal.Refresher interface
+func (p *aadCredentialTokenProvider) Refresh() error {
+	token, err := p.credential.GetToken(p.ctx, p.resource)
+	if err != nil {
+		return err
+	}
+
+	p.token = token
+	return nil
+}
+
+// RefreshExchange is for implementing the adal.Refresher interface
+func (p *aadCredentialTokenProvider) RefreshExchange(resource string) error {
+	p.resource = resource
+	return p.Refresh()
+}
+
+// EnsureFresh is for implementing the adal.Refresher interface
+func (p *aadCredentialTokenProvider) EnsureFresh() error {
+	return p.Refresh()
+}
+
+// GetToken is for implementing the auth.TokenProvider interface
+func (p *aadCredentialTokenProvider) GetToken(uri string) (*amqpAuth.Token, error) {
+	if err := p.Refresh(); err != nil {
+		return nil, err
+	}
+
+	return amqpAuth.NewToken(amqpAuth.CBSTokenTypeJWT, p.token.AccessToken, p.token.ExpiresOn), nil
+}
@@ -0,0 +1,116 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+type parseAADCredentialConfigTestData struct {
+	name        string
+	podIdentity kedav1alpha1.PodIdentityProvider
+	authParams  map[string]string
+	metadata    map[string]string
+	resolvedEnv map[string]string
+	isNil       bool
+	isError     bool
+}
+
+var parseAADCredentialConfigTestDataset = []parseAADCredentialConfigTestData{
+	{"azure pod identity", kedav1alpha1.PodIdentityProviderAzure, map[string]string{}, map[string]string{}, map[string]string{}, false, false},
+	{"azure workload identity", kedav1alpha1.PodIdentityProviderAzureWorkload, map[string]string{}, map[string]string{}, map[string]string{}, false, false},
+	{"unsupported pod identity", kedav1alpha1.PodIdentityProviderAwsEKS, map[string]string{}, map[string]string{}, map[string]string{}, true, true},
+	{"no identity", "", map[string]string{}, map[string]string{}, map[string]string{}, true, false},
+	{"none pod identity", kedav1alpha1.PodIdentityProviderNone, map[string]string{}, map[string]string{}, map[string]string{}, true, false},
+	{"client secret in auth params", "", map[string]string{"tenantId": "tenant", "clientId": "client", "clientSecret": "secret"}, map[string]string{}, map[string]string{}, false, false},
+	{"client secret in metadata", "", map[string]string{}, map[string]string{"tenantId": "tenant", "clientId": "client", "clientSecret": "secret"}, map[string]string{}, false, false},
+	{"client secret from env", "", map[string]string{}, map[string]string{"tenantIdFromEnv": "TENANT", "clientIdFromEnv": "CLIENT", "clientSecretFromEnv": "SECRET"}, map[string]string{"TENANT": "tenant", "CLIENT": "client", "SECRET": "secret"}, false, false},
+	{"former parameter names", "", map[string]string{"tenantId": "tenant", "activeDirectoryClientId": "client", "activeDirectoryClientPassword": "secret"}, map[string]string{}, map[string]string{}, false, false},
+	{"missing tenantId", "", map[string]string{"clientId": "client", "clientSecret": "secret"}, map[string]string{}, map[string]string{}, true, true},
+	{"missing clientId", "", map[string]string{"tenantId": "tenant", "clientSecret": "secret"}, map[string]string{}, map[string]string{}, true, true},
+	{"client secret and certificate", "", map[string]string{"tenantId": "tenant", "clientId": "client", "clientSecret": "secret", "clientCertificate": "certificate"}, map[string]string{}, map[string]string{}, true, true},
+	{"invalid client certificate", "", map[string]string{"tenantId": "tenant", "clientId": "client", "clientCertificate": "certificate"}, map[string]string{}, map[string]string{}, true, true},
+	{"private cloud without activeDirectoryEndpoint", "", map[string]string{"tenantId": "tenant", "clientId": "client", "clientSecret": "secret"}, map[string]string{"cloud": PrivateCloud}, map[string]string{}, true, true},
+	{"pod identity in private cloud without activeDirectoryEndpoint", kedav1alpha1.PodIdentityProviderAzure, map[string]string{}, map[string]string{"cloud": PrivateCloud}, map[string]string{}, false, false},
+}
+
+func TestParseAADCredentialConfig(t *testing.T) {
+	for _, testData := range parseAADCredentialConfigTestDataset {
+		t.Run(testData.name, func(t *testing.T) {
+			config, err := ParseAADCredentialConfig(kedav1alpha1.AuthPodIdentity{Provider: testData.podIdentity}, testData.authParams, testData.metadata, testData.resolvedEnv)
+			if err != nil && !testData.isError {
+				t.Error("Expected success but got error", err)
+			}
+			if testData.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+			if testData.isNil != (config == nil) {
+				t.Errorf("Expected nil config to be %v but got %v", testData.isNil, config)
+			}
+			if config != nil && config.PodIdentity.Provider == "" && (config.TenantID != "tenant" || config.ClientID != "client" || config.ClientSecret != "secret") {
+				t.Errorf("Expected the client credentials to be parsed but got %v", config)
+			}
+		})
+	}
+}
+
+type countingPodIdentityClient struct {
+	requests int
+	expireIn time.Duration
+}
+
+func (c *countingPodIdentityClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests++
+	body := fmt.Sprintf(`{"access_token":"token-%d","expires_on":"%d"}`, c.requests, time.Now().Add(c.expireIn).Unix())
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestAADCredentialGetTokenIsShared(t *testing.T) {
+	client := &countingPodIdentityClient{expireIn: time.Hour}
+	config := AADCredentialConfig{PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure, IdentityID: "shared"}}
+
+	first, err := NewAADCredential(config, client).GetToken(context.Background(), "https://shared.resource")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	second, err := NewAADCredential(config, client).GetToken(context.Background(), "https://shared.resource")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	if client.requests != 1 || first.AccessToken != second.AccessToken {
+		t.Errorf("Expected the token to be acquired once and shared, but got %d requests", client.requests)
+	}
+
+	if _, err := NewAADCredential(config, client).GetToken(context.Background(), "https://other.resource"); err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if client.requests != 2 {
+		t.Errorf("Expected a new token for another resource, but got %d requests", client.requests)
+	}
+}
+
+func TestAADCredentialGetTokenRefreshesExpiringToken(t *testing.T) {
+	client := &countingPodIdentityClient{expireIn: time.Minute}
+	config := AADCredentialConfig{PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure, IdentityID: "expiring"}}
+	credential := NewAADCredential(config, client)
+
+	first, err := credential.GetToken(context.Background(), "https://expiring.resource")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	second, err := credential.GetToken(context.Background(), "https://expiring.resource")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	if client.requests != 2 || first.AccessToken == second.AccessToken {
+		t.Errorf("Expected the token expiring within the refresh margin to be refreshed, but got %d requests", client.requests)
+	}
+}
@@ -8,10 +8,9 @@ import (
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
-	"github.com/Azure/go-autorest/autorest/azure/auth"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 const (
@@ -25,16 +24,13 @@ var AppInsightsResourceURLInCloud = map[string]string{
 }
 
 type AppInsightsInfo struct {
-	ApplicationInsightsID   string
-	TenantID                string
-	MetricID                string
-	AggregationTimespan     string
-	AggregationType         string
-	Filter                  string
-	ClientID                string
-	ClientPassword          string
-	AppInsightsResourceURL  string
-	ActiveDirectoryEndpoint string
+	ApplicationInsightsID  string
+	MetricID               string
+	AggregationTimespan    string
+	AggregationType        string
+	Filter                 string
+	AppInsightsResourceURL string
+	AADConfig              AADCredentialConfig
 }
 
 type ApplicationInsightsMetric struct {
@@ -59,24 +55,6 @@ func toISO8601(time string) (string, error) {
 	return fmt.Sprintf("PT%02dH%02dM", hours, minutes), nil
 }
 
-func getAuthConfig(ctx context.Context, info AppInsightsInfo, podIdentity kedav1alpha1.AuthPodIdentity) auth.AuthorizerConfig {
-	switch podIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		config := auth.NewClientCredentialsConfig(info.ClientID, info.ClientPassword, info.TenantID)
-		config.Resource = info.AppInsightsResourceURL
-		config.AADEndpoint = info.ActiveDirectoryEndpoint
-		return config
-	case kedav1alpha1.PodIdentityProviderAzure:
-		config := auth.NewMSIConfig()
-		config.Resource = info.AppInsightsResourceURL
-		config.ClientID = podIdentity.IdentityID
-		return config
-	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		return NewAzureADWorkloadIdentityConfig(ctx, podIdentity.IdentityID, info.AppInsightsResourceURL)
-	}
-	return nil
-}
-
 func extractAppInsightValue(info AppInsightsInfo, metric ApplicationInsightsMetric) (float64, error) {
 	if _, ok := metric.Value[info.MetricID]; !ok {
 		return -1, fmt.Errorf("metric named %s not found in app insights response", info.MetricID)
@@ -115,12 +93,8 @@ func queryParamsForAppInsightsRequest(info AppInsightsInfo) (map[string]interfac
 }
 
 // GetAzureAppInsightsMetricValue returns the value of an Azure App Insights metric, rounded to the nearest int
-func GetAzureAppInsightsMetricValue(ctx context.Context, info AppInsightsInfo, podIdentity kedav1alpha1.AuthPodIdentity) (float64, error) {
-	config := getAuthConfig(ctx, info, podIdentity)
-	authorizer, err := config.Authorizer()
-	if err != nil {
-		return -1, err
-	}
+func GetAzureAppInsightsMetricValue(ctx context.Context, httpClient util.HTTPDoer, info AppInsightsInfo) (float64, error) {
+	authorizer := NewAADCredential(info.AADConfig, httpClient).Authorizer(ctx, info.AppInsightsResourceURL)
 
 	queryParams, err := queryParamsForAppInsightsRequest(info)
 	if err != nil {
@@ -22,7 +22,6 @@ import (
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/gobwas/glob"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -41,8 +40,8 @@ type BlobMetadata struct {
 }
 
 // GetAzureBlobListLength returns the count of the blobs in blob container in int
-func GetAzureBlobListLength(ctx context.Context, httpClient util.HTTPDoer, podIdentity kedav1alpha1.AuthPodIdentity, meta *BlobMetadata) (int64, error) {
-	credential, endpoint, err := ParseAzureStorageBlobConnection(ctx, httpClient, podIdentity, meta.Connection, meta.AccountName, meta.EndpointSuffix)
+func GetAzureBlobListLength(ctx context.Context, httpClient util.HTTPDoer, aadConfig *AADCredentialConfig, meta *BlobMetadata) (int64, error) {
+	credential, endpoint, err := ParseAzureStorageBlobConnection(ctx, httpClient, aadConfig, meta.Connection, meta.AccountName, meta.EndpointSuffix)
 	if err != nil {
 		return -1, err
 	}
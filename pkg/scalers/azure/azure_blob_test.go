@@ -5,15 +5,13 @@ import (
 	"net/http"
 	"strings"
 	"testing"
-
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
 func TestGetBlobLength(t *testing.T) {
 	httpClient := http.DefaultClient
 
 	meta := BlobMetadata{Connection: "", BlobContainerName: "blobContainerName", AccountName: "", BlobDelimiter: "", BlobPrefix: "", EndpointSuffix: ""}
-	length, err := GetAzureBlobListLength(context.TODO(), httpClient, kedav1alpha1.AuthPodIdentity{}, &meta)
+	length, err := GetAzureBlobListLength(context.TODO(), httpClient, nil, &meta)
 	if length != -1 {
 		t.Error("Expected length to be -1, but got", length)
 	}
@@ -27,7 +25,7 @@ func TestGetBlobLength(t *testing.T) {
 	}
 
 	meta.Connection = "DefaultEndpointsProtocol=https;AccountName=name;AccountKey=key==;EndpointSuffix=core.windows.net"
-	length, err = GetAzureBlobListLength(context.TODO(), httpClient, kedav1alpha1.AuthPodIdentity{}, &meta)
+	length, err = GetAzureBlobListLength(context.TODO(), httpClient, nil, &meta)
 
 	if length != -1 {
 		t.Error("Expected length to be -1, but got", length)
@@ -25,38 +25,25 @@ import (
 	"github.com/Azure/azure-kusto-go/kusto"
 	"github.com/Azure/azure-kusto-go/kusto/data/table"
 	"github.com/Azure/azure-kusto-go/kusto/unsafe"
-	"github.com/Azure/go-autorest/autorest/azure/auth"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 type DataExplorerMetadata struct {
-	ClientID                string
-	ClientSecret            string
-	DatabaseName            string
-	Endpoint                string
-	MetricName              string
-	PodIdentity             kedav1alpha1.AuthPodIdentity
-	Query                   string
-	TenantID                string
-	Threshold               float64
-	ActivationThreshold     float64
-	ActiveDirectoryEndpoint string
+	DatabaseName        string
+	Endpoint            string
+	MetricName          string
+	Query               string
+	Threshold           float64
+	ActivationThreshold float64
+	AADConfig           AADCredentialConfig
 }
 
 var azureDataExplorerLogger = logf.Log.WithName("azure_data_explorer_scaler")
 
-func CreateAzureDataExplorerClient(ctx context.Context, metadata *DataExplorerMetadata) (*kusto.Client, error) {
-	authConfig, err := getDataExplorerAuthConfig(ctx, metadata)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get data explorer auth config: %v", err)
-	}
-
-	authorizer, err := authConfig.Authorizer()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get authorizer: %v", err)
-	}
+func CreateAzureDataExplorerClient(ctx context.Context, httpClient util.HTTPDoer, metadata *DataExplorerMetadata) (*kusto.Client, error) {
+	authorizer := NewAADCredential(metadata.AADConfig, httpClient).Authorizer(ctx, metadata.Endpoint)
 
 	client, err := kusto.New(metadata.Endpoint, kusto.Authorization{Authorizer: authorizer})
 	if err != nil {
@@ -66,37 +53,6 @@ func CreateAzureDataExplorerClient(ctx context.Context, metadata *DataExplorerMe
 	return client, nil
 }
 
-func getDataExplorerAuthConfig(ctx context.Context, metadata *DataExplorerMetadata) (auth.AuthorizerConfig, error) {
-	var authConfig auth.AuthorizerConfig
-
-	switch metadata.PodIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		if metadata.ClientID != "" && metadata.ClientSecret != "" && metadata.TenantID != "" {
-			config := auth.NewClientCredentialsConfig(metadata.ClientID, metadata.ClientSecret, metadata.TenantID)
-			config.Resource = metadata.Endpoint
-			config.AADEndpoint = metadata.ActiveDirectoryEndpoint
-			azureDataExplorerLogger.V(1).Info("Creating Azure Data Explorer Client using clientID, clientSecret and tenantID")
-
-			authConfig = config
-			return authConfig, nil
-		}
-	case kedav1alpha1.PodIdentityProviderAzure:
-		config := auth.NewMSIConfig()
-		config.Resource = metadata.Endpoint
-		config.ClientID = metadata.PodIdentity.IdentityID
-		azureDataExplorerLogger.V(1).Info("Creating Azure Data Explorer Client using Pod Identity")
-
-		authConfig = config
-		return authConfig, nil
-	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		azureDataExplorerLogger.V(1).Info("Creating Azure Data Explorer Client using Workload Identity")
-		authConfig = NewAzureADWorkloadIdentityConfig(ctx, metadata.PodIdentity.IdentityID, metadata.Endpoint)
-		return authConfig, nil
-	}
-
-	return nil, fmt.Errorf("missing credentials. please reconfigure your scaled object metadata")
-}
-
 func GetAzureDataExplorerMetricValue(ctx context.Context, client *kusto.Client, db string, query string) (float64, error) {
 	azureDataExplorerLogger.V(1).Info("Querying Azure Data Explorer", "db", db, "query", query)
 
@@ -6,11 +6,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/Azure/azure-amqp-common-go/v3/aad"
 	eventhub "github.com/Azure/azure-event-hubs-go/v3"
 	"github.com/Azure/go-autorest/autorest/azure"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 // EventHubInfo to keep event hub connection and resources
@@ -25,52 +24,30 @@ type EventHubInfo struct {
 	ServiceBusEndpointSuffix string
 	ActiveDirectoryEndpoint  string
 	EventHubResourceURL      string
-	PodIdentity              kedav1alpha1.AuthPodIdentity
+	// AADConfig is the Azure AD identity used rather than the connection string if given
+	AADConfig *AADCredentialConfig
 }
 
 const (
 	DefaultEventhubResourceURL = "https://eventhubs.azure.net/"
 )
 
-// GetEventHubClient returns eventhub client
-func GetEventHubClient(ctx context.Context, info EventHubInfo) (*eventhub.Hub, error) {
-	switch info.PodIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		// The user wants to use a connectionstring, not a pod identity
+// GetEventHubClient returns eventhub client, the http client is used to get the tokens of the AAD pod identity
+func GetEventHubClient(ctx context.Context, httpClient util.HTTPDoer, info EventHubInfo) (*eventhub.Hub, error) {
+	if info.AADConfig == nil {
+		// The user wants to use a connectionstring, not an Azure AD identity
 		hub, err := eventhub.NewHubFromConnectionString(info.EventHubConnection)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create hub client: %s", err)
 		}
 		return hub, nil
-	case kedav1alpha1.PodIdentityProviderAzure:
-		env := azure.Environment{ActiveDirectoryEndpoint: info.ActiveDirectoryEndpoint, ServiceBusEndpointSuffix: info.ServiceBusEndpointSuffix}
-		hubEnvOptions := eventhub.HubWithEnvironment(env)
-		// Since there is no connectionstring, then user wants to use AAD Pod identity
-		// Internally, the JWTProvider will use Managed Service Identity to authenticate if no Service Principal info supplied
-		envJWTProviderOption := aad.JWTProviderWithAzureEnvironment(&env)
-		resourceURLJWTProviderOption := aad.JWTProviderWithResourceURI(info.EventHubResourceURL)
-		clientIDJWTProviderOption := func(config *aad.TokenProviderConfiguration) error {
-			config.ClientID = info.PodIdentity.IdentityID
-			return nil
-		}
-
-		provider, aadErr := aad.NewJWTProvider(envJWTProviderOption, resourceURLJWTProviderOption, clientIDJWTProviderOption)
-
-		if aadErr == nil {
-			return eventhub.NewHub(info.Namespace, info.EventHubName, provider, hubEnvOptions)
-		}
-
-		return nil, aadErr
-	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		// User wants to use AAD Workload Identity
-		env := azure.Environment{ActiveDirectoryEndpoint: info.ActiveDirectoryEndpoint, ServiceBusEndpointSuffix: info.ServiceBusEndpointSuffix}
-		hubEnvOptions := eventhub.HubWithEnvironment(env)
-		provider := NewAzureADWorkloadIdentityTokenProvider(ctx, info.PodIdentity.IdentityID, info.EventHubResourceURL)
-
-		return eventhub.NewHub(info.Namespace, info.EventHubName, provider, hubEnvOptions)
 	}
 
-	return nil, fmt.Errorf("event hub does not support pod identity %v", info.PodIdentity)
+	env := azure.Environment{ActiveDirectoryEndpoint: info.ActiveDirectoryEndpoint, ServiceBusEndpointSuffix: info.ServiceBusEndpointSuffix}
+	hubEnvOptions := eventhub.HubWithEnvironment(env)
+	provider := NewAADCredential(*info.AADConfig, httpClient).TokenProvider(ctx, info.EventHubResourceURL)
+
+	return eventhub.NewHub(info.Namespace, info.EventHubName, provider, hubEnvOptions)
 }
 
 // ParseAzureEventHubConnectionString parses Event Hub connection string into (namespace, name)
@@ -258,7 +258,7 @@ func TestShouldParseCheckpointForFunctionWithPodIdentity(t *testing.T) {
 		EventHubName:             "hub-test",
 		EventHubConsumerGroup:    "$Default",
 		ServiceBusEndpointSuffix: "servicebus.windows.net",
-		PodIdentity:              kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure},
+		AADConfig:                &AADCredentialConfig{PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure}},
 	}
 
 	cp := newCheckpointer(eventHubInfo, "0")
@@ -266,7 +266,7 @@ func TestShouldParseCheckpointForFunctionWithPodIdentity(t *testing.T) {
 
 	assert.Equal(t, url.Path, "/azure-webjobs-eventhub/eventhubnamespace.servicebus.windows.net/hub-test/$Default/0")
 
-	eventHubInfo.PodIdentity = kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload}
+	eventHubInfo.AADConfig = &AADCredentialConfig{PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload}}
 	cp = newCheckpointer(eventHubInfo, "0")
 	url, _ = cp.resolvePath(eventHubInfo)
 
@@ -280,7 +280,7 @@ func TestShouldParseCheckpointForFunctionWithCheckpointStrategyAndPodIdentity(t
 		EventHubConsumerGroup:    "$Default",
 		ServiceBusEndpointSuffix: "servicebus.windows.net",
 		CheckpointStrategy:       "azureFunction",
-		PodIdentity:              kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure},
+		AADConfig:                &AADCredentialConfig{PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure}},
 	}
 
 	cp := newCheckpointer(eventHubInfo, "0")
@@ -288,7 +288,7 @@ func TestShouldParseCheckpointForFunctionWithCheckpointStrategyAndPodIdentity(t
 
 	assert.Equal(t, url.Path, "/azure-webjobs-eventhub/eventhubnamespace.servicebus.windows.net/hub-test/$Default/0")
 
-	eventHubInfo.PodIdentity = kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload}
+	eventHubInfo.AADConfig = &AADCredentialConfig{PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload}}
 	cp = newCheckpointer(eventHubInfo, "0")
 	url, _ = cp.resolvePath(eventHubInfo)
 
@@ -372,7 +372,7 @@ func createNewCheckpointInStorage(urlPath string, containerName string, partitio
 	ctx := context.Background()
 
 	credential, endpoint, _ := ParseAzureStorageBlobConnection(ctx, http.DefaultClient,
-		kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, StorageConnectionString, "", "")
+		nil, StorageConnectionString, "", "")
 
 	// Create container
 	path, _ := url.Parse(containerName)
@@ -24,10 +24,9 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
-	"github.com/Azure/go-autorest/autorest/azure/auth"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 // Much of the code in this file is taken from the Azure Kubernetes Metrics Adapter
@@ -49,7 +48,6 @@ type azureExternalMetricRequest struct {
 // MonitorInfo to create metric request
 type MonitorInfo struct {
 	ResourceURI                  string
-	TenantID                     string
 	SubscriptionID               string
 	ResourceGroupName            string
 	Name                         string
@@ -57,17 +55,15 @@ type MonitorInfo struct {
 	Filter                       string
 	AggregationInterval          string
 	AggregationType              string
-	ClientID                     string
-	ClientPassword               string
 	AzureResourceManagerEndpoint string
-	ActiveDirectoryEndpoint      string
+	AADConfig                    AADCredentialConfig
 }
 
 var azureMonitorLog = logf.Log.WithName("azure_monitor_scaler")
 
 // GetAzureMetricValue returns the value of an Azure Monitor metric, rounded to the nearest int
-func GetAzureMetricValue(ctx context.Context, info MonitorInfo, podIdentity kedav1alpha1.AuthPodIdentity) (float64, error) {
-	client := createMetricsClient(ctx, info, podIdentity)
+func GetAzureMetricValue(ctx context.Context, httpClient util.HTTPDoer, info MonitorInfo) (float64, error) {
+	client := createMetricsClient(ctx, httpClient, info)
 	requestPtr, err := createMetricsRequest(info)
 	if err != nil {
 		return -1, err
@@ -76,28 +72,9 @@ func GetAzureMetricValue(ctx context.Context, info MonitorInfo, podIdentity keda
 	return executeRequest(ctx, client, requestPtr)
 }
 
-func createMetricsClient(ctx context.Context, info MonitorInfo, podIdentity kedav1alpha1.AuthPodIdentity) insights.MetricsClient {
+func createMetricsClient(ctx context.Context, httpClient util.HTTPDoer, info MonitorInfo) insights.MetricsClient {
 	client := insights.NewMetricsClientWithBaseURI(info.AzureResourceManagerEndpoint, info.SubscriptionID)
-	var authConfig auth.AuthorizerConfig
-	switch podIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		config := auth.NewClientCredentialsConfig(info.ClientID, info.ClientPassword, info.TenantID)
-		config.Resource = info.AzureResourceManagerEndpoint
-		config.AADEndpoint = info.ActiveDirectoryEndpoint
-
-		authConfig = config
-	case kedav1alpha1.PodIdentityProviderAzure:
-		config := auth.NewMSIConfig()
-		config.Resource = info.AzureResourceManagerEndpoint
-		config.ClientID = podIdentity.IdentityID
-
-		authConfig = config
-	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		authConfig = NewAzureADWorkloadIdentityConfig(ctx, podIdentity.IdentityID, info.AzureResourceManagerEndpoint)
-	}
-
-	authorizer, _ := authConfig.Authorizer()
-	client.Authorizer = authorizer
+	client.Authorizer = NewAADCredential(info.AADConfig, httpClient).Authorizer(ctx, info.AzureResourceManagerEndpoint)
 
 	return client
 }
@@ -21,7 +21,6 @@ import (
 
 	"github.com/Azure/azure-storage-queue-go/azqueue"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -30,8 +29,8 @@ const (
 )
 
 // GetAzureQueueLength returns the length of a queue in int
-func GetAzureQueueLength(ctx context.Context, httpClient util.HTTPDoer, podIdentity kedav1alpha1.AuthPodIdentity, connectionString, queueName, accountName, endpointSuffix string) (int64, error) {
-	credential, endpoint, err := ParseAzureStorageQueueConnection(ctx, httpClient, podIdentity, connectionString, accountName, endpointSuffix)
+func GetAzureQueueLength(ctx context.Context, httpClient util.HTTPDoer, aadConfig *AADCredentialConfig, connectionString, queueName, accountName, endpointSuffix string) (int64, error) {
+	credential, endpoint, err := ParseAzureStorageQueueConnection(ctx, httpClient, aadConfig, connectionString, accountName, endpointSuffix)
 	if err != nil {
 		return -1, err
 	}
@@ -27,7 +27,6 @@ import (
 	"github.com/Azure/azure-storage-queue-go/azqueue"
 	az "github.com/Azure/go-autorest/autorest/azure"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -79,60 +78,56 @@ func ParseAzureStorageEndpointSuffix(metadata map[string]string, endpointType St
 	return ParseEnvironmentProperty(metadata, DefaultEndpointSuffixKey, envSuffixProvider)
 }
 
-// ParseAzureStorageQueueConnection parses queue connection string and returns credential and resource url
-func ParseAzureStorageQueueConnection(ctx context.Context, httpClient util.HTTPDoer, podIdentity kedav1alpha1.AuthPodIdentity, connectionString, accountName, endpointSuffix string) (azqueue.Credential, *url.URL, error) {
-	switch podIdentity.Provider {
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-		token, endpoint, err := parseAcessTokenAndEndpoint(ctx, httpClient, accountName, endpointSuffix, podIdentity)
+// ParseAzureStorageQueueConnection parses queue connection string and returns credential and resource url,
+// the Azure AD identity is used rather than the connection string if given
+func ParseAzureStorageQueueConnection(ctx context.Context, httpClient util.HTTPDoer, aadConfig *AADCredentialConfig, connectionString, accountName, endpointSuffix string) (azqueue.Credential, *url.URL, error) {
+	if aadConfig != nil {
+		token, endpoint, err := parseAcessTokenAndEndpoint(ctx, httpClient, accountName, endpointSuffix, *aadConfig)
 		if err != nil {
 			return nil, nil, err
 		}
 
 		credential := azqueue.NewTokenCredential(token, nil)
 		return credential, endpoint, nil
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		endpoint, accountName, accountKey, err := parseAzureStorageConnectionString(connectionString, QueueEndpoint)
-		if err != nil {
-			return nil, nil, err
-		}
+	}
 
-		credential, err := azqueue.NewSharedKeyCredential(accountName, accountKey)
-		if err != nil {
-			return nil, nil, err
-		}
+	endpoint, accountName, accountKey, err := parseAzureStorageConnectionString(connectionString, QueueEndpoint)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		return credential, endpoint, nil
-	default:
-		return nil, nil, fmt.Errorf("azure queues doesn't support %s pod identity type", podIdentity)
+	credential, err := azqueue.NewSharedKeyCredential(accountName, accountKey)
+	if err != nil {
+		return nil, nil, err
 	}
+
+	return credential, endpoint, nil
 }
 
-// ParseAzureStorageBlobConnection parses blob connection string and returns credential and resource url
-func ParseAzureStorageBlobConnection(ctx context.Context, httpClient util.HTTPDoer, podIdentity kedav1alpha1.AuthPodIdentity, connectionString, accountName, endpointSuffix string) (azblob.Credential, *url.URL, error) {
-	switch podIdentity.Provider {
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-		token, endpoint, err := parseAcessTokenAndEndpoint(ctx, httpClient, accountName, endpointSuffix, podIdentity)
+// ParseAzureStorageBlobConnection parses blob connection string and returns credential and resource url,
+// the Azure AD identity is used rather than the connection string if given
+func ParseAzureStorageBlobConnection(ctx context.Context, httpClient util.HTTPDoer, aadConfig *AADCredentialConfig, connectionString, accountName, endpointSuffix string) (azblob.Credential, *url.URL, error) {
+	if aadConfig != nil {
+		token, endpoint, err := parseAcessTokenAndEndpoint(ctx, httpClient, accountName, endpointSuffix, *aadConfig)
 		if err != nil {
 			return nil, nil, err
 		}
 
 		credential := azblob.NewTokenCredential(token, nil)
 		return credential, endpoint, nil
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		endpoint, accountName, accountKey, err := parseAzureStorageConnectionString(connectionString, BlobEndpoint)
-		if err != nil {
-			return nil, nil, err
-		}
+	}
 
-		credential, err := azblob.NewSharedKeyCredential(accountName, accountKey)
-		if err != nil {
-			return nil, nil, err
-		}
+	endpoint, accountName, accountKey, err := parseAzureStorageConnectionString(connectionString, BlobEndpoint)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		return credential, endpoint, nil
-	default:
-		return nil, nil, fmt.Errorf("azure queues doesn't support %s pod identity type", podIdentity)
+	credential, err := azblob.NewSharedKeyCredential(accountName, accountKey)
+	if err != nil {
+		return nil, nil, err
 	}
+
+	return credential, endpoint, nil
 }
 
 func parseAzureStorageConnectionString(connectionString string, endpointType StorageEndpointType) (*url.URL, string, string, error) {
@@ -193,25 +188,16 @@ func parseAzureStorageConnectionString(connectionString string, endpointType Sto
 }
 
 func parseAcessTokenAndEndpoint(ctx context.Context, httpClient util.HTTPDoer, accountName string, endpointSuffix string,
-	podIdentity kedav1alpha1.AuthPodIdentity) (string, *url.URL, error) {
-	var token AADToken
-	var err error
-
-	switch podIdentity.Provider {
-	case kedav1alpha1.PodIdentityProviderAzure:
-		token, err = GetAzureADPodIdentityToken(ctx, httpClient, podIdentity.IdentityID, storageResource)
-	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		token, err = GetAzureADWorkloadIdentityToken(ctx, podIdentity.IdentityID, storageResource)
+	aadConfig AADCredentialConfig) (string, *url.URL, error) {
+	if accountName == "" {
+		return "", nil, fmt.Errorf("accountName is required for azure AD authentication")
 	}
 
+	token, err := NewAADCredential(aadConfig, httpClient).GetToken(ctx, storageResource)
 	if err != nil {
 		return "", nil, err
 	}
 
-	if accountName == "" {
-		return "", nil, fmt.Errorf("accountName is required for podIdentity azure")
-	}
-
 	endpoint, _ := url.Parse(fmt.Sprintf("https://%s.%s", accountName, endpointSuffix))
 	return token.AccessToken, endpoint, nil
 }
@@ -3,6 +3,7 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -11,7 +12,6 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -24,7 +24,6 @@ const (
 	azureAppInsightsMetricAggregationTimespanName = "metricAggregationTimespan"
 	azureAppInsightsMetricAggregationTypeName     = "metricAggregationType"
 	azureAppInsightsMetricFilterName              = "metricFilter"
-	azureAppInsightsTenantIDName                  = "tenantId"
 )
 
 type azureAppInsightsMetadata struct {
@@ -35,10 +34,10 @@ type azureAppInsightsMetadata struct {
 }
 
 type azureAppInsightsScaler struct {
-	metricType  v2beta2.MetricTargetType
-	metadata    *azureAppInsightsMetadata
-	podIdentity kedav1alpha1.AuthPodIdentity
-	logger      logr.Logger
+	metricType v2beta2.MetricTargetType
+	metadata   *azureAppInsightsMetadata
+	httpClient *http.Client
+	logger     logr.Logger
 }
 
 // NewAzureAppInsightsScaler creates a new AzureAppInsightsScaler
@@ -56,10 +55,10 @@ func NewAzureAppInsightsScaler(config *ScalerConfig) (Scaler, error) {
 	}
 
 	return &azureAppInsightsScaler{
-		metricType:  metricType,
-		metadata:    meta,
-		podIdentity: config.PodIdentity,
-		logger:      logger,
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     logger,
 	}, nil
 }
 
@@ -132,12 +131,6 @@ func parseAzureAppInsightsMetadata(config *ScalerConfig, logger logr.Logger) (*a
 		}
 	}
 
-	activeDirectoryEndpoint, err := azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
-	if err != nil {
-		return nil, err
-	}
-	meta.azureAppInsightsInfo.ActiveDirectoryEndpoint = activeDirectoryEndpoint
-
 	// Required authentication parameters below
 
 	val, err = getParameterFromConfig(config, azureAppInsightsAppIDName, true)
@@ -146,18 +139,11 @@ func parseAzureAppInsightsMetadata(config *ScalerConfig, logger logr.Logger) (*a
 	}
 	meta.azureAppInsightsInfo.ApplicationInsightsID = val
 
-	val, err = getParameterFromConfig(config, azureAppInsightsTenantIDName, true)
-	if err != nil {
-		return nil, err
-	}
-	meta.azureAppInsightsInfo.TenantID = val
-
-	clientID, clientPassword, err := parseAzurePodIdentityParams(config)
+	aadConfig, err := parseAzureAADCredentialConfig(config)
 	if err != nil {
 		return nil, err
 	}
-	meta.azureAppInsightsInfo.ClientID = clientID
-	meta.azureAppInsightsInfo.ClientPassword = clientPassword
+	meta.azureAppInsightsInfo.AADConfig = *aadConfig
 
 	meta.scalerIndex = config.ScalerIndex
 
@@ -166,7 +152,7 @@ func parseAzureAppInsightsMetadata(config *ScalerConfig, logger logr.Logger) (*a
 
 // Returns true if the Azure App Insights metric value is greater than the target value
 func (s *azureAppInsightsScaler) IsActive(ctx context.Context) (bool, error) {
-	val, err := azure.GetAzureAppInsightsMetricValue(ctx, s.metadata.azureAppInsightsInfo, s.podIdentity)
+	val, err := azure.GetAzureAppInsightsMetricValue(ctx, s.httpClient, s.metadata.azureAppInsightsInfo)
 	if err != nil {
 		s.logger.Error(err, "error getting azure app insights metric")
 		return false, err
@@ -192,7 +178,7 @@ func (s *azureAppInsightsScaler) GetMetricSpecForScaling(context.Context) []v2be
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *azureAppInsightsScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	val, err := azure.GetAzureAppInsightsMetricValue(ctx, s.metadata.azureAppInsightsInfo, s.podIdentity)
+	val, err := azure.GetAzureAppInsightsMetricValue(ctx, s.httpClient, s.metadata.azureAppInsightsInfo)
 	if err != nil {
 		s.logger.Error(err, "error getting azure app insights metric")
 		return []external_metrics.ExternalMetricValue{}, err
@@ -249,8 +249,7 @@ func TestAzureAppInsightsGetMetricSpecForScaling(t *testing.T) {
 				t.Fatal("Could not parse metadata:", err)
 			}
 			mockAzureAppInsightsScaler := azureAppInsightsScaler{
-				metadata:    meta,
-				podIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure},
+				metadata: meta,
 			}
 
 			metricSpec := mockAzureAppInsightsScaler.GetMetricSpecForScaling(ctx)
@@ -42,11 +42,11 @@ const (
 )
 
 type azureBlobScaler struct {
-	metricType  v2beta2.MetricTargetType
-	metadata    *azure.BlobMetadata
-	podIdentity kedav1alpha1.AuthPodIdentity
-	httpClient  *http.Client
-	logger      logr.Logger
+	metricType v2beta2.MetricTargetType
+	metadata   *azure.BlobMetadata
+	aadConfig  *azure.AADCredentialConfig
+	httpClient *http.Client
+	logger     logr.Logger
 }
 
 // NewAzureBlobScaler creates a new azureBlobScaler
@@ -58,20 +58,20 @@ func NewAzureBlobScaler(config *ScalerConfig) (Scaler, error) {
 
 	logger := InitializeLogger(config, "azure_blob_scaler")
 
-	meta, podIdentity, err := parseAzureBlobMetadata(config, logger)
+	meta, aadConfig, err := parseAzureBlobMetadata(config, logger)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing azure blob metadata: %s", err)
 	}
 
 	return &azureBlobScaler{
-		metricType:  metricType,
-		metadata:    meta,
-		podIdentity: podIdentity,
-		httpClient:  kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		metricType: metricType,
+		metadata:   meta,
+		aadConfig:  aadConfig,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
 	}, nil
 }
 
-func parseAzureBlobMetadata(config *ScalerConfig, logger logr.Logger) (*azure.BlobMetadata, kedav1alpha1.AuthPodIdentity, error) {
+func parseAzureBlobMetadata(config *ScalerConfig, logger logr.Logger) (*azure.BlobMetadata, *azure.AADCredentialConfig, error) {
 	meta := azure.BlobMetadata{}
 	meta.TargetBlobCount = defaultTargetBlobCount
 	meta.BlobDelimiter = defaultBlobDelimiter
@@ -81,7 +81,7 @@ func parseAzureBlobMetadata(config *ScalerConfig, logger logr.Logger) (*azure.Bl
 		blobCount, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
 			logger.Error(err, "Error parsing azure blob metadata", "blobCountMetricName", blobCountMetricName)
-			return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("error parsing azure blob metadata %s: %s", blobCountMetricName, err.Error())
+			return nil, nil, fmt.Errorf("error parsing azure blob metadata %s: %s", blobCountMetricName, err.Error())
 		}
 
 		meta.TargetBlobCount = blobCount
@@ -92,7 +92,7 @@ func parseAzureBlobMetadata(config *ScalerConfig, logger logr.Logger) (*azure.Bl
 		activationBlobCount, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
 			logger.Error(err, "Error parsing azure blob metadata", activationBlobCountMetricName, activationBlobCountMetricName)
-			return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("error parsing azure blob metadata %s: %s", activationBlobCountMetricName, err.Error())
+			return nil, nil, fmt.Errorf("error parsing azure blob metadata %s: %s", activationBlobCountMetricName, err.Error())
 		}
 
 		meta.ActivationTargetBlobCount = activationBlobCount
@@ -101,7 +101,7 @@ func parseAzureBlobMetadata(config *ScalerConfig, logger logr.Logger) (*azure.Bl
 	if val, ok := config.TriggerMetadata["blobContainerName"]; ok && val != "" {
 		meta.BlobContainerName = val
 	} else {
-		return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("no blobContainerName given")
+		return nil, nil, fmt.Errorf("no blobContainerName given")
 	}
 
 	if val, ok := config.TriggerMetadata["blobDelimiter"]; ok && val != "" {
@@ -111,7 +111,7 @@ func parseAzureBlobMetadata(config *ScalerConfig, logger logr.Logger) (*azure.Bl
 	if val, ok := config.TriggerMetadata["recursive"]; ok && val != "" {
 		recursive, err := strconv.ParseBool(val)
 		if err != nil {
-			return nil, kedav1alpha1.AuthPodIdentity{}, err
+			return nil, nil, err
 		}
 
 		if recursive {
@@ -122,7 +122,7 @@ func parseAzureBlobMetadata(config *ScalerConfig, logger logr.Logger) (*azure.Bl
 	if val, ok := config.TriggerMetadata["globPattern"]; ok && val != "" {
 		glob, err := glob.Compile(val)
 		if err != nil {
-			return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("invalid glob pattern - %s", err.Error())
+			return nil, nil, fmt.Errorf("invalid glob pattern - %s", err.Error())
 		}
 		meta.GlobPattern = &glob
 	}
@@ -133,7 +133,7 @@ func parseAzureBlobMetadata(config *ScalerConfig, logger logr.Logger) (*azure.Bl
 
 	endpointSuffix, err := azure.ParseAzureStorageEndpointSuffix(config.TriggerMetadata, azure.BlobEndpoint)
 	if err != nil {
-		return nil, kedav1alpha1.AuthPodIdentity{}, err
+		return nil, nil, err
 	}
 
 	meta.EndpointSuffix = endpointSuffix
@@ -149,10 +149,13 @@ func parseAzureBlobMetadata(config *ScalerConfig, logger logr.Logger) (*azure.Bl
 		meta.MetricName = kedautil.NormalizeString(fmt.Sprintf("azure-blob-%s", meta.BlobContainerName))
 	}
 
-	// If the Use AAD Pod Identity is not present, or set to "none"
-	// then check for connection string
-	switch config.PodIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
+	aadConfig, err := azure.ParseAADCredentialConfig(config.PodIdentity, config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If no Azure AD identity is given, then check for connection string
+	if aadConfig == nil {
 		// Azure Blob Scaler expects a "connection" parameter in the metadata
 		// of the scaler or in a TriggerAuthentication object
 		if config.AuthParams["connection"] != "" {
@@ -162,22 +165,20 @@ func parseAzureBlobMetadata(config *ScalerConfig, logger logr.Logger) (*azure.Bl
 		}
 
 		if len(meta.Connection) == 0 {
-			return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("no connection setting given")
+			return nil, nil, fmt.Errorf("no connection setting given")
 		}
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-		// If the Use AAD Pod Identity / Workload Identity is present then check account name
+	} else {
+		// If an Azure AD identity is given then check account name
 		if val, ok := config.TriggerMetadata["accountName"]; ok && val != "" {
 			meta.AccountName = val
 		} else {
-			return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("no accountName given")
+			return nil, nil, fmt.Errorf("no accountName given")
 		}
-	default:
-		return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("pod identity %s not supported for azure storage blobs", config.PodIdentity)
 	}
 
 	meta.ScalerIndex = config.ScalerIndex
 
-	return &meta, config.PodIdentity, nil
+	return &meta, aadConfig, nil
 }
 
 // GetScaleDecision is a func
@@ -185,7 +186,7 @@ func (s *azureBlobScaler) IsActive(ctx context.Context) (bool, error) {
 	length, err := azure.GetAzureBlobListLength(
 		ctx,
 		s.httpClient,
-		s.podIdentity,
+		s.aadConfig,
 		s.metadata,
 	)
 
@@ -217,7 +218,7 @@ func (s *azureBlobScaler) GetMetrics(ctx context.Context, metricName string, met
 	bloblen, err := azure.GetAzureBlobListLength(
 		ctx,
 		s.httpClient,
-		s.podIdentity,
+		s.aadConfig,
 		s.metadata,
 	)
 
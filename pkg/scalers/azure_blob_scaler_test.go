@@ -111,7 +111,7 @@ var azBlobMetricIdentifiers = []azBlobMetricIdentifier{
 
 func TestAzBlobParseMetadata(t *testing.T) {
 	for _, testData := range testAzBlobMetadata {
-		_, podIdentity, err := parseAzureBlobMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: testData.resolvedEnv,
+		_, aadConfig, err := parseAzureBlobMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: testData.resolvedEnv,
 			AuthParams: testData.authParams, PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.podIdentity}}, logr.Discard())
 		if err != nil && !testData.isError {
 			t.Error("Expected success but got error", err)
@@ -119,7 +119,7 @@ func TestAzBlobParseMetadata(t *testing.T) {
 		if testData.isError && err == nil {
 			t.Errorf("Expected error but got success. testData: %v", testData)
 		}
-		if testData.podIdentity != "" && testData.podIdentity != podIdentity.Provider && err == nil {
+		if testData.podIdentity != "" && testData.podIdentity != kedav1alpha1.PodIdentityProviderNone && (aadConfig == nil || testData.podIdentity != aadConfig.PodIdentity.Provider) && err == nil {
 			t.Error("Expected success but got error: podIdentity value is not returned as expected")
 		}
 	}
@@ -128,16 +128,16 @@ func TestAzBlobParseMetadata(t *testing.T) {
 func TestAzBlobGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range azBlobMetricIdentifiers {
 		ctx := context.Background()
-		meta, podIdentity, err := parseAzureBlobMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
+		meta, aadConfig, err := parseAzureBlobMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
 			ResolvedEnv: testData.metadataTestData.resolvedEnv, AuthParams: testData.metadataTestData.authParams,
 			PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.metadataTestData.podIdentity}, ScalerIndex: testData.scalerIndex}, logr.Discard())
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
 		mockAzBlobScaler := azureBlobScaler{
-			metadata:    meta,
-			podIdentity: podIdentity,
-			httpClient:  http.DefaultClient,
+			metadata:   meta,
+			aadConfig:  aadConfig,
+			httpClient: http.DefaultClient,
 		}
 
 		metricSpec := mockAzBlobScaler.GetMetricSpecForScaling(ctx)
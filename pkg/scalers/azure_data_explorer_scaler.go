@@ -27,7 +27,6 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -56,7 +55,7 @@ func NewAzureDataExplorerScaler(ctx context.Context, config *ScalerConfig) (Scal
 		return nil, fmt.Errorf("failed to parse azure data explorer metadata: %s", err)
 	}
 
-	client, err := azure.CreateAzureDataExplorerClient(ctx, metadata)
+	client, err := azure.CreateAzureDataExplorerClient(ctx, kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false), metadata)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create azure data explorer client: %s", err)
 	}
@@ -72,10 +71,13 @@ func NewAzureDataExplorerScaler(ctx context.Context, config *ScalerConfig) (Scal
 }
 
 func parseAzureDataExplorerMetadata(config *ScalerConfig, logger logr.Logger) (*azure.DataExplorerMetadata, error) {
-	metadata, err := parseAzureDataExplorerAuthParams(config, logger)
+	metadata := &azure.DataExplorerMetadata{}
+
+	aadConfig, err := parseAzureAADCredentialConfig(config)
 	if err != nil {
 		return nil, err
 	}
+	metadata.AADConfig = *aadConfig
 
 	// Get database name.
 	databaseName, err := getParameterFromConfig(config, "databaseName", false)
@@ -120,56 +122,18 @@ func parseAzureDataExplorerMetadata(config *ScalerConfig, logger logr.Logger) (*
 	// Generate metricName.
 	metadata.MetricName = GenerateMetricNameWithIndex(config.ScalerIndex, kedautil.NormalizeString(fmt.Sprintf("%s-%s", adxName, metadata.DatabaseName)))
 
-	activeDirectoryEndpoint, err := azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
-	if err != nil {
-		return nil, err
-	}
-	metadata.ActiveDirectoryEndpoint = activeDirectoryEndpoint
-
 	logger.V(1).Info("Parsed azureDataExplorerMetadata",
 		"database", metadata.DatabaseName,
 		"endpoint", metadata.Endpoint,
 		"metricName", metadata.MetricName,
 		"query", metadata.Query,
 		"threshold", metadata.Threshold,
-		"activeDirectoryEndpoint", metadata.ActiveDirectoryEndpoint,
+		"activeDirectoryEndpoint", metadata.AADConfig.ActiveDirectoryEndpoint,
 	)
 
 	return metadata, nil
 }
 
-func parseAzureDataExplorerAuthParams(config *ScalerConfig, logger logr.Logger) (*azure.DataExplorerMetadata, error) {
-	metadata := azure.DataExplorerMetadata{}
-
-	switch config.PodIdentity.Provider {
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-		metadata.PodIdentity = config.PodIdentity
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		logger.V(1).Info("Pod Identity is not provided. Trying to resolve clientId, clientSecret and tenantId.")
-
-		tenantID, err := getParameterFromConfig(config, "tenantId", true)
-		if err != nil {
-			return nil, err
-		}
-		metadata.TenantID = tenantID
-
-		clientID, err := getParameterFromConfig(config, "clientId", true)
-		if err != nil {
-			return nil, err
-		}
-		metadata.ClientID = clientID
-
-		clientSecret, err := getParameterFromConfig(config, "clientSecret", true)
-		if err != nil {
-			return nil, err
-		}
-		metadata.ClientSecret = clientSecret
-	default:
-		return nil, fmt.Errorf("error parsing auth params")
-	}
-	return &metadata, nil
-}
-
 func (s azureDataExplorerScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
 	metricValue, err := azure.GetAzureDataExplorerMetricValue(ctx, s.client, s.metadata.DatabaseName, s.metadata.Query)
 	if err != nil {
@@ -33,7 +33,6 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
-	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -75,7 +74,8 @@ func NewAzureEventHubScaler(ctx context.Context, config *ScalerConfig) (Scaler,
 		return nil, fmt.Errorf("unable to get eventhub metadata: %s", err)
 	}
 
-	hub, err := azure.GetEventHubClient(ctx, parsedMetadata.eventHubInfo)
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	hub, err := azure.GetEventHubClient(ctx, httpClient, parsedMetadata.eventHubInfo)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get eventhub client: %s", err)
 	}
@@ -84,7 +84,7 @@ func NewAzureEventHubScaler(ctx context.Context, config *ScalerConfig) (Scaler,
 		metricType: metricType,
 		metadata:   parsedMetadata,
 		client:     hub,
-		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		httpClient: httpClient,
 		logger:     InitializeLogger(config, "azure_eventhub_scaler"),
 	}, nil
 }
@@ -166,9 +166,13 @@ func parseAzureEventHubMetadata(config *ScalerConfig) (*eventHubMetadata, error)
 	}
 	meta.eventHubInfo.ActiveDirectoryEndpoint = activeDirectoryEndpoint
 
-	meta.eventHubInfo.PodIdentity = config.PodIdentity
-	switch config.PodIdentity.Provider {
-	case "", v1alpha1.PodIdentityProviderNone:
+	aadConfig, err := azure.ParseAADCredentialConfig(config.PodIdentity, config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.eventHubInfo.AADConfig = aadConfig
+	if aadConfig == nil {
 		if config.AuthParams["connection"] != "" {
 			meta.eventHubInfo.EventHubConnection = config.AuthParams["connection"]
 		} else if config.TriggerMetadata["connectionFromEnv"] != "" {
@@ -178,7 +182,7 @@ func parseAzureEventHubMetadata(config *ScalerConfig) (*eventHubMetadata, error)
 		if len(meta.eventHubInfo.EventHubConnection) == 0 {
 			return nil, fmt.Errorf("no event hub connection string given")
 		}
-	case v1alpha1.PodIdentityProviderAzure, v1alpha1.PodIdentityProviderAzureWorkload:
+	} else {
 		if config.TriggerMetadata["eventHubNamespace"] != "" {
 			meta.eventHubInfo.Namespace = config.TriggerMetadata["eventHubNamespace"]
 		} else if config.TriggerMetadata["eventHubNamespaceFromEnv"] != "" {
@@ -19,31 +19,23 @@ package scalers
 import (
 	"bytes"
 	"context"
-	"crypto/sha1"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"strconv"
 	"strings"
-	"sync"
-	"time"
 
-	"github.com/Azure/azure-amqp-common-go/v3/auth"
 	"github.com/go-logr/logr"
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 const (
-	aadTokenEndpoint               = "%s/%s/oauth2/token"
 	laQueryEndpoint                = "%s/v1/workspaces/%s/query"
 	defaultLogAnalyticsResourceURL = "https://api.loganalytics.io"
 )
@@ -59,18 +51,14 @@ type azureLogAnalyticsScaler struct {
 }
 
 type azureLogAnalyticsMetadata struct {
-	tenantID                string
-	clientID                string
-	clientSecret            string
+	aadConfig               azure.AADCredentialConfig
 	workspaceID             string
-	podIdentity             kedav1alpha1.AuthPodIdentity
 	query                   string
 	threshold               float64
 	activationThreshold     float64
 	metricName              string // Custom metric name for trigger
 	scalerIndex             int
 	logAnalyticsResourceURL string
-	activeDirectoryEndpoint string
 }
 
 type sessionCache struct {
@@ -78,17 +66,6 @@ type sessionCache struct {
 	metricThreshold float64
 }
 
-type tokenData struct {
-	TokenType               string `json:"token_type"`
-	ExpiresIn               int    `json:"expires_in,string"`
-	ExtExpiresIn            int    `json:"ext_expires_in,string"`
-	ExpiresOn               int64  `json:"expires_on,string"`
-	NotBefore               int64  `json:"not_before,string"`
-	Resource                string `json:"resource"`
-	AccessToken             string `json:"access_token"`
-	IsWorkloadIdentityToken bool   `json:"isWorkloadIdentityToken"`
-}
-
 type metricsData struct {
 	value     float64
 	threshold float64
@@ -105,11 +82,6 @@ type queryResult struct {
 	} `json:"tables"`
 }
 
-var tokenCache = struct {
-	sync.RWMutex
-	m map[string]tokenData
-}{m: make(map[string]tokenData)}
-
 var logAnalyticsResourceURLInCloud = map[string]string{
 	"AZUREPUBLICCLOUD":       "https://api.loganalytics.io",
 	"AZUREUSGOVERNMENTCLOUD": "https://api.loganalytics.us",
@@ -141,35 +113,11 @@ func NewAzureLogAnalyticsScaler(config *ScalerConfig) (Scaler, error) {
 
 func parseAzureLogAnalyticsMetadata(config *ScalerConfig) (*azureLogAnalyticsMetadata, error) {
 	meta := azureLogAnalyticsMetadata{}
-	switch config.PodIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		// Getting tenantId
-		tenantID, err := getParameterFromConfig(config, "tenantId", true)
-		if err != nil {
-			return nil, err
-		}
-		meta.tenantID = tenantID
-
-		// Getting clientId
-		clientID, err := getParameterFromConfig(config, "clientId", true)
-		if err != nil {
-			return nil, err
-		}
-		meta.clientID = clientID
-
-		// Getting clientSecret
-		clientSecret, err := getParameterFromConfig(config, "clientSecret", true)
-		if err != nil {
-			return nil, err
-		}
-		meta.clientSecret = clientSecret
-
-		meta.podIdentity = config.PodIdentity
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-		meta.podIdentity = config.PodIdentity
-	default:
-		return nil, fmt.Errorf("error parsing metadata. Details: Log Analytics Scaler doesn't support pod identity %s", config.PodIdentity)
+	aadConfig, err := parseAzureAADCredentialConfig(config)
+	if err != nil {
+		return nil, err
 	}
+	meta.aadConfig = *aadConfig
 
 	// Getting workspaceId
 	workspaceID, err := getParameterFromConfig(config, "workspaceId", true)
@@ -231,12 +179,6 @@ func parseAzureLogAnalyticsMetadata(config *ScalerConfig) (*azureLogAnalyticsMet
 		}
 	}
 
-	activeDirectoryEndpoint, err := azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
-	if err != nil {
-		return nil, err
-	}
-	meta.activeDirectoryEndpoint = activeDirectoryEndpoint
-
 	return &meta, nil
 }
 
@@ -295,6 +237,18 @@ func (s *azureLogAnalyticsScaler) GetMetrics(ctx context.Context, metricName str
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
 
+func (s *azureLogAnalyticsScaler) GetMetricsAndActivity(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error) {
+	receivedMetric, err := s.getMetricData(ctx)
+
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("failed to get metrics. Scaled object: %s. Namespace: %s. Inner Error: %v", s.name, s.namespace, err)
+	}
+
+	metric := GenerateMetricInMili(metricName, receivedMetric.value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), receivedMetric.value > s.metadata.activationThreshold, nil
+}
+
 func (s *azureLogAnalyticsScaler) Close(context.Context) error {
 	return nil
 }
@@ -320,12 +274,12 @@ func (s *azureLogAnalyticsScaler) updateCache(ctx context.Context) error {
 }
 
 func (s *azureLogAnalyticsScaler) getMetricData(ctx context.Context) (metricsData, error) {
-	tokenInfo, err := s.getAccessToken(ctx)
+	token, err := azure.NewAADCredential(s.metadata.aadConfig, s.httpClient).GetToken(ctx, s.metadata.logAnalyticsResourceURL)
 	if err != nil {
-		return metricsData{}, err
+		return metricsData{}, fmt.Errorf("error getting access token. Inner Error: %v", err)
 	}
 
-	metricsInfo, err := s.executeQuery(ctx, s.metadata.query, tokenInfo)
+	metricsInfo, err := s.executeQuery(ctx, s.metadata.query, token.AccessToken)
 	if err != nil {
 		return metricsData{}, err
 	}
@@ -335,68 +289,10 @@ func (s *azureLogAnalyticsScaler) getMetricData(ctx context.Context) (metricsDat
 	return metricsInfo, nil
 }
 
-func (s *azureLogAnalyticsScaler) getAccessToken(ctx context.Context) (tokenData, error) {
-	// if there is no token yet or it will be expired in less, that 30 secs
-	currentTimeSec := time.Now().Unix()
-	tokenInfo := tokenData{}
-
-	switch s.metadata.podIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		tokenInfo, _ = getTokenFromCache(s.metadata.clientID, s.metadata.clientSecret)
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-		tokenInfo, _ = getTokenFromCache(string(s.metadata.podIdentity.Provider), string(s.metadata.podIdentity.Provider))
-	}
-
-	if currentTimeSec+30 > tokenInfo.ExpiresOn {
-		newTokenInfo, err := s.refreshAccessToken(ctx)
-		if err != nil {
-			return tokenData{}, err
-		}
-
-		switch s.metadata.podIdentity.Provider {
-		case "", kedav1alpha1.PodIdentityProviderNone:
-			s.logger.V(1).Info("Token for Service Principal has been refreshed", "clientID", s.metadata.clientID, "scaler name", s.name, "namespace", s.namespace)
-			_ = setTokenInCache(s.metadata.clientID, s.metadata.clientSecret, newTokenInfo)
-		case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-			s.logger.V(1).Info("Token for Pod Identity has been refreshed", "type", s.metadata.podIdentity, "scaler name", s.name, "namespace", s.namespace)
-			_ = setTokenInCache(string(s.metadata.podIdentity.Provider), string(s.metadata.podIdentity.Provider), newTokenInfo)
-		}
-
-		return newTokenInfo, nil
-	}
-	return tokenInfo, nil
-}
-
-func (s *azureLogAnalyticsScaler) executeQuery(ctx context.Context, query string, tokenInfo tokenData) (metricsData, error) {
+func (s *azureLogAnalyticsScaler) executeQuery(ctx context.Context, query string, accessToken string) (metricsData, error) {
 	queryData := queryResult{}
-	var body []byte
-	var statusCode int
-	var err error
 
-	body, statusCode, err = s.executeLogAnalyticsREST(ctx, query, tokenInfo)
-
-	// Handle expired token
-	if statusCode == 403 || (len(body) > 0 && strings.Contains(string(body), "TokenExpired")) {
-		tokenInfo, err = s.refreshAccessToken(ctx)
-		if err != nil {
-			return metricsData{}, err
-		}
-
-		switch s.metadata.podIdentity.Provider {
-		case "", kedav1alpha1.PodIdentityProviderNone:
-			s.logger.V(1).Info("Token for Service Principal has been refreshed", "clientID", s.metadata.clientID, "scaler name", s.name, "namespace", s.namespace)
-			_ = setTokenInCache(s.metadata.clientID, s.metadata.clientSecret, tokenInfo)
-		case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-			s.logger.V(1).Info("Token for Pod Identity has been refreshed", "type", s.metadata.podIdentity, "scaler name", s.name, "namespace", s.namespace)
-			_ = setTokenInCache(string(s.metadata.podIdentity.Provider), string(s.metadata.podIdentity.Provider), tokenInfo)
-		}
-
-		if err == nil {
-			body, statusCode, err = s.executeLogAnalyticsREST(ctx, query, tokenInfo)
-		} else {
-			return metricsData{}, err
-		}
-	}
+	body, statusCode, err := s.executeLogAnalyticsREST(ctx, query, accessToken)
 
 	if statusCode != 200 && statusCode != 0 {
 		return metricsData{}, fmt.Errorf("error processing Log Analytics request. HTTP code %d. Inner Error: %v. Body: %s", statusCode, err, string(body))
@@ -476,84 +372,7 @@ func parseTableValueToFloat64(value interface{}, dataType string) (float64, erro
 	return 0, fmt.Errorf("error validating Log Analytics request. Details: value is empty, check your query")
 }
 
-func (s *azureLogAnalyticsScaler) refreshAccessToken(ctx context.Context) (tokenData, error) {
-	tokenInfo, err := s.getAuthorizationToken(ctx)
-
-	if err != nil {
-		return tokenData{}, err
-	}
-
-	if tokenInfo.IsWorkloadIdentityToken {
-		return tokenInfo, nil
-	}
-
-	// Now, let's check we can use this token. If no, wait until we can use it
-	currentTimeSec := time.Now().Unix()
-	if currentTimeSec < tokenInfo.NotBefore {
-		if currentTimeSec < tokenInfo.NotBefore+10 {
-			sleepDurationSec := int(tokenInfo.NotBefore - currentTimeSec + 1)
-			s.logger.V(1).Info("AAD token not ready", "delay (seconds)", sleepDurationSec, "scaler name", s.name, "namespace", s.namespace)
-			time.Sleep(time.Duration(sleepDurationSec) * time.Second)
-		} else {
-			return tokenData{}, fmt.Errorf("error getting access token. Details: AAD token has been received, but start date begins in %d seconds, so current operation will be skipped", tokenInfo.NotBefore-currentTimeSec)
-		}
-	}
-
-	return tokenInfo, nil
-}
-
-func (s *azureLogAnalyticsScaler) getAuthorizationToken(ctx context.Context) (tokenData, error) {
-	var body []byte
-	var statusCode int
-	var err error
-	var tokenInfo tokenData
-
-	switch s.metadata.podIdentity.Provider {
-	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		aadToken, err := azure.GetAzureADWorkloadIdentityToken(ctx, s.metadata.podIdentity.IdentityID, s.metadata.logAnalyticsResourceURL)
-		if err != nil {
-			return tokenData{}, nil
-		}
-
-		expiresOn := aadToken.ExpiresOnTimeObject.Unix()
-		if err != nil {
-			return tokenData{}, nil
-		}
-
-		tokenInfo = tokenData{
-			TokenType:               string(auth.CBSTokenTypeJWT),
-			AccessToken:             aadToken.AccessToken,
-			ExpiresOn:               expiresOn,
-			Resource:                s.metadata.logAnalyticsResourceURL,
-			IsWorkloadIdentityToken: true,
-		}
-
-		return tokenInfo, nil
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		body, statusCode, err = s.executeAADApicall(ctx)
-	case kedav1alpha1.PodIdentityProviderAzure:
-		body, statusCode, err = s.executeIMDSApicall(ctx)
-	}
-
-	if err != nil {
-		return tokenData{}, fmt.Errorf("error getting access token. HTTP code: %d. Inner Error: %v. Body: %s", statusCode, err, string(body))
-	} else if len(body) == 0 {
-		return tokenData{}, fmt.Errorf("error getting access token. Details: empty body. HTTP code: %d", statusCode)
-	}
-
-	err = json.NewDecoder(bytes.NewReader(body)).Decode(&tokenInfo)
-	if err != nil {
-		return tokenData{}, fmt.Errorf("error getting access token. Details: can't decode response body to JSON after getting access token. HTTP code: %d. Inner Error: %v. Body: %s", statusCode, err, string(body))
-	}
-
-	if statusCode == 200 {
-		return tokenInfo, nil
-	}
-
-	return tokenData{}, fmt.Errorf("error getting access token. Details: unknown error. HTTP code: %d. Body: %s", statusCode, string(body))
-}
-
-func (s *azureLogAnalyticsScaler) executeLogAnalyticsREST(ctx context.Context, query string, tokenInfo tokenData) ([]byte, int, error) {
+func (s *azureLogAnalyticsScaler) executeLogAnalyticsREST(ctx context.Context, query string, accessToken string) ([]byte, int, error) {
 	m := map[string]interface{}{"query": query}
 
 	jsonBytes, err := json.Marshal(m)
@@ -567,50 +386,12 @@ func (s *azureLogAnalyticsScaler) executeLogAnalyticsREST(ctx context.Context, q
 	}
 
 	request.Header.Add("Content-Type", "application/json")
-	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenInfo.AccessToken))
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 	request.Header.Add("Content-Length", fmt.Sprintf("%d", len(jsonBytes)))
 
 	return s.runHTTP(request, "Log Analytics REST api")
 }
 
-func (s *azureLogAnalyticsScaler) executeAADApicall(ctx context.Context) ([]byte, int, error) {
-	data := url.Values{
-		"grant_type":    {"client_credentials"},
-		"client_id":     {s.metadata.clientID},
-		"redirect_uri":  {"http://"},
-		"resource":      {s.metadata.logAnalyticsResourceURL},
-		"client_secret": {s.metadata.clientSecret},
-	}
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(aadTokenEndpoint, s.metadata.activeDirectoryEndpoint, s.metadata.tenantID), strings.NewReader(data.Encode())) // URL-encoded payload
-	if err != nil {
-		return nil, 0, fmt.Errorf("can't construct HTTP request to Azure Active Directory. Inner Error: %v", err)
-	}
-
-	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	request.Header.Add("Content-Length", fmt.Sprintf("%d", len(data.Encode())))
-
-	return s.runHTTP(request, "AAD")
-}
-
-func (s *azureLogAnalyticsScaler) executeIMDSApicall(ctx context.Context) ([]byte, int, error) {
-	var urlStr string
-	if s.metadata.podIdentity.IdentityID == "" {
-		urlStr = fmt.Sprintf(azure.MSIURL, s.metadata.logAnalyticsResourceURL)
-	} else {
-		urlStr = fmt.Sprintf(azure.MSIURLWithClientID, s.metadata.logAnalyticsResourceURL, url.QueryEscape(s.metadata.podIdentity.IdentityID))
-	}
-
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
-	if err != nil {
-		return nil, 0, fmt.Errorf("can't construct HTTP request to Azure Instance Metadata service. Inner Error: %v", err)
-	}
-
-	request.Header.Add("Metadata", "true")
-
-	return s.runHTTP(request, "IMDS")
-}
-
 func (s *azureLogAnalyticsScaler) runHTTP(request *http.Request, caller string) ([]byte, int, error) {
 	request.Header.Add("Cache-Control", "no-cache")
 	request.Header.Add("User-Agent", "keda/2.0.0")
@@ -632,44 +413,3 @@ func (s *azureLogAnalyticsScaler) runHTTP(request *http.Request, caller string)
 
 	return body, resp.StatusCode, nil
 }
-
-func getTokenFromCache(clientID string, clientSecret string) (tokenData, error) {
-	key, err := getHash(clientID, clientSecret)
-	if err != nil {
-		return tokenData{}, fmt.Errorf("error calculating sha1 hash. Inner Error: %v", err)
-	}
-
-	tokenCache.RLock()
-
-	if val, ok := tokenCache.m[key]; ok && val.AccessToken != "" {
-		tokenCache.RUnlock()
-		return val, nil
-	}
-
-	tokenCache.RUnlock()
-	return tokenData{}, fmt.Errorf("error getting value from token cache. Details: unknown error")
-}
-
-func setTokenInCache(clientID string, clientSecret string, tokenInfo tokenData) error {
-	key, err := getHash(clientID, clientSecret)
-	if err != nil {
-		return err
-	}
-
-	tokenCache.Lock()
-	tokenCache.m[key] = tokenInfo
-	tokenCache.Unlock()
-
-	return nil
-}
-
-func getHash(clientID string, clientSecret string) (string, error) {
-	sha1Hash := sha1.New()
-	_, err := sha1Hash.Write([]byte(fmt.Sprintf("%s|%s", clientID, clientSecret)))
-
-	if err != nil {
-		return "", err
-	}
-
-	return base64.StdEncoding.EncodeToString(sha1Hash.Sum(nil)), nil
-}
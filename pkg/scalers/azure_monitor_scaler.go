@@ -19,6 +19,7 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -28,7 +29,6 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -40,10 +40,10 @@ const (
 )
 
 type azureMonitorScaler struct {
-	metricType  v2beta2.MetricTargetType
-	metadata    *azureMonitorMetadata
-	podIdentity kedav1alpha1.AuthPodIdentity
-	logger      logr.Logger
+	metricType v2beta2.MetricTargetType
+	metadata   *azureMonitorMetadata
+	httpClient *http.Client
+	logger     logr.Logger
 }
 
 type azureMonitorMetadata struct {
@@ -68,10 +68,10 @@ func NewAzureMonitorScaler(config *ScalerConfig) (Scaler, error) {
 	}
 
 	return &azureMonitorScaler{
-		metricType:  metricType,
-		metadata:    meta,
-		podIdentity: config.PodIdentity,
-		logger:      logger,
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     logger,
 	}, nil
 }
 
@@ -150,22 +150,15 @@ func parseAzureMonitorMetadata(config *ScalerConfig, logger logr.Logger) (*azure
 		return nil, fmt.Errorf("no subscriptionId given")
 	}
 
-	if val, ok := config.TriggerMetadata["tenantId"]; ok && val != "" {
-		meta.azureMonitorInfo.TenantID = val
-	} else {
-		return nil, fmt.Errorf("no tenantId given")
-	}
-
 	if val, ok := config.TriggerMetadata["metricNamespace"]; ok {
 		meta.azureMonitorInfo.Namespace = val
 	}
 
-	clientID, clientPassword, err := parseAzurePodIdentityParams(config)
+	aadConfig, err := parseAzureAADCredentialConfig(config)
 	if err != nil {
 		return nil, err
 	}
-	meta.azureMonitorInfo.ClientID = clientID
-	meta.azureMonitorInfo.ClientPassword = clientPassword
+	meta.azureMonitorInfo.AADConfig = *aadConfig
 
 	meta.scalerIndex = config.ScalerIndex
 
@@ -178,45 +171,24 @@ func parseAzureMonitorMetadata(config *ScalerConfig, logger logr.Logger) (*azure
 	}
 	meta.azureMonitorInfo.AzureResourceManagerEndpoint = azureResourceManagerEndpoint
 
-	activeDirectoryEndpoint, err := azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
-	if err != nil {
-		return nil, err
-	}
-	meta.azureMonitorInfo.ActiveDirectoryEndpoint = activeDirectoryEndpoint
-
 	return &meta, nil
 }
 
-// parseAzurePodIdentityParams gets the activeDirectory clientID and password
-func parseAzurePodIdentityParams(config *ScalerConfig) (clientID string, clientPassword string, err error) {
-	switch config.PodIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
-		clientID, err = getParameterFromConfig(config, "activeDirectoryClientId", true)
-		if err != nil || clientID == "" {
-			return "", "", fmt.Errorf("no activeDirectoryClientId given")
-		}
-
-		if config.AuthParams["activeDirectoryClientPassword"] != "" {
-			clientPassword = config.AuthParams["activeDirectoryClientPassword"]
-		} else if config.TriggerMetadata["activeDirectoryClientPasswordFromEnv"] != "" {
-			clientPassword = config.ResolvedEnv[config.TriggerMetadata["activeDirectoryClientPasswordFromEnv"]]
-		}
-
-		if len(clientPassword) == 0 {
-			return "", "", fmt.Errorf("no activeDirectoryClientPassword given")
-		}
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-		// no params required to be parsed
-	default:
-		return "", "", fmt.Errorf("azure Monitor doesn't support pod identity %s", config.PodIdentity)
+// parseAzureAADCredentialConfig parses the Azure AD identity required by the scalers querying Azure APIs
+func parseAzureAADCredentialConfig(config *ScalerConfig) (*azure.AADCredentialConfig, error) {
+	aadConfig, err := azure.ParseAADCredentialConfig(config.PodIdentity, config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	if err != nil {
+		return nil, err
 	}
-
-	return clientID, clientPassword, nil
+	if aadConfig == nil {
+		return nil, azure.ErrNoAADCredential
+	}
+	return aadConfig, nil
 }
 
 // Returns true if the Azure Monitor metric value is greater than zero
 func (s *azureMonitorScaler) IsActive(ctx context.Context) (bool, error) {
-	val, err := azure.GetAzureMetricValue(ctx, s.metadata.azureMonitorInfo, s.podIdentity)
+	val, err := azure.GetAzureMetricValue(ctx, s.httpClient, s.metadata.azureMonitorInfo)
 	if err != nil {
 		s.logger.Error(err, "error getting azure monitor metric")
 		return false, err
@@ -242,7 +214,7 @@ func (s *azureMonitorScaler) GetMetricSpecForScaling(context.Context) []v2beta2.
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *azureMonitorScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	val, err := azure.GetAzureMetricValue(ctx, s.metadata.azureMonitorInfo, s.podIdentity)
+	val, err := azure.GetAzureMetricValue(ctx, s.httpClient, s.metadata.azureMonitorInfo)
 	if err != nil {
 		s.logger.Error(err, "error getting azure monitor metric")
 		return []external_metrics.ExternalMetricValue{}, err
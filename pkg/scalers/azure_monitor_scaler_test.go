@@ -18,6 +18,7 @@ package scalers
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -130,7 +131,7 @@ func TestAzMonitorGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockAzMonitorScaler := azureMonitorScaler{"", meta, kedav1alpha1.AuthPodIdentity{Provider: testData.metadataTestData.podIdentity}, logr.Discard()}
+		mockAzMonitorScaler := azureMonitorScaler{"", meta, http.DefaultClient, logr.Discard()}
 
 		metricSpec := mockAzMonitorScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
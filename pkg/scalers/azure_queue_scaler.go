@@ -40,11 +40,11 @@ const (
 )
 
 type azureQueueScaler struct {
-	metricType  v2beta2.MetricTargetType
-	metadata    *azureQueueMetadata
-	podIdentity kedav1alpha1.AuthPodIdentity
-	httpClient  *http.Client
-	logger      logr.Logger
+	metricType v2beta2.MetricTargetType
+	metadata   *azureQueueMetadata
+	aadConfig  *azure.AADCredentialConfig
+	httpClient *http.Client
+	logger     logr.Logger
 }
 
 type azureQueueMetadata struct {
@@ -66,21 +66,21 @@ func NewAzureQueueScaler(config *ScalerConfig) (Scaler, error) {
 
 	logger := InitializeLogger(config, "azure_queue_scaler")
 
-	meta, podIdentity, err := parseAzureQueueMetadata(config, logger)
+	meta, aadConfig, err := parseAzureQueueMetadata(config, logger)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing azure queue metadata: %s", err)
 	}
 
 	return &azureQueueScaler{
-		metricType:  metricType,
-		metadata:    meta,
-		podIdentity: podIdentity,
-		httpClient:  kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
-		logger:      logger,
+		metricType: metricType,
+		metadata:   meta,
+		aadConfig:  aadConfig,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     logger,
 	}, nil
 }
 
-func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQueueMetadata, kedav1alpha1.AuthPodIdentity, error) {
+func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQueueMetadata, *azure.AADCredentialConfig, error) {
 	meta := azureQueueMetadata{}
 	meta.targetQueueLength = defaultTargetQueueLength
 
@@ -88,7 +88,7 @@ func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQu
 		queueLength, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
 			logger.Error(err, "Error parsing azure queue metadata", "queueLengthMetricName", queueLengthMetricName)
-			return nil, kedav1alpha1.AuthPodIdentity{},
+			return nil, nil,
 				fmt.Errorf("error parsing azure queue metadata %s: %s", queueLengthMetricName, err.Error())
 		}
 
@@ -100,7 +100,7 @@ func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQu
 		activationQueueLength, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
 			logger.Error(err, "Error parsing azure queue metadata", activationQueueLengthMetricName, activationQueueLengthMetricName)
-			return nil, kedav1alpha1.AuthPodIdentity{},
+			return nil, nil,
 				fmt.Errorf("error parsing azure queue metadata %s: %s", activationQueueLengthMetricName, err.Error())
 		}
 
@@ -109,7 +109,7 @@ func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQu
 
 	endpointSuffix, err := azure.ParseAzureStorageEndpointSuffix(config.TriggerMetadata, azure.QueueEndpoint)
 	if err != nil {
-		return nil, kedav1alpha1.AuthPodIdentity{}, err
+		return nil, nil, err
 	}
 
 	meta.endpointSuffix = endpointSuffix
@@ -117,7 +117,7 @@ func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQu
 	if val, ok := config.TriggerMetadata["queueName"]; ok && val != "" {
 		meta.queueName = val
 	} else {
-		return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("no queueName given")
+		return nil, nil, fmt.Errorf("no queueName given")
 	}
 
 	// before triggerAuthentication CRD, pod identity was configured using this property
@@ -127,10 +127,13 @@ func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQu
 		}
 	}
 
-	// If the Use AAD Pod Identity is not present, or set to "none"
-	// then check for connection string
-	switch config.PodIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
+	aadConfig, err := azure.ParseAADCredentialConfig(config.PodIdentity, config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If no Azure AD identity is given, then check for connection string
+	if aadConfig == nil {
 		// Azure Queue Scaler expects a "connection" parameter in the metadata
 		// of the scaler or in a TriggerAuthentication object
 		if config.AuthParams["connection"] != "" {
@@ -141,22 +144,20 @@ func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQu
 		}
 
 		if len(meta.connection) == 0 {
-			return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("no connection setting given")
+			return nil, nil, fmt.Errorf("no connection setting given")
 		}
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
-		// If the Use AAD Pod Identity is present then check account name
+	} else {
+		// If an Azure AD identity is given then check account name
 		if val, ok := config.TriggerMetadata["accountName"]; ok && val != "" {
 			meta.accountName = val
 		} else {
-			return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("no accountName given")
+			return nil, nil, fmt.Errorf("no accountName given")
 		}
-	default:
-		return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("pod identity %s not supported for azure storage queues", config.PodIdentity)
 	}
 
 	meta.scalerIndex = config.ScalerIndex
 
-	return &meta, config.PodIdentity, nil
+	return &meta, aadConfig, nil
 }
 
 // IsActive determines whether this scaler is currently active
@@ -164,7 +165,7 @@ func (s *azureQueueScaler) IsActive(ctx context.Context) (bool, error) {
 	length, err := azure.GetAzureQueueLength(
 		ctx,
 		s.httpClient,
-		s.podIdentity,
+		s.aadConfig,
 		s.metadata.connection,
 		s.metadata.queueName,
 		s.metadata.accountName,
@@ -199,7 +200,7 @@ func (s *azureQueueScaler) GetMetrics(ctx context.Context, metricName string, me
 	queuelen, err := azure.GetAzureQueueLength(
 		ctx,
 		s.httpClient,
-		s.podIdentity,
+		s.aadConfig,
 		s.metadata.connection,
 		s.metadata.queueName,
 		s.metadata.accountName,
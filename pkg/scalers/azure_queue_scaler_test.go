@@ -104,7 +104,7 @@ var azQueueMetricIdentifiers = []azQueueMetricIdentifier{
 
 func TestAzQueueParseMetadata(t *testing.T) {
 	for _, testData := range testAzQueueMetadata {
-		_, podIdentity, err := parseAzureQueueMetadata(&ScalerConfig{TriggerMetadata: testData.metadata,
+		_, aadConfig, err := parseAzureQueueMetadata(&ScalerConfig{TriggerMetadata: testData.metadata,
 			ResolvedEnv: testData.resolvedEnv, AuthParams: testData.authParams,
 			PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.podIdentity}},
 			logr.Discard())
@@ -114,7 +114,7 @@ func TestAzQueueParseMetadata(t *testing.T) {
 		if testData.isError && err == nil {
 			t.Errorf("Expected error but got success. testData: %v", testData)
 		}
-		if testData.podIdentity != "" && testData.podIdentity != podIdentity.Provider && err == nil {
+		if testData.podIdentity != "" && testData.podIdentity != kedav1alpha1.PodIdentityProviderNone && (aadConfig == nil || testData.podIdentity != aadConfig.PodIdentity.Provider) && err == nil {
 			t.Error("Expected success but got error: podIdentity value is not returned as expected")
 		}
 	}
@@ -122,7 +122,7 @@ func TestAzQueueParseMetadata(t *testing.T) {
 
 func TestAzQueueGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range azQueueMetricIdentifiers {
-		meta, podIdentity, err := parseAzureQueueMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
+		meta, aadConfig, err := parseAzureQueueMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
 			ResolvedEnv: testData.metadataTestData.resolvedEnv, AuthParams: testData.metadataTestData.authParams,
 			PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.metadataTestData.podIdentity}, ScalerIndex: testData.scalerIndex},
 			logr.Discard())
@@ -130,9 +130,9 @@ func TestAzQueueGetMetricSpecForScaling(t *testing.T) {
 			t.Fatal("Could not parse metadata:", err)
 		}
 		mockAzQueueScaler := azureQueueScaler{
-			metadata:    meta,
-			podIdentity: podIdentity,
-			httpClient:  http.DefaultClient,
+			metadata:   meta,
+			aadConfig:  aadConfig,
+			httpClient: http.DefaultClient,
 		}
 
 		metricSpec := mockAzQueueScaler.GetMetricSpecForScaling(context.Background())
@@ -22,7 +22,6 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/Azure/azure-amqp-common-go/v3/auth"
 	servicebus "github.com/Azure/azure-service-bus-go"
 	az "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/go-logr/logr"
@@ -30,7 +29,6 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -49,12 +47,11 @@ const (
 )
 
 type azureServiceBusScaler struct {
-	ctx         context.Context
-	metricType  v2beta2.MetricTargetType
-	metadata    *azureServiceBusMetadata
-	podIdentity kedav1alpha1.AuthPodIdentity
-	httpClient  *http.Client
-	logger      logr.Logger
+	ctx        context.Context
+	metricType v2beta2.MetricTargetType
+	metadata   *azureServiceBusMetadata
+	httpClient *http.Client
+	logger     logr.Logger
 }
 
 type azureServiceBusMetadata struct {
@@ -64,6 +61,7 @@ type azureServiceBusMetadata struct {
 	topicName              string
 	subscriptionName       string
 	connection             string
+	aadConfig              *azure.AADCredentialConfig
 	entityType             entityType
 	namespace              string
 	endpointSuffix         string
@@ -85,12 +83,11 @@ func NewAzureServiceBusScaler(ctx context.Context, config *ScalerConfig) (Scaler
 	}
 
 	return &azureServiceBusScaler{
-		ctx:         ctx,
-		metricType:  metricType,
-		metadata:    meta,
-		podIdentity: config.PodIdentity,
-		httpClient:  kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
-		logger:      logger,
+		ctx:        ctx,
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     logger,
 	}, nil
 }
 
@@ -157,8 +154,13 @@ func parseAzureServiceBusMetadata(config *ScalerConfig, logger logr.Logger) (*az
 	if meta.entityType == none {
 		return nil, fmt.Errorf("no service bus entity type set")
 	}
-	switch config.PodIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
+	aadConfig, err := azure.ParseAADCredentialConfig(config.PodIdentity, config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	if err != nil {
+		return nil, err
+	}
+	meta.aadConfig = aadConfig
+
+	if aadConfig == nil {
 		// get servicebus connection string
 		if config.AuthParams["connection"] != "" {
 			meta.connection = config.AuthParams["connection"]
@@ -169,14 +171,12 @@ func parseAzureServiceBusMetadata(config *ScalerConfig, logger logr.Logger) (*az
 		if len(meta.connection) == 0 {
 			return nil, fmt.Errorf("no connection setting given")
 		}
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+	} else {
 		if val, ok := config.TriggerMetadata["namespace"]; ok {
 			meta.namespace = val
 		} else {
-			return nil, fmt.Errorf("namespace is required when using pod identity")
+			return nil, fmt.Errorf("namespace is required when using azure AD authentication")
 		}
-	default:
-		return nil, fmt.Errorf("azure service bus doesn't support pod identity %s", config.PodIdentity)
 	}
 
 	meta.scalerIndex = config.ScalerIndex
@@ -233,34 +233,6 @@ func (s *azureServiceBusScaler) GetMetrics(ctx context.Context, metricName strin
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
 
-type azureTokenProvider struct {
-	httpClient  *http.Client
-	ctx         context.Context
-	podIdentity kedav1alpha1.AuthPodIdentity
-}
-
-// GetToken implements TokenProvider interface for azureTokenProvider
-func (a azureTokenProvider) GetToken(uri string) (*auth.Token, error) {
-	ctx := a.ctx
-
-	var token azure.AADToken
-	var err error
-
-	switch a.podIdentity.Provider {
-	case kedav1alpha1.PodIdentityProviderAzure:
-		token, err = azure.GetAzureADPodIdentityToken(ctx, a.httpClient, a.podIdentity.IdentityID, serviceBusResource)
-	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		token, err = azure.GetAzureADWorkloadIdentityToken(ctx, a.podIdentity.IdentityID, serviceBusResource)
-	default:
-		err = fmt.Errorf("unknown pod identity provider")
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	return auth.NewToken(auth.CBSTokenTypeJWT, token.AccessToken, token.ExpiresOn), nil
-}
-
 // Returns the length of the queue or subscription
 func (s *azureServiceBusScaler) getAzureServiceBusLength(ctx context.Context) (int64, error) {
 	// get namespace
@@ -284,22 +256,17 @@ func (s *azureServiceBusScaler) getServiceBusNamespace(ctx context.Context) (*se
 	var namespace *servicebus.Namespace
 	var err error
 
-	switch s.podIdentity.Provider {
-	case "", kedav1alpha1.PodIdentityProviderNone:
+	if s.metadata.aadConfig == nil {
 		namespace, err = servicebus.NewNamespace(servicebus.NamespaceWithConnectionString(s.metadata.connection))
 		if err != nil {
 			return namespace, err
 		}
-	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+	} else {
 		namespace, err = servicebus.NewNamespace()
 		if err != nil {
 			return namespace, err
 		}
-		namespace.TokenProvider = azureTokenProvider{
-			ctx:         ctx,
-			httpClient:  s.httpClient,
-			podIdentity: s.podIdentity,
-		}
+		namespace.TokenProvider = azure.NewAADCredential(*s.metadata.aadConfig, s.httpClient).TokenProvider(ctx, serviceBusResource)
 		namespace.Name = s.metadata.namespace
 	}
 
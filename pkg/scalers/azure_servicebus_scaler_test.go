@@ -26,6 +26,7 @@ import (
 	"github.com/go-logr/logr"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 )
 
 const (
@@ -137,18 +138,18 @@ var getServiceBusLengthTestScalers = []azureServiceBusScaler{
 			entityType:       subscription,
 			topicName:        topicName,
 			subscriptionName: subscriptionName,
+			aadConfig:        &azure.AADCredentialConfig{PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure}},
 		},
-		podIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure},
-		httpClient:  commonHTTPClient,
+		httpClient: commonHTTPClient,
 	},
 	{
 		metadata: &azureServiceBusMetadata{
 			entityType:       subscription,
 			topicName:        topicName,
 			subscriptionName: subscriptionName,
+			aadConfig:        &azure.AADCredentialConfig{PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload}},
 		},
-		podIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload},
-		httpClient:  commonHTTPClient,
+		httpClient: commonHTTPClient,
 	},
 }
 
@@ -218,9 +219,8 @@ func TestAzServiceBusGetMetricSpecForScaling(t *testing.T) {
 			t.Fatal("Could not parse metadata:", err)
 		}
 		mockAzServiceBusScalerScaler := azureServiceBusScaler{
-			metadata:    meta,
-			podIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.metadataTestData.podIdentity},
-			httpClient:  http.DefaultClient,
+			metadata:   meta,
+			httpClient: http.DefaultClient,
 		}
 
 		metricSpec := mockAzServiceBusScalerScaler.GetMetricSpecForScaling(context.Background())
@@ -323,3 +323,16 @@ func (s *datadogScaler) GetMetrics(ctx context.Context, metricName string, metri
 
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
+
+// GetMetricsAndActivity returns value for a supported metric and whether it is above the activation value
+func (s *datadogScaler) GetMetricsAndActivity(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error) {
+	num, err := s.getQueryResult(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting metrics from Datadog")
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error getting metrics from Datadog: %s", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, num)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), num > s.metadata.activationQueryValue, nil
+}
@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -40,6 +41,9 @@ type elasticsearchMetadata struct {
 	targetValue           float64
 	activationTargetValue float64
 	metricName            string
+
+	// oauth2 client credentials
+	oauth *authentication.AuthMeta
 }
 
 // NewElasticsearchScaler creates a new elasticsearch scaler
@@ -101,6 +105,16 @@ func parseElasticsearchMetadata(config *ScalerConfig) (*elasticsearchMetadata, e
 		meta.password = config.ResolvedEnv[config.TriggerMetadata["passwordFromEnv"]]
 	}
 
+	if val, ok := config.TriggerMetadata["authMode"]; ok {
+		if authentication.Type(val) != authentication.OAuthType {
+			return nil, fmt.Errorf("authMode must be 'oauth'")
+		}
+		meta.oauth, err = authentication.GetOAuthConfigs(config.TriggerMetadata, config.AuthParams)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	index, err := GetFromAuthOrMeta(config, "index")
 	if err != nil {
 		return nil, err
@@ -155,6 +169,9 @@ func newElasticsearchClient(meta *elasticsearchMetadata, logger logr.Logger) (*e
 	transport := http.DefaultTransport.(*http.Transport)
 	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: meta.unsafeSsl}
 	config.Transport = transport
+	if meta.oauth != nil {
+		config.Transport = authentication.NewOAuth2RoundTripper(meta.oauth, transport)
+	}
 
 	esClient, err := elasticsearch.NewClient(config)
 	if err != nil {
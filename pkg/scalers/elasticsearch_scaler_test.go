@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
 )
 
 type parseElasticsearchMetadataTestData struct {
@@ -278,6 +280,49 @@ var testCases = []parseElasticsearchMetadataTestData{
 		},
 		expectedError: nil,
 	},
+	{
+		name: "oauth authMode",
+		metadata: map[string]string{
+			"addresses":          "http://localhost:9200",
+			"index":              "index1",
+			"searchTemplateName": "myAwesomeSearch",
+			"valueLocation":      "hits.hits[0]._source.value",
+			"targetValue":        "12",
+			"authMode":           "oauth",
+			"oauthTokenURI":      "http://localhost:8080/token",
+			"scopes":             "search, read",
+		},
+		authParams: map[string]string{
+			"clientID":     "keda",
+			"clientSecret": "secret",
+		},
+		expectedMetadata: &elasticsearchMetadata{
+			addresses:          []string{"http://localhost:9200"},
+			unsafeSsl:          false,
+			indexes:            []string{"index1"},
+			searchTemplateName: "myAwesomeSearch",
+			valueLocation:      "hits.hits[0]._source.value",
+			targetValue:        12,
+			metricName:         "s0-elasticsearch-myAwesomeSearch",
+			oauth: &authentication.AuthMeta{
+				EnableOAuth:   true,
+				OauthTokenURI: "http://localhost:8080/token",
+				ClientID:      "keda",
+				ClientSecret:  "secret",
+				Scopes:        []string{"search", "read"},
+			},
+		},
+		expectedError: nil,
+	},
+	{
+		name: "unsupported authMode",
+		metadata: map[string]string{
+			"addresses": "http://localhost:9200",
+			"authMode":  "basic",
+		},
+		authParams:    map[string]string{},
+		expectedError: errors.New("authMode must be 'oauth'"),
+	},
 }
 
 func TestParseElasticsearchMetadata(t *testing.T) {
@@ -2,7 +2,11 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -34,7 +38,12 @@ type externalPushScaler struct {
 type externalScalerMetadata struct {
 	scalerAddress    string
 	tlsCertFile      string
+	caCert           string
+	tlsClientCert    string
+	tlsClientKey     string
+	unsafeSsl        bool
 	originalMetadata map[string]string
+	authParams       map[string]string
 	scalerIndex      int
 }
 
@@ -53,20 +62,18 @@ func NewExternalScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error getting external scaler metric type: %s", err)
 	}
 
-	meta, err := parseExternalScalerMetadata(config)
+	logger := InitializeLogger(config, "external_scaler")
+
+	meta, err := parseExternalScalerMetadata(config, logger)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing external scaler metadata: %s", err)
 	}
 
 	return &externalScaler{
-		metricType: metricType,
-		metadata:   meta,
-		scaledObjectRef: pb.ScaledObjectRef{
-			Name:           config.ScalableObjectName,
-			Namespace:      config.ScalableObjectNamespace,
-			ScalerMetadata: meta.originalMetadata,
-		},
-		logger: InitializeLogger(config, "external_scaler"),
+		metricType:      metricType,
+		metadata:        meta,
+		scaledObjectRef: newScaledObjectRef(config, meta),
+		logger:          logger,
 	}, nil
 }
 
@@ -77,26 +84,31 @@ func NewExternalPushScaler(config *ScalerConfig) (PushScaler, error) {
 		return nil, fmt.Errorf("error getting external scaler metric type: %s", err)
 	}
 
-	meta, err := parseExternalScalerMetadata(config)
+	logger := InitializeLogger(config, "external_push_scaler")
+
+	meta, err := parseExternalScalerMetadata(config, logger)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing external scaler metadata: %s", err)
 	}
 
 	return &externalPushScaler{
 		externalScaler{
-			metricType: metricType,
-			metadata:   meta,
-			scaledObjectRef: pb.ScaledObjectRef{
-				Name:           config.ScalableObjectName,
-				Namespace:      config.ScalableObjectNamespace,
-				ScalerMetadata: meta.originalMetadata,
-			},
-			logger: InitializeLogger(config, "external_push_scaler"),
+			metricType:      metricType,
+			metadata:        meta,
+			scaledObjectRef: newScaledObjectRef(config, meta),
+			logger:          logger,
 		},
 	}, nil
 }
 
-func parseExternalScalerMetadata(config *ScalerConfig) (externalScalerMetadata, error) {
+// externalScalerTLSAuthParams are the authParams used for the connection to the external scaler, they aren't sent to it
+var externalScalerTLSAuthParams = map[string]struct{}{
+	"caCert":        {},
+	"tlsClientCert": {},
+	"tlsClientKey":  {},
+}
+
+func parseExternalScalerMetadata(config *ScalerConfig, logger logr.Logger) (externalScalerMetadata, error) {
 	meta := externalScalerMetadata{
 		originalMetadata: config.TriggerMetadata,
 	}
@@ -112,6 +124,21 @@ func parseExternalScalerMetadata(config *ScalerConfig) (externalScalerMetadata,
 		meta.tlsCertFile = val
 	}
 
+	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok && val != "" {
+		unsafeSsl, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing unsafeSsl: %s", err)
+		}
+		meta.unsafeSsl = unsafeSsl
+	}
+
+	meta.caCert = config.AuthParams["caCert"]
+	meta.tlsClientCert = config.AuthParams["tlsClientCert"]
+	meta.tlsClientKey = config.AuthParams["tlsClientKey"]
+	if (meta.tlsClientCert == "") != (meta.tlsClientKey == "") {
+		return meta, fmt.Errorf("both tlsClientCert and tlsClientKey must be provided for mTLS")
+	}
+
 	meta.originalMetadata = make(map[string]string)
 
 	// Add elements to metadata
@@ -125,10 +152,43 @@ func parseExternalScalerMetadata(config *ScalerConfig) (externalScalerMetadata,
 			meta.originalMetadata[key] = value
 		}
 	}
+	meta.authParams = make(map[string]string)
+	for key, value := range config.AuthParams {
+		// the TLS material is only used to connect to the external scaler
+		if _, ok := externalScalerTLSAuthParams[key]; !ok {
+			meta.authParams[key] = value
+		}
+	}
+	// authParams are only sent over a verified TLS connection, the scaler is still built without them
+	// so triggers of external scalers connected without TLS keep working as before authParams were sent
+	if len(meta.authParams) > 0 && (!meta.isTLSEnabled() || meta.unsafeSsl) {
+		logger.Info("authParams are not sent to the external scaler without a verified TLS connection, configure tlsCertFile, caCert or tlsClientCert without unsafeSsl to send them")
+		meta.authParams = map[string]string{}
+	}
 	meta.scalerIndex = config.ScalerIndex
 	return meta, nil
 }
 
+// newScaledObjectRef creates the reference sent to the external scaler, it carries the resolved trigger metadata,
+// the parameters resolved from TriggerAuthentication and the scale target of the ScaledObject
+func newScaledObjectRef(config *ScalerConfig, meta externalScalerMetadata) pb.ScaledObjectRef {
+	scaledObjectRef := pb.ScaledObjectRef{
+		Name:           config.ScalableObjectName,
+		Namespace:      config.ScalableObjectNamespace,
+		ScalerMetadata: meta.originalMetadata,
+		AuthParams:     meta.authParams,
+	}
+
+	if config.ScaleTargetRef != nil {
+		scaledObjectRef.ScaleTargetRef = &pb.ScaleTargetRef{
+			ApiVersion: config.ScaleTargetRef.APIVersion,
+			Kind:       config.ScaleTargetRef.Kind,
+			Name:       config.ScaleTargetRef.Name,
+		}
+	}
+	return scaledObjectRef
+}
+
 // IsActive checks if there are any messages in the subscription
 func (s *externalScaler) IsActive(ctx context.Context) (bool, error) {
 	grpcClient, err := getClientForConnectionPool(s.metadata)
@@ -170,7 +230,7 @@ func (s *externalScaler) GetMetricSpecForScaling(ctx context.Context) []v2beta2.
 			Metric: v2beta2.MetricIdentifier{
 				Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, spec.MetricName),
 			},
-			Target: GetMetricTarget(s.metricType, spec.TargetSize),
+			Target: getExternalMetricTarget(s.metricType, spec),
 		}
 
 		// Create the metric spec for the HPA
@@ -211,13 +271,31 @@ func (s *externalScaler) GetMetrics(ctx context.Context, metricName string, metr
 	}
 
 	for _, metricResult := range response.MetricValues {
-		metric := GenerateMetricInMili(metricName, float64(metricResult.MetricValue))
+		metric := GenerateMetricInMili(metricName, getExternalMetricValue(metricResult))
 		metrics = append(metrics, metric)
 	}
 
 	return metrics, nil
 }
 
+// getExternalMetricTarget returns the metric target of the spec, preferring the floating point
+// targetSizeFloat if it is set by the external scaler over the integer targetSize
+func getExternalMetricTarget(metricType v2beta2.MetricTargetType, spec *pb.MetricSpec) v2beta2.MetricTarget {
+	if spec.TargetSizeFloat != 0 {
+		return GetMetricTargetMili(metricType, spec.TargetSizeFloat)
+	}
+	return GetMetricTarget(metricType, spec.TargetSize)
+}
+
+// getExternalMetricValue returns the value of the metric, preferring the floating point
+// metricValueFloat if it is set by the external scaler over the integer metricValue
+func getExternalMetricValue(metricValue *pb.MetricValue) float64 {
+	if metricValue.MetricValueFloat != 0 {
+		return metricValue.MetricValueFloat
+	}
+	return float64(metricValue.MetricValue)
+}
+
 // handleIsActiveStream is the only writer to the active channel and will close it on return.
 func (s *externalPushScaler) Run(ctx context.Context, active chan<- bool) {
 	defer close(active)
@@ -289,12 +367,12 @@ func getClientForConnectionPool(metadata externalScalerMetadata) (pb.ExternalSca
 	defer connectionPoolMutex.Unlock()
 
 	buildGRPCConnection := func(metadata externalScalerMetadata) (*grpc.ClientConn, error) {
-		if metadata.tlsCertFile != "" {
-			creds, err := credentials.NewClientTLSFromFile(metadata.tlsCertFile, "")
+		if metadata.isTLSEnabled() {
+			tlsConfig, err := getExternalScalerTLSConfig(metadata)
 			if err != nil {
 				return nil, err
 			}
-			return grpc.Dial(metadata.scalerAddress, grpc.WithTransportCredentials(creds))
+			return grpc.Dial(metadata.scalerAddress, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 		}
 
 		return grpc.Dial(metadata.scalerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -302,7 +380,14 @@ func getClientForConnectionPool(metadata externalScalerMetadata) (pb.ExternalSca
 
 	// create a unique key per-metadata. If scaledObjects share the same connection properties
 	// in the metadata, they will share the same grpc.ClientConn
-	key, err := hashstructure.Hash(metadata.scalerAddress, nil)
+	key, err := hashstructure.Hash([]string{
+		metadata.scalerAddress,
+		metadata.tlsCertFile,
+		metadata.caCert,
+		metadata.tlsClientCert,
+		metadata.tlsClientKey,
+		strconv.FormatBool(metadata.unsafeSsl),
+	}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -337,6 +422,46 @@ func getClientForConnectionPool(metadata externalScalerMetadata) (pb.ExternalSca
 	return pb.NewExternalScalerClient(connGroup.grpcConnection), nil
 }
 
+func (metadata externalScalerMetadata) isTLSEnabled() bool {
+	return metadata.tlsCertFile != "" || metadata.caCert != "" || metadata.tlsClientCert != "" || metadata.unsafeSsl
+}
+
+// getExternalScalerTLSConfig returns the TLS configuration for the connection to the external scaler,
+// the server is verified against the CA from tlsCertFile or caCert and the client certificate is used for mTLS
+func getExternalScalerTLSConfig(metadata externalScalerMetadata) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: metadata.unsafeSsl,
+	}
+
+	if metadata.tlsCertFile != "" || metadata.caCert != "" {
+		caCertPool := x509.NewCertPool()
+		if metadata.tlsCertFile != "" {
+			caCert, err := os.ReadFile(metadata.tlsCertFile)
+			if err != nil {
+				return nil, err
+			}
+			if !caCertPool.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("failed to append certificates from tlsCertFile %s", metadata.tlsCertFile)
+			}
+		}
+		if metadata.caCert != "" && !caCertPool.AppendCertsFromPEM([]byte(metadata.caCert)) {
+			return nil, fmt.Errorf("failed to append certificates from caCert")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if metadata.tlsClientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(metadata.tlsClientCert), []byte(metadata.tlsClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing tlsClientCert and tlsClientKey: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
 func waitForState(ctx context.Context, conn *grpc.ClientConn, states ...connectivity.State) (done chan struct{}) {
 	done = make(chan struct{})
 
@@ -8,31 +8,51 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
+	"k8s.io/api/autoscaling/v2beta2"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
 )
 
 type parseExternalScalerMetadataTestData struct {
-	metadata map[string]string
-	isError  bool
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
 }
 
 var testExternalScalerMetadata = []parseExternalScalerMetadataTestData{
-	{map[string]string{}, true},
+	{map[string]string{}, map[string]string{}, true},
 	// all properly formed
-	{map[string]string{"scalerAddress": "myservice", "test1": "7", "test2": "SAMPLE_CREDS"}, false},
+	{map[string]string{"scalerAddress": "myservice", "test1": "7", "test2": "SAMPLE_CREDS"}, map[string]string{}, false},
 	// missing scalerAddress
-	{map[string]string{"test1": "1", "test2": "SAMPLE_CREDS"}, true},
+	{map[string]string{"test1": "1", "test2": "SAMPLE_CREDS"}, map[string]string{}, true},
+	// properly formed unsafeSsl
+	{map[string]string{"scalerAddress": "myservice", "unsafeSsl": "true"}, map[string]string{}, false},
+	// malformed unsafeSsl
+	{map[string]string{"scalerAddress": "myservice", "unsafeSsl": "yes please"}, map[string]string{}, true},
+	// mTLS with client certificate and key
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"caCert": "ca", "tlsClientCert": "cert", "tlsClientKey": "key"}, false},
+	// mTLS with client certificate but without key
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"tlsClientCert": "cert"}, true},
+	// mTLS with client key but without certificate
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"tlsClientKey": "key"}, true},
+	// authParams over TLS
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"caCert": "ca", "password": "secret"}, false},
+	// authParams without TLS, they aren't sent
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"password": "secret"}, false},
+	// authParams over TLS without verifying the external scaler, they aren't sent
+	{map[string]string{"scalerAddress": "myservice", "unsafeSsl": "true"}, map[string]string{"password": "secret"}, false},
 }
 
 func TestExternalScalerParseMetadata(t *testing.T) {
 	for _, testData := range testExternalScalerMetadata {
-		_, err := parseExternalScalerMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: map[string]string{}})
+		_, err := parseExternalScalerMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: map[string]string{}}, logr.Discard())
 		if err != nil && !testData.isError {
 			t.Error("Expected success but got error", err)
 		}
@@ -42,6 +62,98 @@ func TestExternalScalerParseMetadata(t *testing.T) {
 	}
 }
 
+func TestExternalScalerScaledObjectRef(t *testing.T) {
+	config := &ScalerConfig{
+		ScalableObjectName:      "app",
+		ScalableObjectNamespace: "namespace",
+		ScaleTargetRef:          &kedav1alpha1.ScaleTarget{APIVersion: "apps/v1", Kind: "Deployment", Name: "deployment"},
+		TriggerMetadata:         map[string]string{"scalerAddress": "myservice"},
+		AuthParams:              map[string]string{"password": "secret", "caCert": "ca", "tlsClientCert": "cert", "tlsClientKey": "key"},
+		ResolvedEnv:             map[string]string{},
+	}
+	meta, err := parseExternalScalerMetadata(config, logr.Discard())
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	ref := newScaledObjectRef(config, meta)
+	if ref.AuthParams["password"] != "secret" {
+		t.Errorf("Expected authParams to be passed to the external scaler but got %v", ref.AuthParams)
+	}
+	for _, key := range []string{"caCert", "tlsClientCert", "tlsClientKey"} {
+		if _, ok := ref.AuthParams[key]; ok {
+			t.Errorf("Expected %s not to be passed to the external scaler but got %v", key, ref.AuthParams)
+		}
+	}
+	if ref.ScaleTargetRef == nil || ref.ScaleTargetRef.ApiVersion != "apps/v1" || ref.ScaleTargetRef.Kind != "Deployment" || ref.ScaleTargetRef.Name != "deployment" {
+		t.Errorf("Expected scaleTargetRef to be passed to the external scaler but got %v", ref.ScaleTargetRef)
+	}
+
+	config.ScaleTargetRef = nil
+	ref = newScaledObjectRef(config, meta)
+	if ref.ScaleTargetRef != nil {
+		t.Errorf("Expected no scaleTargetRef but got %v", ref.ScaleTargetRef)
+	}
+}
+
+func TestExternalScalerWithoutTLSDropsAuthParams(t *testing.T) {
+	for _, triggerMetadata := range []map[string]string{
+		{"scalerAddress": "myservice"},
+		{"scalerAddress": "myservice", "unsafeSsl": "true"},
+	} {
+		config := &ScalerConfig{
+			TriggerMetadata: triggerMetadata,
+			AuthParams:      map[string]string{"password": "secret"},
+			ResolvedEnv:     map[string]string{},
+		}
+
+		scaler, err := NewExternalScaler(config)
+		if err != nil {
+			t.Fatal("Expected the scaler to be built but got error", err)
+		}
+		if ref := scaler.(*externalScaler).scaledObjectRef; len(ref.AuthParams) != 0 {
+			t.Errorf("Expected no authParams to be sent without verified TLS but got %v", ref.AuthParams)
+		}
+
+		pushScaler, err := NewExternalPushScaler(config)
+		if err != nil {
+			t.Fatal("Expected the push scaler to be built but got error", err)
+		}
+		if ref := pushScaler.(*externalPushScaler).scaledObjectRef; len(ref.AuthParams) != 0 {
+			t.Errorf("Expected no authParams to be sent without verified TLS but got %v", ref.AuthParams)
+		}
+	}
+}
+
+type externalScalerFloatTestData struct {
+	spec           *pb.MetricSpec
+	value          *pb.MetricValue
+	expectedTarget string
+	expectedValue  float64
+}
+
+var testExternalScalerFloats = []externalScalerFloatTestData{
+	// integer fields only
+	{&pb.MetricSpec{TargetSize: 5}, &pb.MetricValue{MetricValue: 10}, "5", 10},
+	// float fields take precedence
+	{&pb.MetricSpec{TargetSize: 5, TargetSizeFloat: 0.5}, &pb.MetricValue{MetricValue: 10, MetricValueFloat: 2.25}, "500m", 2.25},
+	// float fields only
+	{&pb.MetricSpec{TargetSizeFloat: 1.5}, &pb.MetricValue{MetricValueFloat: 0.75}, "1500m", 0.75},
+}
+
+func TestExternalScalerFloatMetrics(t *testing.T) {
+	for _, testData := range testExternalScalerFloats {
+		target := getExternalMetricTarget(v2beta2.AverageValueMetricType, testData.spec)
+		if target.AverageValue.String() != testData.expectedTarget {
+			t.Errorf("Expected target %s but got %s", testData.expectedTarget, target.AverageValue.String())
+		}
+		value := getExternalMetricValue(testData.value)
+		if value != testData.expectedValue {
+			t.Errorf("Expected value %v but got %v", testData.expectedValue, value)
+		}
+	}
+}
+
 func TestExternalPushScaler_Run(t *testing.T) {
 	const serverCount = 5
 	const iterationCount = 500
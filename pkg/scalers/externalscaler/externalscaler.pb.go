@@ -28,6 +28,8 @@ type ScaledObjectRef struct {
 	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Namespace            string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	ScalerMetadata       map[string]string `protobuf:"bytes,3,rep,name=scalerMetadata,proto3" json:"scalerMetadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	AuthParams           map[string]string `protobuf:"bytes,4,rep,name=authParams,proto3" json:"authParams,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ScaleTargetRef       *ScaleTargetRef   `protobuf:"bytes,5,opt,name=scaleTargetRef,proto3" json:"scaleTargetRef,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -79,6 +81,20 @@ func (m *ScaledObjectRef) GetScalerMetadata() map[string]string {
 	return nil
 }
 
+func (m *ScaledObjectRef) GetAuthParams() map[string]string {
+	if m != nil {
+		return m.AuthParams
+	}
+	return nil
+}
+
+func (m *ScaledObjectRef) GetScaleTargetRef() *ScaleTargetRef {
+	if m != nil {
+		return m.ScaleTargetRef
+	}
+	return nil
+}
+
 type IsActiveResponse struct {
 	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -160,6 +176,7 @@ func (m *GetMetricSpecResponse) GetMetricSpecs() []*MetricSpec {
 type MetricSpec struct {
 	MetricName           string   `protobuf:"bytes,1,opt,name=metricName,proto3" json:"metricName,omitempty"`
 	TargetSize           int64    `protobuf:"varint,2,opt,name=targetSize,proto3" json:"targetSize,omitempty"`
+	TargetSizeFloat      float64  `protobuf:"fixed64,3,opt,name=targetSizeFloat,proto3" json:"targetSizeFloat,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -204,6 +221,13 @@ func (m *MetricSpec) GetTargetSize() int64 {
 	return 0
 }
 
+func (m *MetricSpec) GetTargetSizeFloat() float64 {
+	if m != nil {
+		return m.TargetSizeFloat
+	}
+	return 0
+}
+
 type GetMetricsRequest struct {
 	ScaledObjectRef      *ScaledObjectRef `protobuf:"bytes,1,opt,name=scaledObjectRef,proto3" json:"scaledObjectRef,omitempty"`
 	MetricName           string           `protobuf:"bytes,2,opt,name=metricName,proto3" json:"metricName,omitempty"`
@@ -293,6 +317,7 @@ func (m *GetMetricsResponse) GetMetricValues() []*MetricValue {
 type MetricValue struct {
 	MetricName           string   `protobuf:"bytes,1,opt,name=metricName,proto3" json:"metricName,omitempty"`
 	MetricValue          int64    `protobuf:"varint,2,opt,name=metricValue,proto3" json:"metricValue,omitempty"`
+	MetricValueFloat     float64  `protobuf:"fixed64,3,opt,name=metricValueFloat,proto3" json:"metricValueFloat,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -337,8 +362,71 @@ func (m *MetricValue) GetMetricValue() int64 {
 	return 0
 }
 
+func (m *MetricValue) GetMetricValueFloat() float64 {
+	if m != nil {
+		return m.MetricValueFloat
+	}
+	return 0
+}
+
+type ScaleTargetRef struct {
+	ApiVersion           string   `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScaleTargetRef) Reset()         { *m = ScaleTargetRef{} }
+func (m *ScaleTargetRef) String() string { return proto.CompactTextString(m) }
+func (*ScaleTargetRef) ProtoMessage()    {}
+func (*ScaleTargetRef) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3d382708546499d1, []int{7}
+}
+
+func (m *ScaleTargetRef) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScaleTargetRef.Unmarshal(m, b)
+}
+func (m *ScaleTargetRef) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScaleTargetRef.Marshal(b, m, deterministic)
+}
+func (m *ScaleTargetRef) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScaleTargetRef.Merge(m, src)
+}
+func (m *ScaleTargetRef) XXX_Size() int {
+	return xxx_messageInfo_ScaleTargetRef.Size(m)
+}
+func (m *ScaleTargetRef) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScaleTargetRef.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScaleTargetRef proto.InternalMessageInfo
+
+func (m *ScaleTargetRef) GetApiVersion() string {
+	if m != nil {
+		return m.ApiVersion
+	}
+	return ""
+}
+
+func (m *ScaleTargetRef) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ScaleTargetRef) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ScaledObjectRef)(nil), "externalscaler.ScaledObjectRef")
+	proto.RegisterMapType((map[string]string)(nil), "externalscaler.ScaledObjectRef.AuthParamsEntry")
 	proto.RegisterMapType((map[string]string)(nil), "externalscaler.ScaledObjectRef.ScalerMetadataEntry")
 	proto.RegisterType((*IsActiveResponse)(nil), "externalscaler.IsActiveResponse")
 	proto.RegisterType((*GetMetricSpecResponse)(nil), "externalscaler.GetMetricSpecResponse")
@@ -346,40 +434,48 @@ func init() {
 	proto.RegisterType((*GetMetricsRequest)(nil), "externalscaler.GetMetricsRequest")
 	proto.RegisterType((*GetMetricsResponse)(nil), "externalscaler.GetMetricsResponse")
 	proto.RegisterType((*MetricValue)(nil), "externalscaler.MetricValue")
+	proto.RegisterType((*ScaleTargetRef)(nil), "externalscaler.ScaleTargetRef")
 }
 
 func init() { proto.RegisterFile("externalscaler.proto", fileDescriptor_3d382708546499d1) }
 
 var fileDescriptor_3d382708546499d1 = []byte{
-	// 442 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xdf, 0x6b, 0xd4, 0x40,
-	0x10, 0x6e, 0x12, 0x2d, 0xed, 0x44, 0xd3, 0x73, 0xac, 0x12, 0xa2, 0x68, 0x5c, 0x10, 0x8a, 0x0f,
-	0x87, 0x5c, 0x5f, 0x44, 0x05, 0xa9, 0x50, 0xa4, 0x60, 0x3d, 0xd8, 0x70, 0x15, 0xf5, 0x69, 0x9b,
-	0x8e, 0x72, 0x9a, 0xcb, 0xc5, 0xdd, 0xbd, 0xe2, 0xf9, 0xe0, 0x3f, 0xeb, 0xab, 0x7f, 0x84, 0xe4,
-	0x77, 0xb2, 0x9c, 0xe6, 0xa5, 0x4f, 0xd9, 0x9d, 0xf9, 0xe6, 0xdb, 0x99, 0x6f, 0x3e, 0x02, 0xfb,
-	0xf4, 0x43, 0x93, 0x4c, 0x45, 0xa2, 0x62, 0x91, 0x90, 0x1c, 0x67, 0x72, 0xa9, 0x97, 0xe8, 0xf5,
-	0xa3, 0xec, 0xb7, 0x05, 0x7b, 0x51, 0x7e, 0xbc, 0x98, 0x9e, 0x7f, 0xa5, 0x58, 0x73, 0xfa, 0x8c,
-	0x08, 0xd7, 0x52, 0xb1, 0x20, 0xdf, 0x0a, 0xad, 0x83, 0x5d, 0x5e, 0x9c, 0xf1, 0x3e, 0xec, 0xe6,
-	0x5f, 0x95, 0x89, 0x98, 0x7c, 0xbb, 0x48, 0xb4, 0x01, 0xfc, 0x04, 0x5e, 0xc9, 0x77, 0x4a, 0x5a,
-	0x5c, 0x08, 0x2d, 0x7c, 0x27, 0x74, 0x0e, 0xdc, 0xc9, 0xe1, 0xd8, 0x68, 0xc2, 0x78, 0xaa, 0xbc,
-	0x37, 0x55, 0xc7, 0xa9, 0x96, 0x6b, 0x6e, 0x50, 0x05, 0x47, 0x70, 0x7b, 0x03, 0x0c, 0x47, 0xe0,
-	0x7c, 0xa3, 0x75, 0xd5, 0x64, 0x7e, 0xc4, 0x7d, 0xb8, 0x7e, 0x29, 0x92, 0x55, 0xdd, 0x5f, 0x79,
-	0x79, 0x6e, 0x3f, 0xb3, 0xd8, 0x13, 0x18, 0x9d, 0xa8, 0xa3, 0x58, 0xcf, 0x2f, 0x89, 0x93, 0xca,
-	0x96, 0xa9, 0x22, 0xbc, 0x0b, 0xdb, 0x92, 0xd4, 0x2a, 0xd1, 0x05, 0xc5, 0x0e, 0xaf, 0x6e, 0x6c,
-	0x06, 0x77, 0xde, 0x90, 0x3e, 0x25, 0x2d, 0xe7, 0x71, 0x94, 0x51, 0xdc, 0x14, 0xbc, 0x04, 0x77,
-	0xd1, 0x44, 0x95, 0x6f, 0x15, 0x13, 0x06, 0xe6, 0x84, 0x9d, 0xc2, 0x2e, 0x9c, 0xbd, 0x05, 0x68,
-	0x53, 0xf8, 0x00, 0xa0, 0x4c, 0xbe, 0x6b, 0x85, 0xee, 0x44, 0xf2, 0xbc, 0x16, 0xf2, 0x0b, 0xe9,
-	0x68, 0xfe, 0xb3, 0x9c, 0xc7, 0xe1, 0x9d, 0x08, 0xfb, 0x05, 0xb7, 0x9a, 0x26, 0x15, 0xa7, 0xef,
-	0x2b, 0x52, 0x1a, 0x4f, 0x60, 0x4f, 0xf5, 0xf5, 0x2d, 0x98, 0xdd, 0xc9, 0xc3, 0x81, 0x35, 0x70,
-	0xb3, 0xce, 0xe8, 0xcf, 0x36, 0xfb, 0x63, 0x33, 0xc0, 0xee, 0xfb, 0x95, 0x42, 0xaf, 0xe0, 0x46,
-	0x89, 0x39, 0xcb, 0x95, 0xaf, 0x25, 0xba, 0xb7, 0x59, 0xa2, 0x02, 0xc3, 0x7b, 0x05, 0x6c, 0x0a,
-	0x6e, 0x27, 0x39, 0xa8, 0x52, 0x58, 0x6f, 0xe4, 0xac, 0x59, 0xbb, 0xc3, 0xbb, 0xa1, 0xc9, 0x1f,
-	0x1b, 0xbc, 0xe3, 0xea, 0xf5, 0xd2, 0x44, 0x38, 0x85, 0x9d, 0xda, 0x0b, 0x38, 0x24, 0x4c, 0x10,
-	0x9a, 0x00, 0xd3, 0x46, 0x6c, 0x0b, 0xdf, 0x83, 0x17, 0x69, 0x49, 0x62, 0x71, 0xa5, 0xb4, 0x4f,
-	0x2d, 0xfc, 0x00, 0x37, 0x7b, 0x4e, 0x1c, 0xe6, 0x7d, 0x6c, 0x02, 0x36, 0x3a, 0x99, 0x6d, 0xe1,
-	0x0c, 0xa0, 0xdd, 0x1f, 0x3e, 0xfa, 0x67, 0x59, 0xed, 0xad, 0x80, 0xfd, 0x0f, 0x52, 0xd3, 0xbe,
-	0xc6, 0x8f, 0xa3, 0xf1, 0x8b, 0x3e, 0xf0, 0x7c, 0xbb, 0xf8, 0xf1, 0x1c, 0xfe, 0x0d, 0x00, 0x00,
-	0xff, 0xff, 0xdc, 0xf1, 0x73, 0xce, 0x90, 0x04, 0x00, 0x00,
+	// 555 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0x5d, 0x9a, 0x6d, 0xda, 0x6e, 0xa1, 0x2d, 0x97, 0x81, 0xa2, 0x82, 0x46, 0xb0, 0x84, 0x54,
+	0xed, 0xa1, 0xa0, 0xee, 0x05, 0xf1, 0x21, 0x54, 0xa4, 0x0d, 0xed, 0x61, 0x14, 0xb9, 0xb4, 0x7c,
+	0x3d, 0x79, 0xe9, 0x05, 0xc2, 0xda, 0xb4, 0xc4, 0x6e, 0xc5, 0x40, 0xe2, 0x8f, 0xf1, 0x97, 0xf8,
+	0x11, 0x28, 0xdf, 0x8e, 0x29, 0x44, 0x48, 0x3c, 0xc5, 0xbe, 0xf7, 0x9c, 0xe3, 0xeb, 0xe3, 0x6b,
+	0x07, 0xf6, 0xe8, 0x8b, 0xa2, 0x30, 0x10, 0x53, 0xe9, 0x89, 0x29, 0x85, 0xdd, 0x45, 0x38, 0x57,
+	0x73, 0x6c, 0x94, 0xa3, 0xec, 0x87, 0x0d, 0xcd, 0x61, 0x34, 0x9c, 0x0c, 0xce, 0x3e, 0x91, 0xa7,
+	0x38, 0xbd, 0x47, 0x84, 0xcd, 0x40, 0xcc, 0xc8, 0xb1, 0x5c, 0xab, 0xb3, 0xcb, 0xe3, 0x31, 0xde,
+	0x84, 0xdd, 0xe8, 0x2b, 0x17, 0xc2, 0x23, 0xa7, 0x16, 0x27, 0x8a, 0x00, 0xbe, 0x83, 0x46, 0xa2,
+	0x77, 0x4a, 0x4a, 0x4c, 0x84, 0x12, 0x8e, 0xed, 0xda, 0x9d, 0x7a, 0xef, 0xb0, 0x6b, 0x14, 0x61,
+	0x2c, 0xd5, 0x1d, 0x96, 0x58, 0x47, 0x81, 0x0a, 0x2f, 0xb8, 0x21, 0x85, 0x03, 0x00, 0xb1, 0x54,
+	0x1f, 0x5f, 0x88, 0x50, 0xcc, 0xa4, 0xb3, 0x19, 0x0b, 0xdf, 0xad, 0x12, 0xee, 0xe7, 0x8c, 0x44,
+	0x54, 0x93, 0xc0, 0xe3, 0xb4, 0xda, 0x97, 0x22, 0xfc, 0x40, 0x11, 0xda, 0xd9, 0x72, 0xad, 0x4e,
+	0xbd, 0xb7, 0xbf, 0x56, 0x34, 0x47, 0x71, 0x83, 0xd5, 0xee, 0xc3, 0xd5, 0x35, 0xf5, 0x63, 0x0b,
+	0xec, 0x73, 0xba, 0x48, 0xdd, 0x8b, 0x86, 0xb8, 0x07, 0x5b, 0x2b, 0x31, 0x5d, 0x66, 0xc6, 0x25,
+	0x93, 0x07, 0xb5, 0xfb, 0x56, 0xfb, 0x31, 0x34, 0x8d, 0x4a, 0xff, 0x85, 0xce, 0x0e, 0xa0, 0x75,
+	0x22, 0xfb, 0x9e, 0xf2, 0x57, 0xc4, 0x49, 0x2e, 0xe6, 0x81, 0x24, 0xbc, 0x0e, 0xdb, 0x21, 0xc9,
+	0xe5, 0x54, 0xc5, 0x12, 0x3b, 0x3c, 0x9d, 0xb1, 0x11, 0x5c, 0x7b, 0x46, 0xea, 0x94, 0x54, 0xe8,
+	0x7b, 0xc3, 0x05, 0x79, 0x39, 0xe1, 0x11, 0xd4, 0x67, 0x79, 0x54, 0x3a, 0x56, 0x6c, 0x70, 0xdb,
+	0xf4, 0x42, 0x23, 0xea, 0x70, 0xb6, 0x02, 0x28, 0x52, 0xb8, 0x0f, 0x90, 0x24, 0x9f, 0x17, 0x0d,
+	0xa4, 0x45, 0xa2, 0xbc, 0x8a, 0xfd, 0x1b, 0xfa, 0x5f, 0x93, 0xfd, 0xd8, 0x5c, 0x8b, 0x60, 0x07,
+	0x9a, 0xc5, 0xec, 0x78, 0x3a, 0x17, 0xca, 0xb1, 0x5d, 0xab, 0x63, 0x71, 0x33, 0xcc, 0xbe, 0xc3,
+	0x95, 0x7c, 0x3b, 0x92, 0xd3, 0xe7, 0x25, 0x49, 0x85, 0x27, 0xd0, 0x94, 0xe5, 0x46, 0x88, 0x6b,
+	0xa8, 0xf7, 0x6e, 0x55, 0xf4, 0x0b, 0x37, 0x79, 0xc6, 0x4e, 0x6a, 0xe6, 0x4e, 0xd8, 0x08, 0x50,
+	0x5f, 0x3f, 0xf5, 0xf2, 0x09, 0x5c, 0x4a, 0x30, 0xe3, 0xe8, 0x8c, 0x32, 0x33, 0x6f, 0xac, 0x37,
+	0x33, 0xc6, 0xf0, 0x12, 0x81, 0x7d, 0x83, 0xba, 0x96, 0xac, 0xf4, 0xd3, 0xcd, 0xce, 0x6e, 0x9c,
+	0x37, 0x88, 0xcd, 0xf5, 0x10, 0x1e, 0x40, 0x4b, 0x9b, 0xea, 0x96, 0xfe, 0x16, 0x67, 0xaf, 0xa1,
+	0x51, 0x6e, 0xf9, 0x68, 0x7d, 0xb1, 0xf0, 0xc7, 0x14, 0x4a, 0x7f, 0x1e, 0x64, 0xeb, 0x17, 0x91,
+	0xe8, 0xa9, 0x38, 0xf7, 0x83, 0x49, 0xea, 0x4f, 0x3c, 0xce, 0x9f, 0x0f, 0xbb, 0x78, 0x3e, 0x7a,
+	0x3f, 0x6b, 0xd0, 0x38, 0x4a, 0x3d, 0x48, 0xee, 0x0c, 0x0e, 0x60, 0x27, 0xeb, 0x5d, 0xac, 0x3a,
+	0x9e, 0xb6, 0x6b, 0x02, 0xcc, 0xb6, 0x67, 0x1b, 0xf8, 0x0a, 0x1a, 0x43, 0x15, 0x92, 0x98, 0xfd,
+	0x57, 0xd9, 0x7b, 0x16, 0xbe, 0x81, 0xcb, 0xa5, 0x9b, 0x53, 0xad, 0x7b, 0xc7, 0x04, 0xac, 0xbd,
+	0x79, 0x6c, 0x03, 0x47, 0x00, 0x79, 0x4a, 0xe2, 0xed, 0x3f, 0xd2, 0xb2, 0x0e, 0x6f, 0xb3, 0xbf,
+	0x41, 0x32, 0xd9, 0xa7, 0xf8, 0xb6, 0xd5, 0x7d, 0x58, 0x06, 0x9e, 0x6d, 0xc7, 0x3f, 0x80, 0xc3,
+	0x5f, 0x03, 0x00, 0x20, 0x25, 0x61, 0x34, 0x18, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,57 @@
+package gcp
+
+import (
+	"fmt"
+
+	"google.golang.org/api/option"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// AuthorizationMetadata holds the service account credentials used to authenticate against GCP
+type AuthorizationMetadata struct {
+	GoogleApplicationCredentials     string
+	GoogleApplicationCredentialsFile string
+	PodIdentityOwner                 bool
+	PodIdentityProviderEnabled       bool
+}
+
+// GetGCPAuthorization parses the authorization of a GCP scaler from the auth params, trigger metadata, resolved env and pod identity
+func GetGCPAuthorization(authParams, metadata, resolvedEnv map[string]string, podIdentity kedav1alpha1.AuthPodIdentity) (*AuthorizationMetadata, error) {
+	meta := AuthorizationMetadata{}
+	if metadata["identityOwner"] == "operator" {
+		meta.PodIdentityOwner = false
+	} else if metadata["identityOwner"] == "" || metadata["identityOwner"] == "pod" {
+		meta.PodIdentityOwner = true
+		switch {
+		case podIdentity.Provider == kedav1alpha1.PodIdentityProviderGCP:
+			// do nothing, rely on underneath metadata google
+			meta.PodIdentityProviderEnabled = true
+		case authParams["GoogleApplicationCredentials"] != "":
+			meta.GoogleApplicationCredentials = authParams["GoogleApplicationCredentials"]
+		default:
+			switch {
+			case metadata["credentialsFromEnv"] != "":
+				meta.GoogleApplicationCredentials = resolvedEnv[metadata["credentialsFromEnv"]]
+			case metadata["credentialsFromEnvFile"] != "":
+				meta.GoogleApplicationCredentialsFile = resolvedEnv[metadata["credentialsFromEnvFile"]]
+			default:
+				return nil, fmt.Errorf("GoogleApplicationCredentials not found")
+			}
+		}
+	}
+	return &meta, nil
+}
+
+// GetClientOptions returns the options of a GCP client authenticating with the credentials,
+// no option is returned to rely on the default credentials of the pod identity or the operator
+func (a *AuthorizationMetadata) GetClientOptions() []option.ClientOption {
+	switch {
+	case !a.PodIdentityOwner, a.PodIdentityProviderEnabled:
+		return nil
+	case a.GoogleApplicationCredentialsFile != "":
+		return []option.ClientOption{option.WithCredentialsFile(a.GoogleApplicationCredentialsFile)}
+	default:
+		return []option.ClientOption{option.WithCredentialsJSON([]byte(a.GoogleApplicationCredentials))}
+	}
+}
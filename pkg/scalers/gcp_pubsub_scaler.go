@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -42,7 +43,7 @@ type pubsubMetadata struct {
 	activationValue int64
 
 	subscriptionName string
-	gcpAuthorization *gcpAuthorizationMetadata
+	gcpAuthorization *gcp.AuthorizationMetadata
 	scalerIndex      int
 }
 
@@ -127,7 +128,7 @@ func parsePubSubMetadata(config *ScalerConfig, logger logr.Logger) (*pubsubMetad
 		meta.activationValue = activationValue
 	}
 
-	auth, err := getGcpAuthorization(config, config.ResolvedEnv)
+	auth, err := gcp.GetGCPAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv, config.PodIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +217,7 @@ func (s *pubsubScaler) GetMetrics(ctx context.Context, metricName string, metric
 func (s *pubsubScaler) setStackdriverClient(ctx context.Context) error {
 	var client *StackDriverClient
 	var err error
-	if s.metadata.gcpAuthorization.podIdentityProviderEnabled {
+	if s.metadata.gcpAuthorization.PodIdentityProviderEnabled {
 		client, err = NewStackDriverClientPodIdentity(ctx)
 	} else {
 		client, err = NewStackDriverClient(ctx, s.metadata.gcpAuthorization.GoogleApplicationCredentials)
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -32,7 +33,7 @@ type stackdriverMetadata struct {
 	activationTargetValue int64
 	metricName            string
 
-	gcpAuthorization *gcpAuthorizationMetadata
+	gcpAuthorization *gcp.AuthorizationMetadata
 	aggregation      *monitoringpb.Aggregation
 }
 
@@ -110,7 +111,7 @@ func parseStackdriverMetadata(config *ScalerConfig, logger logr.Logger) (*stackd
 		meta.activationTargetValue = activationTargetValue
 	}
 
-	auth, err := getGcpAuthorization(config, config.ResolvedEnv)
+	auth, err := gcp.GetGCPAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv, config.PodIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -146,10 +147,10 @@ func parseAggregation(config *ScalerConfig, logger logr.Logger) (*monitoringpb.A
 	return nil, nil
 }
 
-func initializeStackdriverClient(ctx context.Context, gcpAuthorization *gcpAuthorizationMetadata, logger logr.Logger) (*StackDriverClient, error) {
+func initializeStackdriverClient(ctx context.Context, gcpAuthorization *gcp.AuthorizationMetadata, logger logr.Logger) (*StackDriverClient, error) {
 	var client *StackDriverClient
 	var err error
-	if gcpAuthorization.podIdentityProviderEnabled {
+	if gcpAuthorization.PodIdentityProviderEnabled {
 		client, err = NewStackDriverClientPodIdentity(ctx)
 	} else {
 		client, err = NewStackDriverClient(ctx, gcpAuthorization.GoogleApplicationCredentials)
@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -34,7 +35,7 @@ type gcsScaler struct {
 
 type gcsMetadata struct {
 	bucketName                  string
-	gcpAuthorization            *gcpAuthorizationMetadata
+	gcpAuthorization            *gcp.AuthorizationMetadata
 	maxBucketItemsToScan        int64
 	metricName                  string
 	targetObjectCount           int64
@@ -60,7 +61,7 @@ func NewGcsScaler(config *ScalerConfig) (Scaler, error) {
 	var client *storage.Client
 
 	switch {
-	case meta.gcpAuthorization.podIdentityProviderEnabled:
+	case meta.gcpAuthorization.PodIdentityProviderEnabled:
 		client, err = storage.NewClient(ctx)
 	case meta.gcpAuthorization.GoogleApplicationCredentialsFile != "":
 		client, err = storage.NewClient(
@@ -136,7 +137,7 @@ func parseGcsMetadata(config *ScalerConfig, logger logr.Logger) (*gcsMetadata, e
 		meta.maxBucketItemsToScan = maxBucketItemsToScan
 	}
 
-	auth, err := getGcpAuthorization(config, config.ResolvedEnv)
+	auth, err := gcp.GetGCPAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv, config.PodIdentity)
 	if err != nil {
 		return nil, err
 	}
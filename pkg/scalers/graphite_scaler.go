@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -47,7 +48,11 @@ type graphiteMetadata struct {
 	enableBasicAuth bool
 	username        string
 	password        string // +optional
-	scalerIndex     int
+
+	// oauth2 client credentials
+	oauth *authentication.AuthMeta
+
+	scalerIndex int
 }
 
 type grapQueryResult []struct {
@@ -69,6 +74,9 @@ func NewGraphiteScaler(config *ScalerConfig) (Scaler, error) {
 	}
 
 	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	if meta.oauth != nil {
+		httpClient.Transport = authentication.NewOAuth2RoundTripper(meta.oauth, httpClient.Transport)
+	}
 
 	return &graphiteScaler{
 		metricType: metricType,
@@ -132,8 +140,17 @@ func parseGraphiteMetadata(config *ScalerConfig) (*graphiteMetadata, error) {
 	if !ok {
 		return &meta, nil
 	}
-	if val != "basic" {
-		return nil, fmt.Errorf("authMode must be 'basic'")
+	switch authentication.Type(val) {
+	case authentication.BasicAuthType:
+	case authentication.OAuthType:
+		oauth, err := authentication.GetOAuthConfigs(config.TriggerMetadata, config.AuthParams)
+		if err != nil {
+			return nil, err
+		}
+		meta.oauth = oauth
+		return &meta, nil
+	default:
+		return nil, fmt.Errorf("authMode must be 'basic' or 'oauth'")
 	}
 
 	if len(config.AuthParams["username"]) == 0 {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
@@ -56,6 +57,10 @@ type metricsAPIScalerMetadata struct {
 	// bearer
 	enableBearerAuth bool
 	bearerToken      string
+	bearerTokenFile  *authentication.TokenFile // +optional
+
+	// oauth2 client credentials
+	oauth *authentication.AuthMeta
 
 	scalerIndex int
 }
@@ -84,7 +89,11 @@ func NewMetricsAPIScaler(config *ScalerConfig) (Scaler, error) {
 			return nil, err
 		}
 
-		httpClient.Transport = &http.Transport{TLSClientConfig: config}
+		httpClient.Transport = otelhttp.NewTransport(&http.Transport{TLSClientConfig: config})
+	}
+
+	if meta.oauth != nil {
+		httpClient.Transport = authentication.NewOAuth2RoundTripper(meta.oauth, httpClient.Transport)
 	}
 
 	return &metricsAPIScaler{
@@ -188,6 +197,21 @@ func parseMetricsAPIMetadata(config *ScalerConfig) (*metricsAPIScalerMetadata, e
 
 		meta.bearerToken = config.AuthParams["token"]
 		meta.enableBearerAuth = true
+	case authentication.OAuthType:
+		oauth, err := authentication.GetOAuthConfigs(config.TriggerMetadata, config.AuthParams)
+		if err != nil {
+			return nil, err
+		}
+
+		meta.oauth = oauth
+	case authentication.BearerTokenFileAuthType:
+		tokenFile, err := authentication.GetBearerTokenFile(config.AuthParams)
+		if err != nil {
+			return nil, err
+		}
+
+		meta.bearerTokenFile = tokenFile
+		meta.enableBearerAuth = true
 	default:
 		return nil, fmt.Errorf("err incorrect value for authMode is given: %s", authMode)
 	}
@@ -330,7 +354,14 @@ func getMetricAPIServerRequest(ctx context.Context, meta *metricsAPIScalerMetada
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", meta.bearerToken))
+		bearerToken := meta.bearerToken
+		if meta.bearerTokenFile != nil {
+			bearerToken, err = meta.bearerTokenFile.Token()
+			if err != nil {
+				return nil, err
+			}
+		}
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", bearerToken))
 	default:
 		req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
 		if err != nil {
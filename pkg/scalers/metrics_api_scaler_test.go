@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
 )
 
 type metricsAPIMetadataTestData struct {
@@ -63,6 +67,10 @@ var testMetricsAPIAuthMetadata = []metricAPIAuthMetadataTestData{
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "basic"}, map[string]string{"username": "user", "password": "pass"}, false},
 	// fail basicAuth with no username
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "basic"}, map[string]string{}, true},
+	// success oauth
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "oauth", "oauthTokenURI": "http://dummy:1230/token", "scopes": "read"}, map[string]string{"clientID": "id", "clientSecret": "secret"}, false},
+	// fail oauth without client secret
+	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "oauth", "oauthTokenURI": "http://dummy:1230/token"}, map[string]string{"clientID": "id"}, true},
 	// success bearerAuth default
 	{map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "authMode": "bearer"}, map[string]string{"token": "bearerTokenValue"}, false},
 	// fail bearerAuth without token
@@ -219,6 +227,48 @@ func TestBearerAuth(t *testing.T) {
 	}
 }
 
+func TestBearerTokenFileAuth(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(authentication.BearerTokenFileDirEnv, dir)
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("projected-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer projected-token" {
+			t.Errorf("Authorization header malformed")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"components":[{"id": "82328e93e", "tasks": 32, "str": "64", "k":"1k","wrong":"NaN"}],"count":2.43}`))
+	}))
+
+	metadata := map[string]string{
+		"url":           apiStub.URL,
+		"valueLocation": "components.0.tasks",
+		"targetValue":   "1",
+		"authMode":      "bearerTokenFile",
+	}
+
+	s, err := NewMetricsAPIScaler(
+		&ScalerConfig{
+			ResolvedEnv:       map[string]string{},
+			TriggerMetadata:   metadata,
+			AuthParams:        map[string]string{"bearerTokenFile": tokenFile},
+			GlobalHTTPTimeout: 3000 * time.Millisecond,
+		},
+	)
+	if err != nil {
+		t.Fatalf("Error creating the Scaler: %s", err)
+	}
+
+	_, err = s.GetMetrics(context.TODO(), "test-metric", nil)
+	if err != nil {
+		t.Errorf("Error getting the metric")
+	}
+}
+
 type MockHTTPRoundTripper struct {
 	mock.Mock
 }
@@ -98,6 +98,10 @@ func NewPrometheusScaler(config *ScalerConfig) (Scaler, error) {
 		}
 	}
 
+	if meta.prometheusAuth != nil && meta.prometheusAuth.EnableOAuth {
+		httpClient.Transport = authentication.NewOAuth2RoundTripper(meta.prometheusAuth, httpClient.Transport)
+	}
+
 	return &prometheusScaler{
 		metricType: metricType,
 		metadata:   meta,
@@ -221,7 +225,11 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 	}
 
 	if s.metadata.prometheusAuth != nil && s.metadata.prometheusAuth.EnableBearerAuth {
-		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", s.metadata.prometheusAuth.BearerToken))
+		bearerToken, err := s.metadata.prometheusAuth.GetBearerToken()
+		if err != nil {
+			return -1, err
+		}
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", bearerToken))
 	} else if s.metadata.prometheusAuth != nil && s.metadata.prometheusAuth.EnableBasicAuth {
 		req.SetBasicAuth(s.metadata.prometheusAuth.Username, s.metadata.prometheusAuth.Password)
 	}
@@ -299,3 +307,15 @@ func (s *prometheusScaler) GetMetrics(ctx context.Context, metricName string, _
 
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
+
+func (s *prometheusScaler) GetMetricsAndActivity(ctx context.Context, metricName string, _ labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error) {
+	val, err := s.ExecutePromQuery(ctx)
+	if err != nil {
+		s.logger.Error(err, "error executing prometheus query")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, val)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), val > s.metadata.activationThreshold, nil
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
 )
 
 type parsePrometheusMetadataTestData struct {
@@ -283,3 +287,41 @@ func TestPrometheusScalerCortexHeader(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestPrometheusScalerBearerTokenFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(authentication.BearerTokenFileDirEnv, dir)
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("projected-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "Bearer projected-token", request.Header.Get("Authorization"))
+		_, _ = writer.Write([]byte(`{"data":{"result":[{"value": ["1", "2"]}]}}`))
+	}))
+	defer server.Close()
+
+	meta, err := parsePrometheusMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": server.URL, "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "bearerTokenFile"},
+		AuthParams:      map[string]string{"bearerTokenFile": tokenFile},
+	})
+	assert.NoError(t, err)
+
+	scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient}
+	value, err := scaler.ExecutePromQuery(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), value)
+
+	_, err = parsePrometheusMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": server.URL, "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "bearerTokenFile"},
+		AuthParams:      map[string]string{"bearerTokenFile": filepath.Join(dir, "missing")},
+	})
+	assert.Error(t, err)
+
+	_, err = parsePrometheusMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": server.URL, "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "bearerTokenFile", "bearerTokenFile": tokenFile},
+		AuthParams:      map[string]string{},
+	})
+	assert.Error(t, err, "bearerTokenFile is only accepted from the TriggerAuthentication")
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
@@ -109,7 +110,7 @@ func NewPulsarScaler(config *ScalerConfig) (Scaler, error) {
 		if err != nil {
 			return nil, err
 		}
-		client.Transport = &http.Transport{TLSClientConfig: config}
+		client.Transport = otelhttp.NewTransport(&http.Transport{TLSClientConfig: config})
 	}
 
 	return &pulsarScaler{
@@ -56,6 +56,15 @@ type Scaler interface {
 	Close(ctx context.Context) error
 }
 
+// MetricsAndActivityScaler interface is implemented by scalers determining their activity from the metric value,
+// so the metrics and the activity are returned from a single query
+type MetricsAndActivityScaler interface {
+	Scaler
+
+	// GetMetricsAndActivity returns the metric values for a metric Name and criteria matching the selector, and whether the scaler is active
+	GetMetricsAndActivity(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error)
+}
+
 // PushScaler interface
 type PushScaler interface {
 	Scaler
@@ -75,6 +84,9 @@ type ScalerConfig struct {
 	// ScalableObjectType specifies whether this Scaler is owned by ScaledObject or ScaledJob
 	ScalableObjectType string
 
+	// ScaleTargetRef specifies the scale target of the ScaledObject that owns this scaler, nil for ScaledJob
+	ScaleTargetRef *kedav1alpha1.ScaleTarget
+
 	// The timeout to be used on all HTTP requests from the controller
 	GlobalHTTPTimeout time.Duration
 
@@ -87,12 +99,27 @@ type ScalerConfig struct {
 	// AuthParams
 	AuthParams map[string]string
 
+	// AuthRefreshTime is the time when AuthParams have to be resolved again before the leases of the Vault secrets expire, zero if they don't expire
+	AuthRefreshTime time.Time
+
+	// RevokeAuthLeases revokes the leases of the Vault dynamic secrets in AuthParams once the scaler is closed, nil if there are none
+	RevokeAuthLeases func(ctx context.Context)
+
+	// AuthenticationRef is the reference to the TriggerAuthentication or ClusterTriggerAuthentication of the trigger
+	AuthenticationRef *kedav1alpha1.ScaledObjectAuthRef
+
 	// PodIdentity
 	PodIdentity kedav1alpha1.AuthPodIdentity
 
 	// ScalerIndex
 	ScalerIndex int
 
+	// TriggerType specifies the type of the trigger of this scaler, eg. prometheus
+	TriggerType string
+
+	// TriggerName is the optional name of the trigger of this scaler
+	TriggerName string
+
 	// MetricType
 	MetricType v2beta2.MetricTargetType
 }
@@ -193,3 +220,106 @@ func GenerateMetricInMili(metricName string, value float64) external_metrics.Ext
 		Timestamp:  metav1.Now(),
 	}
 }
+
+// metadataParsers parse the metadata of the scalers which connect to or authenticate with their backend when they are created
+var metadataParsers = map[string]func(config *ScalerConfig) error{
+	"cassandra": func(config *ScalerConfig) error {
+		_, err := parseCassandraMetadata(config)
+		return err
+	},
+	"datadog": func(config *ScalerConfig) error {
+		_, err := parseDatadogMetadata(config, logr.Discard())
+		return err
+	},
+	"gcp-pubsub": func(config *ScalerConfig) error {
+		_, err := parsePubSubMetadata(config, logr.Discard())
+		return err
+	},
+	"gcp-stackdriver": func(config *ScalerConfig) error {
+		_, err := parseStackdriverMetadata(config, logr.Discard())
+		return err
+	},
+	"gcp-storage": func(config *ScalerConfig) error {
+		_, err := parseGcsMetadata(config, logr.Discard())
+		return err
+	},
+	"kafka": func(config *ScalerConfig) error {
+		_, err := parseKafkaMetadata(config, logr.Discard())
+		return err
+	},
+	"mongodb": func(config *ScalerConfig) error {
+		_, _, err := parseMongoDBMetadata(config)
+		return err
+	},
+	"mssql": func(config *ScalerConfig) error {
+		_, err := parseMSSQLMetadata(config)
+		return err
+	},
+	"mysql": func(config *ScalerConfig) error {
+		_, err := parseMySQLMetadata(config)
+		return err
+	},
+	"openstack-metric": func(config *ScalerConfig) error {
+		if _, err := parseOpenstackMetricMetadata(config, logr.Discard()); err != nil {
+			return err
+		}
+		_, err := parseOpenstackMetricAuthenticationMetadata(config)
+		return err
+	},
+	"openstack-swift": func(config *ScalerConfig) error {
+		if _, err := parseOpenstackSwiftMetadata(config); err != nil {
+			return err
+		}
+		_, err := parseOpenstackSwiftAuthenticationMetadata(config)
+		return err
+	},
+	"postgresql": func(config *ScalerConfig) error {
+		_, err := parsePostgreSQLMetadata(config)
+		return err
+	},
+	"predictkube": func(config *ScalerConfig) error {
+		_, err := parsePredictKubeMetadata(config)
+		return err
+	},
+	"rabbitmq": func(config *ScalerConfig) error {
+		_, err := parseRabbitMQMetadata(config)
+		return err
+	},
+	"redis": func(config *ScalerConfig) error {
+		_, err := parseRedisMetadata(config, parseRedisAddress)
+		return err
+	},
+	"redis-cluster": func(config *ScalerConfig) error {
+		_, err := parseRedisMetadata(config, parseRedisClusterAddress)
+		return err
+	},
+	"redis-sentinel": func(config *ScalerConfig) error {
+		_, err := parseRedisMetadata(config, parseRedisSentinelAddress)
+		return err
+	},
+	"redis-streams": func(config *ScalerConfig) error {
+		_, err := parseRedisStreamsMetadata(config, parseRedisAddress)
+		return err
+	},
+	"redis-cluster-streams": func(config *ScalerConfig) error {
+		_, err := parseRedisStreamsMetadata(config, parseRedisClusterAddress)
+		return err
+	},
+	"redis-sentinel-streams": func(config *ScalerConfig) error {
+		_, err := parseRedisStreamsMetadata(config, parseRedisSentinelAddress)
+		return err
+	},
+}
+
+// ValidateMetadata validates the metadata of the scaler of the trigger type without creating the scaler, for the scalers which
+// connect to their backend when they are created. It returns false if the scaler has to be created to validate its metadata
+func ValidateMetadata(triggerType string, config *ScalerConfig) (bool, error) {
+	parse, ok := metadataParsers[triggerType]
+	if !ok {
+		return false, nil
+	}
+	if _, err := GetMetricTargetType(config); err != nil {
+		return true, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+	return true, parse(config)
+}
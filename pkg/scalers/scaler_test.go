@@ -122,3 +122,79 @@ func TestRemoveIndexFromMetricName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateMetadata(t *testing.T) {
+	cases := []struct {
+		name          string
+		triggerType   string
+		config        *ScalerConfig
+		wantValidated bool
+		wantErr       bool
+	}{
+		{
+			name:          "valid metadata of a scaler connecting to its backend",
+			triggerType:   "redis",
+			config:        &ScalerConfig{TriggerMetadata: map[string]string{"listName": "mylist", "address": "redis:6379"}},
+			wantValidated: true,
+		},
+		{
+			name:          "invalid metadata of a scaler connecting to its backend",
+			triggerType:   "redis",
+			config:        &ScalerConfig{TriggerMetadata: map[string]string{"address": "redis:6379"}},
+			wantValidated: true,
+			wantErr:       true,
+		},
+		{
+			name:          "invalid metric type of a scaler connecting to its backend",
+			triggerType:   "redis",
+			config:        &ScalerConfig{TriggerMetadata: map[string]string{"listName": "mylist", "address": "redis:6379"}, MetricType: v2beta2.UtilizationMetricType},
+			wantValidated: true,
+			wantErr:       true,
+		},
+		{
+			name:          "valid metadata of a scaler creating a client of its backend",
+			triggerType:   "gcp-storage",
+			config:        &ScalerConfig{TriggerMetadata: map[string]string{"bucketName": "test-bucket"}, AuthParams: map[string]string{"GoogleApplicationCredentials": "Creds"}},
+			wantValidated: true,
+		},
+		{
+			name:          "valid metadata of a scaler validating its authentication with its backend",
+			triggerType:   "datadog",
+			config:        &ScalerConfig{TriggerMetadata: map[string]string{"query": "sum:trace.redis.command.hits{env:none,service:redis}.as_count()", "queryValue": "7"}, AuthParams: map[string]string{"apiKey": "apiKey", "appKey": "appKey", "datadogSite": "datadogSite"}},
+			wantValidated: true,
+		},
+		{
+			name:          "invalid metadata of a scaler validating its authentication with its backend",
+			triggerType:   "datadog",
+			config:        &ScalerConfig{TriggerMetadata: map[string]string{"queryValue": "7"}, AuthParams: map[string]string{"apiKey": "apiKey", "appKey": "appKey", "datadogSite": "datadogSite"}},
+			wantValidated: true,
+			wantErr:       true,
+		},
+		{
+			name:          "invalid authentication of a scaler authenticating with its backend",
+			triggerType:   "openstack-swift",
+			config:        &ScalerConfig{TriggerMetadata: map[string]string{"swiftURL": "http://swift:8080/v1/AUTH_project", "containerName": "my-container"}, AuthParams: map[string]string{"authURL": "http://keystone:5000/v3/"}},
+			wantValidated: true,
+			wantErr:       true,
+		},
+		{
+			name:          "scaler not connecting to its backend",
+			triggerType:   "cron",
+			config:        &ScalerConfig{TriggerMetadata: map[string]string{}},
+			wantValidated: false,
+		},
+	}
+
+	for _, testCase := range cases {
+		c := testCase
+		t.Run(c.name, func(t *testing.T) {
+			validated, err := ValidateMetadata(c.triggerType, c.config)
+			assert.Equal(t, c.wantValidated, validated)
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
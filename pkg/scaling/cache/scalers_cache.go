@@ -20,8 +20,15 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 	"k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -31,7 +38,11 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
+	"github.com/kedacore/keda/v2/pkg/metricscache"
 	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
 type ScalersCache struct {
@@ -39,14 +50,77 @@ type ScalersCache struct {
 	Scalers    []ScalerBuilder
 	Logger     logr.Logger
 	Recorder   record.EventRecorder
+
+	// mutex guards the access to Scalers, it is only held while they are read or replaced and never
+	// while the scalers are used, so a slow scaler doesn't block the other callers of the cache
+	mutex sync.RWMutex
+
+	// refreshGroup keys the refreshes by the id of the scaler, so a scaler is only built once by concurrent refreshes
+	refreshGroup singleflight.Group
 }
 
 type ScalerBuilder struct {
-	Scaler  scalers.Scaler
-	Factory func() (scalers.Scaler, error)
+	Scaler       scalers.Scaler
+	ScalerConfig scalers.ScalerConfig
+	Factory      func() (scalers.Scaler, *scalers.ScalerConfig, error)
+
+	// refreshes counts the refreshes of the scaler, so a scaler refreshed concurrently is only replaced once
+	refreshes int
+
+	// users counts the callers using the scaler, it's created once the scaler is used for the first time
+	users *scalerUsers
+}
+
+// scalerUsers counts the callers using a scaler, so a scaler replaced or removed from the cache
+// is only closed once the last caller using it released it
+type scalerUsers struct {
+	mutex   sync.Mutex
+	count   int
+	retired bool
+}
+
+// Close closes the scaler and revokes the leases of the Vault dynamic secrets it was built with
+func (b *ScalerBuilder) Close(ctx context.Context) error {
+	err := b.Scaler.Close(ctx)
+	if b.ScalerConfig.RevokeAuthLeases != nil {
+		b.ScalerConfig.RevokeAuthLeases(ctx)
+	}
+	return err
+}
+
+// acquire counts the caller as a user of the scaler, it's called with the lock of the cache held
+func (b *ScalerBuilder) acquire() {
+	if b.users == nil {
+		b.users = &scalerUsers{}
+	}
+	b.users.mutex.Lock()
+	b.users.count++
+	b.users.mutex.Unlock()
+}
+
+// release returns whether the scaler has to be closed as the caller was its last user and it was retired
+func (b *ScalerBuilder) release() bool {
+	b.users.mutex.Lock()
+	defer b.users.mutex.Unlock()
+	b.users.count--
+	return b.users.retired && b.users.count == 0
+}
+
+// retire returns whether the scaler removed from the cache has to be closed as it has no users
+func (b *ScalerBuilder) retire() bool {
+	if b.users == nil {
+		return true
+	}
+	b.users.mutex.Lock()
+	defer b.users.mutex.Unlock()
+	b.users.retired = true
+	return b.users.count == 0
 }
 
 func (c *ScalersCache) GetScalers() []scalers.Scaler {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
 	result := make([]scalers.Scaler, 0, len(c.Scalers))
 	for _, s := range c.Scalers {
 		result = append(result, s.Scaler)
@@ -54,7 +128,74 @@ func (c *ScalersCache) GetScalers() []scalers.Scaler {
 	return result
 }
 
+// GetScalerBuilders returns the scalers with the configs they were built with
+func (c *ScalersCache) GetScalerBuilders() []ScalerBuilder {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.Scalers == nil {
+		return nil
+	}
+	return append([]ScalerBuilder{}, c.Scalers...)
+}
+
+// acquireScalerBuilders returns the scalers to be used by the caller, which has to release them with releaseScalerBuilders
+// once done, so they aren't closed while they are used. The lock is held exclusively as the users of the scalers are created lazily
+func (c *ScalersCache) acquireScalerBuilders() []ScalerBuilder {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.Scalers == nil {
+		return nil
+	}
+	for i := range c.Scalers {
+		c.Scalers[i].acquire()
+	}
+	return append([]ScalerBuilder{}, c.Scalers...)
+}
+
+// acquireScalerBuilder returns the scaler with the id to be used by the caller, which has to release it with releaseScalerBuilder once done
+func (c *ScalersCache) acquireScalerBuilder(id int) (ScalerBuilder, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if id < 0 || id >= len(c.Scalers) {
+		return ScalerBuilder{}, fmt.Errorf("scaler with id %d not found. Len = %d", id, len(c.Scalers))
+	}
+	c.Scalers[id].acquire()
+	return c.Scalers[id], nil
+}
+
+func (c *ScalersCache) releaseScalerBuilders(ctx context.Context, sbs []ScalerBuilder) {
+	for _, sb := range sbs {
+		c.releaseScalerBuilder(ctx, sb)
+	}
+}
+
+// releaseScalerBuilder releases the scaler acquired by the caller, the scaler is closed if it was retired meanwhile and the caller was its last user
+func (c *ScalersCache) releaseScalerBuilder(ctx context.Context, sb ScalerBuilder) {
+	if sb.release() {
+		c.closeScalerBuilder(ctx, sb)
+	}
+}
+
+// retireScalerBuilder closes the scaler removed from the cache, or leaves it to its last user if it's still used
+func (c *ScalersCache) retireScalerBuilder(ctx context.Context, sb ScalerBuilder) {
+	if sb.retire() {
+		c.closeScalerBuilder(ctx, sb)
+	}
+}
+
+func (c *ScalersCache) closeScalerBuilder(ctx context.Context, sb ScalerBuilder) {
+	if err := sb.Close(ctx); err != nil {
+		c.Logger.Error(err, "error closing scaler", "scaler", sb)
+	}
+}
+
 func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
 	var result []scalers.PushScaler
 	for _, s := range c.Scalers {
 		if ps, ok := s.Scaler.(scalers.PushScaler); ok {
@@ -64,39 +205,90 @@ func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
 	return result
 }
 
-func (c *ScalersCache) GetMetricsForScaler(ctx context.Context, id int, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	if id < 0 || id >= len(c.Scalers) {
-		return nil, fmt.Errorf("scaler with id %d not found. Len = %d", id, len(c.Scalers))
+func (c *ScalersCache) GetMetricsForScaler(ctx context.Context, id int, metricName string, metricSelector labels.Selector) (metrics []external_metrics.ExternalMetricValue, err error) {
+	sb, err := c.acquireScalerBuilder(id)
+	if err != nil {
+		return nil, err
 	}
-	m, err := c.Scalers[id].Scaler.GetMetrics(ctx, metricName, metricSelector)
+	defer func() { c.releaseScalerBuilder(ctx, sb) }()
+
+	ctx, span := tracing.StartSpan(ctx, "ScalersCache.GetMetricsForScaler",
+		append(tracing.ScalerAttributes(&sb.ScalerConfig), attribute.String("metricName", metricName))...)
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if isAuthExpired(&sb.ScalerConfig) {
+		if nsb, err := c.refreshScaler(ctx, id, sb); err != nil {
+			c.Logger.Error(err, "error refreshing scaler with expired authentication", "scalerIndex", sb.ScalerConfig.ScalerIndex)
+		} else {
+			c.releaseScalerBuilder(ctx, sb)
+			sb = nsb
+		}
+	}
+
+	m, err := sb.Scaler.GetMetrics(ctx, metricName, metricSelector)
 	if err == nil {
 		return m, nil
 	}
+	span.AddEvent("refreshing scaler after error", trace.WithAttributes(attribute.String("error", err.Error())))
 
-	ns, err := c.refreshScaler(ctx, id)
+	nsb, err := c.refreshScaler(ctx, id, sb)
 	if err != nil {
 		return nil, err
 	}
+	c.releaseScalerBuilder(ctx, sb)
+	sb = nsb
 
-	return ns.GetMetrics(ctx, metricName, metricSelector)
+	return sb.Scaler.GetMetrics(ctx, metricName, metricSelector)
 }
 
+// IsScaledObjectActive returns whether the ScaledObject is active, whether any scaler raised an error
+// and metrics of the triggers with useCachedMetrics enabled
 func (c *ScalersCache) IsScaledObjectActive(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, []external_metrics.ExternalMetricValue) {
+	ctx, span := tracing.StartSpan(ctx, "ScalersCache.IsScaledObjectActive", tracing.ScalableObjectAttributes(scaledObject.Namespace, "ScaledObject", scaledObject.Name)...)
+	defer span.End()
+
 	isActive := false
 	isError := false
+	metrics := []external_metrics.ExternalMetricValue{}
+
+	// the scalers were closed while the scale loop was checking them, eg. after their authentication was revoked
+	scalerBuilders := c.acquireScalerBuilders()
+	if scalerBuilders == nil {
+		return isActive, true, metrics
+	}
+	defer c.releaseScalerBuilders(ctx, scalerBuilders)
+	usingModifiers := scaledObject.IsUsingModifiers()
 	// Let's collect status of all scalers, no matter if any scaler raises error or is active
-	for i, s := range c.Scalers {
-		isTriggerActive, err := s.Scaler.IsActive(ctx)
-		if err != nil {
-			var ns scalers.Scaler
-			ns, err = c.refreshScaler(ctx, i)
-			if err == nil {
-				isTriggerActive, err = ns.IsActive(ctx)
+	for i, s := range scalerBuilders {
+		// activity of the triggers referenced in the formula is determined by the composite metric
+		if usingModifiers {
+			isReferenced, _ := modifiers.IsReferenced(scaledObject, s.ScalerConfig.TriggerName)
+			if metricSpecs := s.Scaler.GetMetricSpecForScaling(ctx); isReferenced && len(metricSpecs) > 0 && metricSpecs[0].External != nil {
+				continue
 			}
 		}
 
 		logger := c.Logger.WithValues("scaledobject.Name", scaledObject.Name, "scaledObject.Namespace", scaledObject.Namespace,
 			"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+		useCachedMetrics := i < len(scaledObject.Spec.Triggers) && scaledObject.Spec.Triggers[i].UseCachedMetrics
+		metricName, queriedOnce := getMetricNameForActivity(ctx, s.Scaler)
+		queriedOnce = queriedOnce && useCachedMetrics
+
+		start := time.Now()
+		var isTriggerActive bool
+		var err error
+		var triggerMetrics []external_metrics.ExternalMetricValue
+		if queriedOnce {
+			// the activity of the trigger is determined from the cached metrics, so the trigger is queried once
+			triggerMetrics, isTriggerActive, err = c.getMetricsAndActivityForScaler(ctx, i, s, metricName, metricscache.ScaledObjectMetricSelector(scaledObject.Name))
+		} else {
+			isTriggerActive, err = c.isScalerActive(ctx, i, s)
+		}
+		scalerName := getScalerName(s.Scaler)
+		prommetrics.RecordScalerLatency(scaledObject.Namespace, prommetrics.ScaledObjectType, scaledObject.Name, scalerName, i, time.Since(start))
+		if err == nil {
+			prommetrics.RecordScalerActive(scaledObject.Namespace, prommetrics.ScaledObjectType, scaledObject.Name, scalerName, i, isTriggerActive)
+		}
 
 		if err != nil {
 			isError = true
@@ -111,13 +303,147 @@ func (c *ScalersCache) IsScaledObjectActive(ctx context.Context, scaledObject *k
 				logger.V(1).Info("Scaler for scaledObject is active", "Metrics Name", resourceMetricsSpec.Name)
 			}
 		}
+
+		if err == nil && useCachedMetrics {
+			if !queriedOnce {
+				triggerMetrics = c.getMetricsForCaching(ctx, i, s.Scaler, scaledObject, logger)
+			}
+			metrics = append(metrics, triggerMetrics...)
+		}
+	}
+
+	if usingModifiers {
+		logger := c.Logger.WithValues("scaledobject.Name", scaledObject.Name, "scaledObject.Namespace", scaledObject.Namespace,
+			"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+
+		isCompositeActive, err := c.isCompositeMetricActive(ctx, scaledObject)
+		if err != nil {
+			isError = true
+			logger.Error(err, "Error getting scale decision")
+			c.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+		} else if isCompositeActive {
+			isActive = true
+			logger.V(1).Info("Scaler for scaledObject is active", "Metrics Name", kedav1alpha1.CompositeMetricName)
+		}
+	}
+
+	return isActive, isError, metrics
+}
+
+// getMetricsForCaching returns metrics of the scaler to be served from the metrics cache, errors are only logged
+// as the metrics are then queried directly by KEDA Metrics Server
+func (c *ScalersCache) getMetricsForCaching(ctx context.Context, id int, scaler scalers.Scaler, scaledObject *kedav1alpha1.ScaledObject, logger logr.Logger) []external_metrics.ExternalMetricValue {
+	metricSpecs := scaler.GetMetricSpecForScaling(ctx)
+	if len(metricSpecs) < 1 || metricSpecs[0].External == nil {
+		return nil
 	}
 
-	return isActive, isError, []external_metrics.ExternalMetricValue{}
+	// metrics are queried with the selector the HPA queries them with from KEDA Metrics Server
+	metrics, err := c.GetMetricsForScaler(ctx, id, metricSpecs[0].External.Metric.Name, metricscache.ScaledObjectMetricSelector(scaledObject.Name))
+	if err != nil {
+		logger.Error(err, "Error getting metrics for caching", "scalerIndex", id)
+		return nil
+	}
+	return metrics
 }
 
-func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, int64, int64) {
+// getMetricNameForActivity returns the name of the external metric of the scaler, if the scaler determines its activity from the metric
+func getMetricNameForActivity(ctx context.Context, scaler scalers.Scaler) (string, bool) {
+	if _, ok := scaler.(scalers.MetricsAndActivityScaler); !ok {
+		return "", false
+	}
+	metricSpecs := scaler.GetMetricSpecForScaling(ctx)
+	if len(metricSpecs) < 1 || metricSpecs[0].External == nil {
+		return "", false
+	}
+	return metricSpecs[0].External.Metric.Name, true
+}
+
+// getMetricsAndActivityForScaler returns the metrics of the scaler and whether it is active from a single query,
+// the scaler is refreshed and queried again after an error
+func (c *ScalersCache) getMetricsAndActivityForScaler(ctx context.Context, id int, sb ScalerBuilder, metricName string, metricSelector labels.Selector) (metrics []external_metrics.ExternalMetricValue, isActive bool, err error) {
+	ctx, span := tracing.StartSpan(ctx, "Scaler.GetMetricsAndActivity",
+		append(tracing.ScalerAttributes(&sb.ScalerConfig), attribute.String("metricName", metricName))...)
+	defer func() {
+		span.SetAttributes(attribute.Bool("active", isActive))
+		tracing.EndSpan(span, err)
+	}()
+
+	metrics, isActive, err = sb.Scaler.(scalers.MetricsAndActivityScaler).GetMetricsAndActivity(ctx, metricName, metricSelector)
+	if err != nil {
+		span.AddEvent("refreshing scaler after error", trace.WithAttributes(attribute.String("error", err.Error())))
+		var nsb ScalerBuilder
+		nsb, err = c.refreshScaler(ctx, id, sb)
+		if err == nil {
+			if ns, ok := nsb.Scaler.(scalers.MetricsAndActivityScaler); ok {
+				metrics, isActive, err = ns.GetMetricsAndActivity(ctx, metricName, metricSelector)
+			} else {
+				err = fmt.Errorf("refreshed scaler %s doesn't return metrics and activity", getScalerName(nsb.Scaler))
+			}
+			c.releaseScalerBuilder(ctx, nsb)
+		}
+	}
+	return metrics, isActive, err
+}
+
+// GetCompositeMetricValue returns value of the composite metric, calculated by the formula
+// defined in ScalingModifiers from metrics of the triggers referenced in the formula
+func (c *ScalersCache) GetCompositeMetricValue(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (float64, error) {
+	formula := scaledObject.Spec.Advanced.ScalingModifiers.Formula
+	names, err := modifiers.ReferencedTriggers(formula)
+	if err != nil {
+		return 0, err
+	}
+
+	scalerBuilders := c.acquireScalerBuilders()
+	defer c.releaseScalerBuilders(ctx, scalerBuilders)
+
+	values := make(map[string]float64, len(names))
+	for i, s := range scalerBuilders {
+		triggerName := s.ScalerConfig.TriggerName
+		if !contains(names, triggerName) {
+			continue
+		}
+
+		metricSpecs := s.Scaler.GetMetricSpecForScaling(ctx)
+		if len(metricSpecs) < 1 || metricSpecs[0].External == nil {
+			continue
+		}
+		metricName := metricSpecs[0].External.Metric.Name
+
+		metrics, err := c.GetMetricsForScaler(ctx, i, metricName, nil)
+		if err != nil {
+			return 0, fmt.Errorf("error getting metrics for trigger %s: %s", triggerName, err)
+		}
+
+		var value float64
+		for _, m := range metrics {
+			if m.MetricName == metricName {
+				value += m.Value.AsApproximateFloat64()
+			}
+		}
+		values[triggerName] = value
+	}
+
+	return modifiers.Evaluate(formula, values)
+}
+
+func (c *ScalersCache) isCompositeMetricActive(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, error) {
+	activationTarget, err := modifiers.GetActivationTarget(scaledObject)
+	if err != nil {
+		return false, err
+	}
+
+	value, err := c.GetCompositeMetricValue(ctx, scaledObject)
+	if err != nil {
+		return false, err
+	}
+	return value > activationTarget, nil
+}
+
+func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, int64, int64, string) {
 	var queueLength float64
+	var triggerNames []string
 	var maxValue float64
 	isActive := false
 
@@ -130,6 +456,7 @@ func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1a
 				queueLength = metrics.queueLength
 				maxValue = metrics.maxValue
 				isActive = metrics.isActive
+				triggerNames = []string{metrics.triggerName}
 			}
 		}
 	case "avg":
@@ -141,6 +468,7 @@ func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1a
 				queueLengthSum += metrics.queueLength
 				maxValueSum += metrics.maxValue
 				isActive = metrics.isActive
+				triggerNames = append(triggerNames, metrics.triggerName)
 				length++
 			}
 		}
@@ -154,6 +482,7 @@ func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1a
 				queueLength += metrics.queueLength
 				maxValue += metrics.maxValue
 				isActive = metrics.isActive
+				triggerNames = append(triggerNames, metrics.triggerName)
 			}
 		}
 	default: // max
@@ -162,6 +491,7 @@ func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1a
 				queueLength = metrics.queueLength
 				maxValue = metrics.maxValue
 				isActive = metrics.isActive
+				triggerNames = []string{metrics.triggerName}
 			}
 		}
 	}
@@ -173,19 +503,23 @@ func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1a
 	maxValue = min(float64(scaledJob.MaxReplicaCount()), maxValue)
 	logger.V(1).WithValues("ScaledJob", scaledJob.Name).Info("Checking if ScaleJob Scalers are active", "isActive", isActive, "maxValue", maxValue, "MultipleScalersCalculation", scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation)
 
-	return isActive, ceilToInt64(queueLength), ceilToInt64(maxValue)
+	return isActive, ceilToInt64(queueLength), ceilToInt64(maxValue), strings.Join(triggerNames, ",")
 }
 
 func (c *ScalersCache) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	scalerBuilders := c.acquireScalerBuilders()
+	defer c.releaseScalerBuilders(ctx, scalerBuilders)
+
 	var metrics []external_metrics.ExternalMetricValue
-	for i, s := range c.Scalers {
+	for i, s := range scalerBuilders {
 		m, err := s.Scaler.GetMetrics(ctx, metricName, metricSelector)
 		if err != nil {
-			ns, err := c.refreshScaler(ctx, i)
+			nsb, err := c.refreshScaler(ctx, i, s)
 			if err != nil {
 				return metrics, err
 			}
-			m, err = ns.GetMetrics(ctx, metricName, metricSelector)
+			m, err = nsb.Scaler.GetMetrics(ctx, metricName, metricSelector)
+			c.releaseScalerBuilder(ctx, nsb)
 			if err != nil {
 				return metrics, err
 			}
@@ -196,42 +530,126 @@ func (c *ScalersCache) GetMetrics(ctx context.Context, metricName string, metric
 	return metrics, nil
 }
 
-func (c *ScalersCache) refreshScaler(ctx context.Context, id int) (scalers.Scaler, error) {
-	if id < 0 || id >= len(c.Scalers) {
-		return nil, fmt.Errorf("scaler with id %d not found. Len = %d", id, len(c.Scalers))
+// isScalerActive returns the activity of the scaler, the scaler is refreshed and queried again if it raises an error
+func (c *ScalersCache) isScalerActive(ctx context.Context, id int, sb ScalerBuilder) (isActive bool, err error) {
+	ctx, span := tracing.StartSpan(ctx, "Scaler.IsActive", tracing.ScalerAttributes(&sb.ScalerConfig)...)
+	defer func() {
+		span.SetAttributes(attribute.Bool("active", isActive))
+		tracing.EndSpan(span, err)
+	}()
+
+	isActive, err = sb.Scaler.IsActive(ctx)
+	if err != nil {
+		span.AddEvent("refreshing scaler after error", trace.WithAttributes(attribute.String("error", err.Error())))
+		var nsb ScalerBuilder
+		nsb, err = c.refreshScaler(ctx, id, sb)
+		if err == nil {
+			isActive, err = nsb.Scaler.IsActive(ctx)
+			c.releaseScalerBuilder(ctx, nsb)
+		}
 	}
+	return isActive, err
+}
+
+// refreshScaler replaces the scaler sb with the id by a scaler built again and returns the scaler replacing it, to be released
+// by the caller. The scaler is built without holding the lock and only once by concurrent refreshes, if the scaler was already
+// replaced meanwhile it isn't built again. The replaced scaler is closed once its last user released it
+func (c *ScalersCache) refreshScaler(ctx context.Context, id int, sb ScalerBuilder) (_ ScalerBuilder, err error) {
+	ctx, span := tracing.StartSpan(ctx, "ScalersCache.refreshScaler", tracing.ScalerAttributes(&sb.ScalerConfig)...)
+	defer func() { tracing.EndSpan(span, err) }()
+
+	_, err, _ = c.refreshGroup.Do(strconv.Itoa(id), func() (interface{}, error) {
+		c.mutex.RLock()
+		replaced := id < 0 || id >= len(c.Scalers) || c.Scalers[id].refreshes != sb.refreshes
+		c.mutex.RUnlock()
+		if replaced {
+			return nil, nil
+		}
+
+		ns, sConfig, err := sb.Factory()
+		if err != nil {
+			return nil, err
+		}
+		nsb := ScalerBuilder{
+			Scaler:       ns,
+			ScalerConfig: *sConfig,
+			Factory:      sb.Factory,
+			refreshes:    sb.refreshes + 1,
+		}
+
+		c.mutex.Lock()
+		// the cache was closed while the scaler was built
+		if id >= len(c.Scalers) {
+			c.mutex.Unlock()
+			c.closeScalerBuilder(ctx, nsb)
+			return nil, nil
+		}
+		replacedScaler := c.Scalers[id]
+		c.Scalers[id] = nsb
+		c.mutex.Unlock()
 
-	sb := c.Scalers[id]
-	ns, err := sb.Factory()
+		c.retireScalerBuilder(ctx, replacedScaler)
+		return nil, nil
+	})
 	if err != nil {
-		return nil, err
+		return ScalerBuilder{}, err
 	}
+	return c.acquireScalerBuilder(id)
+}
 
-	c.Scalers[id] = ScalerBuilder{
-		Scaler:  ns,
-		Factory: sb.Factory,
+// RefreshScalers rebuilds the scalers matching the filter with their authentication resolved again, push scalers
+// are skipped as they keep running with the scaler they were started with until the scale loop is restarted.
+// The error of the last scaler which couldn't be rebuilt is returned, the scaler is kept until the cache is closed
+func (c *ScalersCache) RefreshScalers(ctx context.Context, filter func(config *scalers.ScalerConfig) bool) error {
+	scalerBuilders := c.acquireScalerBuilders()
+	defer c.releaseScalerBuilders(ctx, scalerBuilders)
+
+	var lastErr error
+	for id, sb := range scalerBuilders {
+		if _, ok := sb.Scaler.(scalers.PushScaler); ok || !filter(&sb.ScalerConfig) {
+			continue
+		}
+		nsb, err := c.refreshScaler(ctx, id, sb)
+		if err != nil {
+			c.Logger.Error(err, "error refreshing scaler", "scalerIndex", sb.ScalerConfig.ScalerIndex)
+			lastErr = err
+			continue
+		}
+		c.releaseScalerBuilder(ctx, nsb)
 	}
-	sb.Scaler.Close(ctx)
+	return lastErr
+}
 
-	return ns, nil
+// RefreshExpiredScalers rebuilds the scalers whose authentication has to be resolved again before the leases of their Vault secrets expire,
+// errors are logged and the scalers are rebuilt again when they fail
+func (c *ScalersCache) RefreshExpiredScalers(ctx context.Context) {
+	_ = c.RefreshScalers(ctx, isAuthExpired)
+}
+
+func isAuthExpired(config *scalers.ScalerConfig) bool {
+	return !config.AuthRefreshTime.IsZero() && !time.Now().Before(config.AuthRefreshTime)
 }
 
 func (c *ScalersCache) GetMetricSpecForScaling(ctx context.Context) []v2beta2.MetricSpec {
+	scalerBuilders := c.acquireScalerBuilders()
+	defer c.releaseScalerBuilders(ctx, scalerBuilders)
+
 	var spec []v2beta2.MetricSpec
-	for _, s := range c.Scalers {
+	for _, s := range scalerBuilders {
 		spec = append(spec, s.Scaler.GetMetricSpecForScaling(ctx)...)
 	}
 	return spec
 }
 
+// Close closes the scalers, the scalers still used are closed once their last user released them
 func (c *ScalersCache) Close(ctx context.Context) {
+	c.mutex.Lock()
 	scalers := c.Scalers
 	c.Scalers = nil
+	c.mutex.Unlock()
+
 	for _, s := range scalers {
-		err := s.Scaler.Close(ctx)
-		if err != nil {
-			c.Logger.Error(err, "error closing scaler", "scaler", s)
-		}
+		c.retireScalerBuilder(ctx, s)
 	}
 }
 
@@ -239,11 +657,15 @@ type scalerMetrics struct {
 	queueLength float64
 	maxValue    float64
 	isActive    bool
+	triggerName string
 }
 
 func (c *ScalersCache) getScaledJobMetrics(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) []scalerMetrics {
+	scalerBuilders := c.acquireScalerBuilders()
+	defer c.releaseScalerBuilders(ctx, scalerBuilders)
+
 	var scalersMetrics []scalerMetrics
-	for i, s := range c.Scalers {
+	for i, s := range scalerBuilders {
 		var queueLength float64
 		var targetAverageValue float64
 		isActive := false
@@ -260,14 +682,10 @@ func (c *ScalersCache) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 			continue
 		}
 
-		isTriggerActive, err := s.Scaler.IsActive(ctx)
-		if err != nil {
-			var ns scalers.Scaler
-			ns, err = c.refreshScaler(ctx, i)
-			if err == nil {
-				isTriggerActive, err = ns.IsActive(ctx)
-			}
-		}
+		start := time.Now()
+		isTriggerActive, err := c.isScalerActive(ctx, i, s)
+		scalerName := getScalerName(s.Scaler)
+		prommetrics.RecordScalerLatency(scaledJob.Namespace, prommetrics.ScaledJobType, scaledJob.Name, scalerName, i, time.Since(start))
 
 		if err != nil {
 			scalerLogger.V(1).Info("Error getting scaler.IsActive, but continue", "Error", err)
@@ -297,6 +715,7 @@ func (c *ScalersCache) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 		if isTriggerActive {
 			isActive = true
 		}
+		prommetrics.RecordScalerActive(scaledJob.Namespace, prommetrics.ScaledJobType, scaledJob.Name, scalerName, i, isTriggerActive)
 
 		if targetAverageValue != 0 {
 			averageLength := queueLength / targetAverageValue
@@ -306,11 +725,25 @@ func (c *ScalersCache) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 			queueLength: queueLength,
 			maxValue:    maxValue,
 			isActive:    isActive,
+			triggerName: getTriggerName(&s.ScalerConfig),
 		})
 	}
 	return scalersMetrics
 }
 
+// getTriggerName returns the name of the trigger of the scaler, or its type if it has no name
+func getTriggerName(config *scalers.ScalerConfig) string {
+	if config.TriggerName != "" {
+		return config.TriggerName
+	}
+	return config.TriggerType
+}
+
+// getScalerName returns the name of the scaler type reported in metrics, eg. prometheusScaler
+func getScalerName(scaler scalers.Scaler) string {
+	return strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
+}
+
 func getTargetAverageValue(metricSpecs []v2beta2.MetricSpec) float64 {
 	var targetAverageValue float64
 	var metricValue float64
@@ -341,3 +774,12 @@ func min(x, y float64) float64 {
 	}
 	return x
 }
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -2,7 +2,9 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -10,10 +12,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricscache"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 )
@@ -72,9 +77,10 @@ func TestIsScaledJobActive(t *testing.T) {
 	// Assme 1 trigger only
 	scaledJobSingle := createScaledObject(0, 100, "") // testing default = max
 	scalerSingle := []ScalerBuilder{{
-		Scaler: createScaler(ctrl, int64(20), int64(2), true, metricName),
-		Factory: func() (scalers.Scaler, error) {
-			return createScaler(ctrl, int64(20), int64(2), true, metricName), nil
+		Scaler:       createScaler(ctrl, int64(20), int64(2), true, metricName),
+		ScalerConfig: scalers.ScalerConfig{TriggerType: "queue", TriggerName: "orders"},
+		Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+			return createScaler(ctrl, int64(20), int64(2), true, metricName), &scalers.ScalerConfig{}, nil
 		},
 	}}
 
@@ -84,17 +90,18 @@ func TestIsScaledJobActive(t *testing.T) {
 		Recorder: recorder,
 	}
 
-	isActive, queueLength, maxValue := cache.IsScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, queueLength, maxValue, triggerName := cache.IsScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, int64(20), queueLength)
 	assert.Equal(t, int64(10), maxValue)
+	assert.Equal(t, "orders", triggerName)
 	cache.Close(context.Background())
 
 	// Non-Active trigger only
 	scalerSingle = []ScalerBuilder{{
 		Scaler: createScaler(ctrl, int64(0), int64(2), false, metricName),
-		Factory: func() (scalers.Scaler, error) {
-			return createScaler(ctrl, int64(0), int64(2), false, metricName), nil
+		Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+			return createScaler(ctrl, int64(0), int64(2), false, metricName), &scalers.ScalerConfig{}, nil
 		},
 	}}
 
@@ -104,7 +111,7 @@ func TestIsScaledJobActive(t *testing.T) {
 		Recorder: recorder,
 	}
 
-	isActive, queueLength, maxValue = cache.IsScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, queueLength, maxValue, _ = cache.IsScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, false, isActive)
 	assert.Equal(t, int64(0), queueLength)
 	assert.Equal(t, int64(0), maxValue)
@@ -123,23 +130,23 @@ func TestIsScaledJobActive(t *testing.T) {
 		scaledJob := createScaledObject(scalerTestData.MinReplicaCount, scalerTestData.MaxReplicaCount, scalerTestData.MultipleScalersCalculation)
 		scalersToTest := []ScalerBuilder{{
 			Scaler: createScaler(ctrl, scalerTestData.Scaler1QueueLength, scalerTestData.Scaler1AverageValue, scalerTestData.Scaler1IsActive, scalerTestData.MetricName),
-			Factory: func() (scalers.Scaler, error) {
-				return createScaler(ctrl, scalerTestData.Scaler1QueueLength, scalerTestData.Scaler1AverageValue, scalerTestData.Scaler1IsActive, scalerTestData.MetricName), nil
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return createScaler(ctrl, scalerTestData.Scaler1QueueLength, scalerTestData.Scaler1AverageValue, scalerTestData.Scaler1IsActive, scalerTestData.MetricName), &scalers.ScalerConfig{}, nil
 			},
 		}, {
 			Scaler: createScaler(ctrl, scalerTestData.Scaler2QueueLength, scalerTestData.Scaler2AverageValue, scalerTestData.Scaler2IsActive, scalerTestData.MetricName),
-			Factory: func() (scalers.Scaler, error) {
-				return createScaler(ctrl, scalerTestData.Scaler2QueueLength, scalerTestData.Scaler2AverageValue, scalerTestData.Scaler2IsActive, scalerTestData.MetricName), nil
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return createScaler(ctrl, scalerTestData.Scaler2QueueLength, scalerTestData.Scaler2AverageValue, scalerTestData.Scaler2IsActive, scalerTestData.MetricName), &scalers.ScalerConfig{}, nil
 			},
 		}, {
 			Scaler: createScaler(ctrl, scalerTestData.Scaler3QueueLength, scalerTestData.Scaler3AverageValue, scalerTestData.Scaler3IsActive, scalerTestData.MetricName),
-			Factory: func() (scalers.Scaler, error) {
-				return createScaler(ctrl, scalerTestData.Scaler3QueueLength, scalerTestData.Scaler3AverageValue, scalerTestData.Scaler3IsActive, scalerTestData.MetricName), nil
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return createScaler(ctrl, scalerTestData.Scaler3QueueLength, scalerTestData.Scaler3AverageValue, scalerTestData.Scaler3IsActive, scalerTestData.MetricName), &scalers.ScalerConfig{}, nil
 			},
 		}, {
 			Scaler: createScaler(ctrl, scalerTestData.Scaler4QueueLength, scalerTestData.Scaler4AverageValue, scalerTestData.Scaler4IsActive, scalerTestData.MetricName),
-			Factory: func() (scalers.Scaler, error) {
-				return createScaler(ctrl, scalerTestData.Scaler4QueueLength, scalerTestData.Scaler4AverageValue, scalerTestData.Scaler4IsActive, scalerTestData.MetricName), nil
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return createScaler(ctrl, scalerTestData.Scaler4QueueLength, scalerTestData.Scaler4AverageValue, scalerTestData.Scaler4IsActive, scalerTestData.MetricName), &scalers.ScalerConfig{}, nil
 			},
 		}}
 
@@ -149,7 +156,7 @@ func TestIsScaledJobActive(t *testing.T) {
 			Recorder: recorder,
 		}
 		fmt.Printf("index: %d", index)
-		isActive, queueLength, maxValue = cache.IsScaledJobActive(context.TODO(), scaledJob)
+		isActive, queueLength, maxValue, _ = cache.IsScaledJobActive(context.TODO(), scaledJob)
 		//	assert.Equal(t, 5, index)
 		assert.Equal(t, scalerTestData.ResultIsActive, isActive)
 		assert.Equal(t, scalerTestData.ResultQueueLength, queueLength)
@@ -167,8 +174,8 @@ func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T
 	scaledJobSingle := createScaledObject(1, 100, "") // testing default = max
 	scalerSingle := []ScalerBuilder{{
 		Scaler: createScaler(ctrl, int64(0), int64(1), true, metricName),
-		Factory: func() (scalers.Scaler, error) {
-			return createScaler(ctrl, int64(0), int64(1), true, metricName), nil
+		Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+			return createScaler(ctrl, int64(0), int64(1), true, metricName), &scalers.ScalerConfig{}, nil
 		},
 	}}
 
@@ -178,13 +185,292 @@ func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T
 		Recorder: recorder,
 	}
 
-	isActive, queueLength, maxValue := cache.IsScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, queueLength, maxValue, _ := cache.IsScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, int64(0), queueLength)
 	assert.Equal(t, int64(0), maxValue)
 	cache.Close(context.Background())
 }
 
+func TestIsScaledJobActiveWithFloatMetrics(t *testing.T) {
+	metricName := "s0-queueLength"
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+
+	scaledJob := createScaledObject(0, 100, "")
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	metricSpec := v2beta2.MetricSpec{
+		External: &v2beta2.ExternalMetricSource{
+			Metric: v2beta2.MetricIdentifier{Name: metricName},
+			Target: scalers.GetMetricTargetMili(v2beta2.AverageValueMetricType, 0.5),
+		},
+	}
+	scaler.EXPECT().IsActive(gomock.Any()).Return(true, nil)
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2beta2.MetricSpec{metricSpec})
+	scaler.EXPECT().GetMetrics(gomock.Any(), gomock.Any(), nil).Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(metricName, 2.25)}, nil)
+	scaler.EXPECT().Close(gomock.Any())
+
+	cache := ScalersCache{
+		Scalers:  []ScalerBuilder{{Scaler: scaler}},
+		Logger:   logr.Discard(),
+		Recorder: recorder,
+	}
+
+	// 2.25 / 0.5 = 4.5 is rounded up to 5 jobs
+	isActive, queueLength, maxValue, _ := cache.IsScaledJobActive(context.TODO(), scaledJob)
+	assert.Equal(t, true, isActive)
+	assert.Equal(t, int64(3), queueLength)
+	assert.Equal(t, int64(5), maxValue)
+	cache.Close(context.Background())
+}
+
+func TestIsScaledObjectActiveWithModifiers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(10)
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "deployment"},
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: &kedav1alpha1.ScalingModifiers{
+					Formula:          "(kafka + rabbit) / 2",
+					Target:           "10",
+					ActivationTarget: "5",
+				},
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "kafka", Name: "kafka"},
+				{Type: "rabbitmq", Name: "rabbit"},
+				{Type: "redis", Name: "redis"},
+			},
+		},
+	}
+
+	tests := []struct {
+		kafka          int64
+		rabbit         int64
+		redisActive    bool
+		expectedValue  float64
+		expectedActive bool
+	}{
+		{kafka: 4, rabbit: 2, expectedValue: 3, expectedActive: false},
+		{kafka: 10, rabbit: 2, expectedValue: 6, expectedActive: true},
+		// the activity of a trigger not referenced in the formula is determined by the trigger itself
+		{kafka: 4, rabbit: 2, redisActive: true, expectedValue: 3, expectedActive: true},
+	}
+
+	for _, test := range tests {
+		redisScaler := createMetricsScaler(ctrl, 100, "s2-redis")
+		redisScaler.EXPECT().IsActive(gomock.Any()).Return(test.redisActive, nil)
+		cache := ScalersCache{
+			Scalers: []ScalerBuilder{
+				{Scaler: createMetricsScaler(ctrl, test.rabbit, "s1-rabbitmq"), ScalerConfig: scalers.ScalerConfig{TriggerName: "rabbit"}},
+				{Scaler: createMetricsScaler(ctrl, test.kafka, "s0-kafka"), ScalerConfig: scalers.ScalerConfig{TriggerName: "kafka"}},
+				{Scaler: redisScaler, ScalerConfig: scalers.ScalerConfig{TriggerName: "redis"}},
+			},
+			Logger:   logr.Discard(),
+			Recorder: recorder,
+		}
+
+		value, err := cache.GetCompositeMetricValue(context.TODO(), scaledObject)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expectedValue, value)
+
+		isActive, isError, _ := cache.IsScaledObjectActive(context.TODO(), scaledObject)
+		assert.Equal(t, test.expectedActive, isActive)
+		assert.False(t, isError)
+		cache.Close(context.Background())
+	}
+}
+
+func TestIsScaledObjectActiveReturnsCachedMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(10)
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "deployment"},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "kafka", UseCachedMetrics: true},
+				{Type: "rabbitmq"},
+			},
+		},
+	}
+
+	cachedScaler := createMetricsScaler(ctrl, 10, "s0-kafka")
+	cachedScaler.EXPECT().IsActive(gomock.Any()).Return(true, nil)
+	scaler := createMetricsScaler(ctrl, 5, "s1-rabbitmq")
+	scaler.EXPECT().IsActive(gomock.Any()).Return(false, nil)
+
+	cache := ScalersCache{
+		Scalers:  []ScalerBuilder{{Scaler: cachedScaler}, {Scaler: scaler}},
+		Logger:   logr.Discard(),
+		Recorder: recorder,
+	}
+
+	isActive, isError, metrics := cache.IsScaledObjectActive(context.TODO(), scaledObject)
+	assert.True(t, isActive)
+	assert.False(t, isError)
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, "s0-kafka", metrics[0].MetricName)
+	assert.Equal(t, int64(10), metrics[0].Value.Value())
+	cache.Close(context.Background())
+}
+
+func TestIsScaledObjectActiveQueriesCachedTriggerOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(10)
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "so"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "deployment"},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "prometheus", UseCachedMetrics: true},
+			},
+		},
+	}
+
+	// IsActive and GetMetrics are not expected, the activity is determined from the cached metrics
+	scaler := mock_scalers.NewMockMetricsAndActivityScaler(ctrl)
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-prometheus", Value: *resource.NewQuantity(10, resource.DecimalSI)},
+	}
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2beta2.MetricSpec{createMetricSpec(1, "s0-prometheus")}).AnyTimes()
+	scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), "s0-prometheus", metricscache.ScaledObjectMetricSelector("so")).Return(metrics, true, nil).Times(1)
+	scaler.EXPECT().Close(gomock.Any())
+
+	cache := ScalersCache{
+		Scalers:  []ScalerBuilder{{Scaler: scaler}},
+		Logger:   logr.Discard(),
+		Recorder: recorder,
+	}
+
+	isActive, isError, cachedMetrics := cache.IsScaledObjectActive(context.TODO(), scaledObject)
+	assert.True(t, isActive)
+	assert.False(t, isError)
+	assert.Equal(t, metrics, cachedMetrics)
+	cache.Close(context.Background())
+}
+
+func TestGetMetricsForScalerDoesNotWaitForOtherScalers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	// the first scaler blocks until the second scaler returned its metrics
+	released := make(chan struct{})
+	slowScaler := mock_scalers.NewMockScaler(ctrl)
+	slowScaler.EXPECT().GetMetrics(gomock.Any(), "s0-slow", gomock.Any()).DoAndReturn(
+		func(context.Context, string, labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+			<-released
+			return nil, nil
+		})
+	slowScaler.EXPECT().Close(gomock.Any())
+	scaler := createMetricsScaler(ctrl, 5, "s1-fast")
+
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{Scaler: slowScaler}, {Scaler: scaler}},
+		Logger:  logr.Discard(),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := cache.GetMetricsForScaler(context.TODO(), 0, "s0-slow", nil)
+		assert.NoError(t, err)
+	}()
+
+	metrics, err := cache.GetMetricsForScaler(context.TODO(), 1, "s1-fast", nil)
+	close(released)
+	<-done
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 1)
+	cache.Close(context.Background())
+}
+
+func TestConcurrentRefreshReplacesScalerOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	failingScaler := mock_scalers.NewMockScaler(ctrl)
+	failingScaler.EXPECT().IsActive(gomock.Any()).Return(false, errors.New("connection lost")).Times(2)
+	failingScaler.EXPECT().Close(gomock.Any())
+
+	var builds int32
+	cache := ScalersCache{Logger: logr.Discard()}
+	cache.Scalers = []ScalerBuilder{{
+		Scaler: failingScaler,
+		Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+			atomic.AddInt32(&builds, 1)
+			scaler := mock_scalers.NewMockScaler(ctrl)
+			scaler.EXPECT().IsActive(gomock.Any()).Return(true, nil).AnyTimes()
+			scaler.EXPECT().Close(gomock.Any())
+			return scaler, &scalers.ScalerConfig{}, nil
+		},
+	}}
+
+	// both callers got the failing scaler before it was refreshed
+	sb, err := cache.acquireScalerBuilder(0)
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		isActive, err := cache.isScalerActive(context.TODO(), 0, sb)
+		assert.NoError(t, err)
+		assert.True(t, isActive)
+	}
+	cache.releaseScalerBuilder(context.TODO(), sb)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&builds), "the scaler is only built by the first refresh")
+	assert.Len(t, cache.GetScalers(), 1)
+	assert.Equal(t, 1, cache.GetScalerBuilders()[0].refreshes)
+	cache.Close(context.Background())
+}
+
+func TestRefreshClosesReplacedScalerOnceReleased(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	var closed int32
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scaler.EXPECT().Close(gomock.Any()).Do(func(context.Context) { atomic.AddInt32(&closed, 1) })
+	var revoked int32
+	newScaler := mock_scalers.NewMockScaler(ctrl)
+	newScaler.EXPECT().Close(gomock.Any())
+
+	cache := ScalersCache{Logger: logr.Discard()}
+	cache.Scalers = []ScalerBuilder{{
+		Scaler:       scaler,
+		ScalerConfig: scalers.ScalerConfig{RevokeAuthLeases: func(context.Context) { atomic.AddInt32(&revoked, 1) }},
+		Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+			return newScaler, &scalers.ScalerConfig{}, nil
+		},
+	}}
+
+	// the scaler is still used by a caller while it's refreshed
+	sb, err := cache.acquireScalerBuilder(0)
+	assert.NoError(t, err)
+	assert.NoError(t, cache.RefreshScalers(context.TODO(), func(*scalers.ScalerConfig) bool { return true }))
+	assert.Equal(t, []scalers.Scaler{newScaler}, cache.GetScalers())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&closed), "the replaced scaler isn't closed while it's used")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&revoked), "the leases of the replaced scaler aren't revoked while it's used")
+
+	cache.releaseScalerBuilder(context.TODO(), sb)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&closed), "the replaced scaler is closed once released")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&revoked))
+	cache.Close(context.Background())
+}
+
+// createMetricsScaler creates scaler returning the metric value, expectations on IsActive are left to the caller
+func createMetricsScaler(ctrl *gomock.Controller, value int64, metricName string) *mock_scalers.MockScaler {
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	metrics := []external_metrics.ExternalMetricValue{
+		{
+			MetricName: metricName,
+			Value:      *resource.NewQuantity(value, resource.DecimalSI),
+		},
+	}
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2beta2.MetricSpec{createMetricSpec(1, metricName)}).AnyTimes()
+	scaler.EXPECT().GetMetrics(gomock.Any(), metricName, gomock.Any()).Return(metrics, nil).AnyTimes()
+	scaler.EXPECT().Close(gomock.Any())
+	return scaler
+}
+
 func newScalerTestData(
 	metricName string,
 	maxReplicaCount int,
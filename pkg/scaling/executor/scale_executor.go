@@ -38,7 +38,7 @@ const (
 
 // ScaleExecutor contains methods RequestJobScale and RequestScale
 type ScaleExecutor interface {
-	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64)
+	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64, triggerName string)
 	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool)
 }
 
@@ -124,6 +124,17 @@ func (e *scaleExecutor) setActiveCondition(ctx context.Context, logger logr.Logg
 	return e.setCondition(ctx, logger, object, status, reason, message, active)
 }
 
+// setDegradedCondition sets the Degraded condition of the ScaledJob, which is added to the conditions when it's first set
+func (e *scaleExecutor) setDegradedCondition(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, status metav1.ConditionStatus, reason string, message string) error {
+	patch := runtimeclient.MergeFrom(scaledJob.DeepCopy())
+	scaledJob.Status.Conditions.SetDegradedCondition(status, reason, message)
+	err := e.client.Status().Patch(ctx, scaledJob, patch)
+	if err != nil {
+		logger.Error(err, "Failed to patch Objects Status")
+	}
+	return err
+}
+
 func (e *scaleExecutor) setFallbackCondition(ctx context.Context, logger logr.Logger, object interface{}, status metav1.ConditionStatus, reason string, message string) error {
 	fallback := func(conditions kedav1alpha1.Conditions, status metav1.ConditionStatus, reason string, message string) {
 		conditions.SetFallbackCondition(status, reason, message)
@@ -20,38 +20,71 @@ import (
 	"context"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
 	version "github.com/kedacore/keda/v2/version"
 )
 
 const (
 	defaultSuccessfulJobsHistoryLimit = int32(100)
 	defaultFailedJobsHistoryLimit     = int32(100)
+
+	// annotations and env vars passed to each Job of a batch, so that the Jobs can partition the work of the batch
+	jobIndexAnnotation       = "scaledjob.keda.sh/job-index"
+	jobBatchSizeAnnotation   = "scaledjob.keda.sh/batch-size"
+	jobTriggerAnnotation     = "scaledjob.keda.sh/trigger"
+	jobQueueLengthAnnotation = "scaledjob.keda.sh/queue-length"
+	jobIndexEnv              = "KEDA_JOB_INDEX"
+	jobBatchSizeEnv          = "KEDA_JOB_BATCH_SIZE"
+	jobTriggerEnv            = "KEDA_JOB_TRIGGER"
+	jobQueueLengthEnv        = "KEDA_JOB_QUEUE_LENGTH"
 )
 
-func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64) {
+func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64, triggerName string) {
 	logger := e.logger.WithValues("scaledJob.Name", scaledJob.Name, "scaledJob.Namespace", scaledJob.Namespace)
 
+	if err := e.terminateDrainedJobs(ctx, logger, scaledJob); err != nil {
+		logger.Error(err, "Failed to terminate drained jobs")
+	}
+
 	runningJobCount := e.getRunningJobCount(ctx, scaledJob)
 	pendingJobCount := e.getPendingJobCount(ctx, scaledJob)
 	logger.Info("Scaling Jobs", "Number of running Jobs", runningJobCount)
 	logger.Info("Scaling Jobs", "Number of pending Jobs ", pendingJobCount)
+	prommetrics.RecordScaledJobJobs(scaledJob.Namespace, scaledJob.Name, runningJobCount, pendingJobCount)
 
-	effectiveMaxScale, scaleTo := e.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, logger)
+	queueLength := scaleTo
+	parallelismMode := scaledJob.Spec.ScalingMode == kedav1alpha1.ScaledJobScalingModeParallelism
+
+	var effectiveMaxScale int64
+	if parallelismMode {
+		drainingJobPods, err := e.getDrainingJobPods(ctx, scaledJob)
+		if err != nil {
+			logger.Error(err, "Failed to get the pods of drained jobs")
+		}
+		effectiveMaxScale = getJobParallelism(scaledJob, isActive, maxScale, drainingJobPods)
+	} else {
+		effectiveMaxScale, scaleTo = e.getScalingDecision(scaledJob, runningJobCount, scaleTo, maxScale, pendingJobCount, logger)
+	}
 
 	if effectiveMaxScale < 0 {
 		effectiveMaxScale = 0
 	}
 
+	decision := kedav1alpha1.ScaledJobScalingDecision{QueueLength: queueLength, MaxScale: maxScale, EffectiveMaxScale: effectiveMaxScale}
+
+	var jobsCreation *kedav1alpha1.ScaledJobJobsCreation
 	if isActive {
 		logger.V(1).Info("At least one scaler is active")
 		now := metav1.Now()
@@ -60,11 +93,23 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		if err != nil {
 			logger.Error(err, "Failed to update last active time")
 		}
-		e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale)
 	} else {
 		logger.V(1).Info("No change in activity")
 	}
 
+	switch {
+	case parallelismMode:
+		// the Job is scaled also when the triggers aren't active, to suspend it
+		jobsCreation = e.scaleJobParallelism(ctx, logger, scaledJob, effectiveMaxScale)
+	case isActive:
+		scaleTo = e.applyFailurePolicy(ctx, logger, scaledJob, scaleTo, runningJobCount, time.Now())
+		jobsCreation = e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale, triggerName, queueLength)
+	}
+
+	if err := e.updateJobsStatus(ctx, scaledJob, runningJobCount, pendingJobCount, decision, jobsCreation); err != nil {
+		logger.Error(err, "Failed to update jobs status")
+	}
+
 	condition := scaledJob.Status.Conditions.GetActiveCondition()
 	if condition.IsUnknown() || condition.IsTrue() != isActive {
 		if isActive {
@@ -100,20 +145,73 @@ func (e *scaleExecutor) getScalingDecision(scaledJob *kedav1alpha1.ScaledJob, ru
 	return effectiveMaxScale, scaleTo
 }
 
-func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, maxScale int64) {
-	scaledJob.Spec.JobTargetRef.Template.GenerateName = scaledJob.GetName() + "-"
-	if scaledJob.Spec.JobTargetRef.Template.Labels == nil {
-		scaledJob.Spec.JobTargetRef.Template.Labels = map[string]string{}
+// updateJobsStatus reports the Jobs and the last scaling decision in the status of the ScaledJob,
+// the status is only patched when it changed
+func (e *scaleExecutor) updateJobsStatus(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, runningJobCount int64, pendingJobCount int64, decision kedav1alpha1.ScaledJobScalingDecision, jobsCreation *kedav1alpha1.ScaledJobJobsCreation) error {
+	status := scaledJob.Status.DeepCopy()
+	status.RunningJobs = &runningJobCount
+	status.PendingJobs = &pendingJobCount
+	status.LastScalingDecision = &decision
+	if jobsCreation != nil {
+		status.LastJobsCreation = jobsCreation
+	}
+
+	if equality.Semantic.DeepEqual(&scaledJob.Status, status) {
+		return nil
 	}
-	scaledJob.Spec.JobTargetRef.Template.Labels["scaledjob.keda.sh/name"] = scaledJob.GetName()
 
+	patch := client.MergeFrom(scaledJob.DeepCopy())
+	scaledJob.Status = *status
+	return e.client.Status().Patch(ctx, scaledJob, patch)
+}
+
+// createJobs creates the Jobs of the ScaledJob up to maxScale and returns the created batch,
+// or nil if no Job was requested. Each Job is passed its index in the batch, the size of the batch,
+// the triggers and the queue length the batch was created for
+func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, maxScale int64, triggerName string, queueLength int64) *kedav1alpha1.ScaledJobJobsCreation {
 	logger.Info("Creating jobs", "Effective number of max jobs", maxScale)
 
+	requestedJobs := scaleTo
 	if scaleTo > maxScale {
 		scaleTo = maxScale
 	}
 	logger.Info("Creating jobs", "Number of jobs", scaleTo)
 
+	var createdJobs int64
+	for i := 0; i < int(scaleTo); i++ {
+		job := e.newJob(logger, scaledJob)
+		setJobBatchParameters(job, int64(i), scaleTo, triggerName, queueLength)
+
+		err := e.client.Create(ctx, job)
+		if err != nil {
+			logger.Error(err, "Failed to create a new Job")
+			continue
+		}
+		createdJobs++
+	}
+	if requestedJobs > 0 {
+		prommetrics.RecordScaledJobJobsCreated(scaledJob.Namespace, scaledJob.Name, createdJobs, requestedJobs-createdJobs)
+	}
+	logger.Info("Created jobs", "Number of jobs", createdJobs)
+	// the event is also emitted as CloudEvent, so it is only recorded when Jobs were created
+	if createdJobs > 0 {
+		e.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreated, "Created %d jobs", createdJobs)
+	}
+
+	if requestedJobs <= 0 {
+		return nil
+	}
+	return &kedav1alpha1.ScaledJobJobsCreation{Time: metav1.Now(), RequestedJobs: requestedJobs, CreatedJobs: createdJobs}
+}
+
+// newJob returns a new Job of the ScaledJob from its jobTargetRef, owned by the ScaledJob
+func (e *scaleExecutor) newJob(logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) *batchv1.Job {
+	scaledJob.Spec.JobTargetRef.Template.GenerateName = scaledJob.GetName() + "-"
+	if scaledJob.Spec.JobTargetRef.Template.Labels == nil {
+		scaledJob.Spec.JobTargetRef.Template.Labels = map[string]string{}
+	}
+	scaledJob.Spec.JobTargetRef.Template.Labels["scaledjob.keda.sh/name"] = scaledJob.GetName()
+
 	labels := map[string]string{
 		"app.kubernetes.io/name":       scaledJob.GetName(),
 		"app.kubernetes.io/version":    version.Version,
@@ -124,37 +222,81 @@ func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scal
 	for key, value := range scaledJob.ObjectMeta.Labels {
 		labels[key] = value
 	}
+	labels[kedav1alpha1.ScaledJobGenerationLabel] = strconv.FormatInt(scaledJob.Generation, 10)
 
-	for i := 0; i < int(scaleTo); i++ {
-		job := &batchv1.Job{
-			ObjectMeta: metav1.ObjectMeta{
-				GenerateName: scaledJob.GetName() + "-",
-				Namespace:    scaledJob.GetNamespace(),
-				Labels:       labels,
-			},
-			Spec: *scaledJob.Spec.JobTargetRef.DeepCopy(),
-		}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: scaledJob.GetName() + "-",
+			Namespace:    scaledJob.GetNamespace(),
+			Labels:       labels,
+		},
+		Spec: *scaledJob.Spec.JobTargetRef.DeepCopy(),
+	}
 
-		// Job doesn't allow RestartPolicyAlways, it seems like this value is set by the client as a default one,
-		// we should set this property to allowed value in that case
-		if job.Spec.Template.Spec.RestartPolicy == "" {
-			logger.V(1).Info("Job RestartPolicy is not set, setting it to 'OnFailure', to avoid setting it to the client's default value 'Always'")
-			job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
-		}
+	// Job doesn't allow RestartPolicyAlways, it seems like this value is set by the client as a default one,
+	// we should set this property to allowed value in that case
+	if job.Spec.Template.Spec.RestartPolicy == "" {
+		logger.V(1).Info("Job RestartPolicy is not set, setting it to 'OnFailure', to avoid setting it to the client's default value 'Always'")
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	}
 
-		// Set ScaledJob instance as the owner and controller
-		err := controllerutil.SetControllerReference(scaledJob, job, e.reconcilerScheme)
-		if err != nil {
-			logger.Error(err, "Failed to set ScaledJob as the owner of the new Job")
+	// Set ScaledJob instance as the owner and controller
+	err := controllerutil.SetControllerReference(scaledJob, job, e.reconcilerScheme)
+	if err != nil {
+		logger.Error(err, "Failed to set ScaledJob as the owner of the new Job")
+	}
+	return job
+}
+
+// setJobBatchParameters annotates the Job and its pod template with the parameters of the batch, and sets them as env vars
+// of the containers which don't define them already
+func setJobBatchParameters(job *batchv1.Job, index int64, batchSize int64, triggerName string, queueLength int64) {
+	parameters := []struct {
+		annotation string
+		env        string
+		value      string
+	}{
+		{jobIndexAnnotation, jobIndexEnv, strconv.FormatInt(index, 10)},
+		{jobBatchSizeAnnotation, jobBatchSizeEnv, strconv.FormatInt(batchSize, 10)},
+		{jobTriggerAnnotation, jobTriggerEnv, triggerName},
+		{jobQueueLengthAnnotation, jobQueueLengthEnv, strconv.FormatInt(queueLength, 10)},
+	}
+
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	if job.Spec.Template.Annotations == nil {
+		job.Spec.Template.Annotations = map[string]string{}
+	}
+	for _, parameter := range parameters {
+		job.Annotations[parameter.annotation] = parameter.value
+		job.Spec.Template.Annotations[parameter.annotation] = parameter.value
+	}
+
+	podSpec := &job.Spec.Template.Spec
+	containers := make([]*corev1.Container, 0, len(podSpec.InitContainers)+len(podSpec.Containers))
+	for i := range podSpec.InitContainers {
+		containers = append(containers, &podSpec.InitContainers[i])
+	}
+	for i := range podSpec.Containers {
+		containers = append(containers, &podSpec.Containers[i])
+	}
+	for _, container := range containers {
+		for _, parameter := range parameters {
+			if !hasEnv(container, parameter.env) {
+				container.Env = append(container.Env, corev1.EnvVar{Name: parameter.env, Value: parameter.value})
+			}
 		}
+	}
+}
 
-		err = e.client.Create(ctx, job)
-		if err != nil {
-			logger.Error(err, "Failed to create a new Job")
+func hasEnv(container *corev1.Container, name string) bool {
+	for _, env := range container.Env {
+		if env.Name == name {
+			return true
 		}
 	}
-	logger.Info("Created jobs", "Number of jobs", scaleTo)
-	e.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreated, "Created %d jobs", scaleTo)
+	return false
 }
 
 func (e *scaleExecutor) isJobFinished(j *batchv1.Job) bool {
@@ -181,9 +323,10 @@ func (e *scaleExecutor) getRunningJobCount(ctx context.Context, scaledJob *kedav
 		return 0
 	}
 
+	now := time.Now()
 	for _, job := range jobs.Items {
 		job := job
-		if !e.isJobFinished(&job) {
+		if !e.isJobFinished(&job) && countsTowardCapacity(scaledJob, &job, now) {
 			runningJobs++
 		}
 	}
@@ -255,10 +398,11 @@ func (e *scaleExecutor) getPendingJobCount(ctx context.Context, scaledJob *kedav
 		return 0
 	}
 
+	now := time.Now()
 	for _, job := range jobs.Items {
 		job := job
 
-		if !e.isJobFinished(&job) {
+		if !e.isJobFinished(&job) && countsTowardCapacity(scaledJob, &job, now) {
 			if len(scaledJob.Spec.ScalingStrategy.PendingPodConditions) > 0 {
 				if !e.areAllPendingPodConditionsFulfilled(ctx, &job, scaledJob.Spec.ScalingStrategy.PendingPodConditions) {
 					pendingJobs++
@@ -290,6 +434,14 @@ func (e *scaleExecutor) cleanUp(ctx context.Context, scaledJob *kedav1alpha1.Sca
 		return err
 	}
 
+	// the failed Jobs are accounted for the failure policy before they are removed
+	if scaledJob.Spec.FailurePolicy != nil {
+		if err := e.accountJobFailures(ctx, scaledJob, jobs.Items); err != nil {
+			logger.Error(err, "Failed to update the failed jobs, not removing jobs")
+			return err
+		}
+	}
+
 	completedJobs := []batchv1.Job{}
 	failedJobs := []batchv1.Job{}
 	for _, job := range jobs.Items {
@@ -428,3 +580,10 @@ func min(x, y int64) int64 {
 	}
 	return x
 }
+
+func max(x, y int64) int64 {
+	if x < y {
+		return y
+	}
+	return x
+}
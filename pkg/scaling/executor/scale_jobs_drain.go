@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// isDrainedLongerThan returns whether the Job of a previous version of the ScaledJob has been drained for longer than the deadline,
+// it's always false if there is no deadline
+func isDrainedLongerThan(job *batchv1.Job, deadlineSeconds *int64, now time.Time) bool {
+	if deadlineSeconds == nil || job.Labels[kedav1alpha1.ScaledJobDrainingLabel] != "true" {
+		return false
+	}
+
+	drainStart, err := time.Parse(time.RFC3339, job.Annotations[kedav1alpha1.ScaledJobDrainStartAnnotation])
+	if err != nil {
+		return false
+	}
+	return now.Sub(drainStart) >= time.Duration(*deadlineSeconds)*time.Second
+}
+
+// countsTowardCapacity returns whether the Job counts toward maxReplicaCount, the drained Jobs of a previous version
+// of the ScaledJob don't count anymore after rollout.drainDeadlineSeconds
+func countsTowardCapacity(scaledJob *kedav1alpha1.ScaledJob, job *batchv1.Job, now time.Time) bool {
+	return !isDrainedLongerThan(job, scaledJob.Spec.Rollout.DrainDeadlineSeconds, now)
+}
+
+// getDrainingJobPods returns the active pods of the unfinished Jobs of previous versions of the ScaledJob which still count
+// toward maxReplicaCount, so the parallelism of the new Job with them stays within maxReplicaCount during the drain
+func (e *scaleExecutor) getDrainingJobPods(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (int64, error) {
+	opts := []client.ListOption{
+		client.InNamespace(scaledJob.GetNamespace()),
+		client.MatchingLabels(map[string]string{
+			"scaledjob.keda.sh/name":            scaledJob.GetName(),
+			kedav1alpha1.ScaledJobDrainingLabel: "true",
+		}),
+	}
+	jobs := &batchv1.JobList{}
+	err := e.client.List(ctx, jobs, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	var pods int64
+	now := time.Now()
+	for _, job := range jobs.Items {
+		job := job
+		if !e.isJobFinished(&job) && countsTowardCapacity(scaledJob, &job, now) {
+			pods += int64(job.Status.Active)
+		}
+	}
+	return pods, nil
+}
+
+// terminateDrainedJobs deletes the unfinished Jobs of previous versions of the ScaledJob drained for longer than rollout.activeDeadlineSeconds
+func (e *scaleExecutor) terminateDrainedJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) error {
+	if scaledJob.Spec.Rollout.ActiveDeadlineSeconds == nil {
+		return nil
+	}
+
+	opts := []client.ListOption{
+		client.InNamespace(scaledJob.GetNamespace()),
+		client.MatchingLabels(map[string]string{
+			"scaledjob.keda.sh/name":            scaledJob.GetName(),
+			kedav1alpha1.ScaledJobDrainingLabel: "true",
+		}),
+	}
+	jobs := &batchv1.JobList{}
+	err := e.client.List(ctx, jobs, opts...)
+	if err != nil {
+		return err
+	}
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	if scaledJob.Spec.Rollout.PropagationPolicy == "foreground" {
+		propagationPolicy = metav1.DeletePropagationForeground
+	}
+
+	now := time.Now()
+	for _, job := range jobs.Items {
+		job := job
+		if e.isJobFinished(&job) || !isDrainedLongerThan(&job, scaledJob.Spec.Rollout.ActiveDeadlineSeconds, now) {
+			continue
+		}
+
+		err := e.client.Delete(ctx, &job, client.PropagationPolicy(propagationPolicy))
+		if err != nil {
+			return err
+		}
+		logger.Info("Terminated a drained job by reaching the activeDeadlineSeconds", "job.Name", job.Name, "activeDeadlineSeconds", *scaledJob.Spec.Rollout.ActiveDeadlineSeconds)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+)
+
+func TestIsDrainedLongerThan(t *testing.T) {
+	now := time.Now()
+	deadline := int64(60)
+
+	assert.False(t, isDrainedLongerThan(getDrainingJob("no-deadline", now.Add(-time.Hour)), nil, now))
+	assert.False(t, isDrainedLongerThan(getDrainingJob("recent", now.Add(-time.Second)), &deadline, now))
+	assert.True(t, isDrainedLongerThan(getDrainingJob("expired", now.Add(-2*time.Minute)), &deadline, now))
+
+	notDraining := getDrainingJob("not-draining", now.Add(-time.Hour))
+	delete(notDraining.Labels, kedav1alpha1.ScaledJobDrainingLabel)
+	assert.False(t, isDrainedLongerThan(notDraining, &deadline, now))
+
+	invalidStart := getDrainingJob("invalid-start", now)
+	invalidStart.Annotations[kedav1alpha1.ScaledJobDrainStartAnnotation] = "invalid"
+	assert.False(t, isDrainedLongerThan(invalidStart, &deadline, now))
+}
+
+func TestGetRunningJobCountExcludesDrainedJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	now := time.Now()
+	mockListJobs(client, []batchv1.Job{
+		*getDrainingJob("drained", now.Add(-2*time.Minute)),
+		*getDrainingJob("draining", now),
+		{ObjectMeta: metav1.ObjectMeta{Name: "new"}},
+	})
+
+	deadline := int64(60)
+	scaledJob := getMockScaledJobWithDefault()
+	scaledJob.Spec.Rollout.DrainDeadlineSeconds = &deadline
+
+	assert.Equal(t, int64(2), scaleExecutor.getRunningJobCount(context.Background(), scaledJob))
+}
+
+func TestGetDrainingJobPods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	now := time.Now()
+	drained := getDrainingJob("drained", now.Add(-2*time.Minute))
+	drained.Status.Active = 3
+	draining := getDrainingJob("draining", now)
+	draining.Status.Active = 4
+	finished := getDrainingJob("finished", now)
+	finished.Status.Active = 5
+	finished.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: "True"}}
+	mockListJobs(client, []batchv1.Job{*drained, *draining, *finished})
+
+	deadline := int64(60)
+	scaledJob := getMockParallelismScaledJob()
+	scaledJob.Spec.Rollout.DrainDeadlineSeconds = &deadline
+
+	pods, err := scaleExecutor.getDrainingJobPods(context.Background(), scaledJob)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), pods, "only the pods of unfinished Jobs drained for less than the drain deadline count")
+}
+
+func TestTerminateDrainedJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	now := time.Now()
+	mockListJobs(client, []batchv1.Job{
+		*getDrainingJob("expired", now.Add(-2*time.Minute)),
+		*getDrainingJob("draining", now),
+	})
+
+	var deleted []string
+	client.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, obj runtimeclient.Object, _ ...runtimeclient.DeleteOption) {
+		deleted = append(deleted, obj.GetName())
+	}).Return(nil)
+
+	deadline := int64(60)
+	scaledJob := getMockScaledJobWithDefault()
+	scaledJob.Spec.Rollout.ActiveDeadlineSeconds = &deadline
+
+	err := scaleExecutor.terminateDrainedJobs(context.Background(), scaleExecutor.logger, scaledJob)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"expired"}, deleted)
+}
+
+func getDrainingJob(name string, drainStart time.Time) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{kedav1alpha1.ScaledJobDrainingLabel: "true"},
+			Annotations: map[string]string{kedav1alpha1.ScaledJobDrainStartAnnotation: drainStart.UTC().Format(time.RFC3339)},
+		},
+	}
+}
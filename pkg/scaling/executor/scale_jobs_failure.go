@@ -0,0 +1,220 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+const (
+	defaultFailureBackoffSeconds      = int32(10)
+	defaultFailureMaxBackoffSeconds   = int32(300)
+	defaultFailureThreshold           = int32(5)
+	defaultFailureCircuitResetSeconds = int32(600)
+)
+
+// applyFailurePolicy returns the number of Jobs to create according to the failurePolicy of the ScaledJob. The creation of Jobs
+// backs off exponentially after consecutive failed Jobs, and the circuit opens after failureThreshold consecutive failed Jobs:
+// no Job is created until circuitResetSeconds after the last failure, when a single Job is created to probe whether the Jobs recovered
+func (e *scaleExecutor) applyFailurePolicy(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, runningJobCount int64, now time.Time) int64 {
+	policy := scaledJob.Spec.FailurePolicy
+	if policy == nil {
+		return scaleTo
+	}
+
+	if err := e.updateJobFailures(ctx, scaledJob); err != nil {
+		logger.Error(err, "Failed to update the failed jobs, not applying the failure policy")
+		return scaleTo
+	}
+
+	failures := kedav1alpha1.ScaledJobFailures{}
+	if scaledJob.Status.JobFailures != nil {
+		failures = *scaledJob.Status.JobFailures
+	}
+
+	circuitOpen := failures.Consecutive >= int64(getFailureThreshold(policy))
+	if err := e.updateDegradedCondition(ctx, logger, scaledJob, circuitOpen, failures.Consecutive); err != nil {
+		logger.Error(err, "Failed to update the degraded condition")
+	}
+
+	if failures.Consecutive == 0 || failures.LastFailureTime == nil {
+		return scaleTo
+	}
+
+	if circuitOpen {
+		resetTime := failures.LastFailureTime.Add(time.Duration(getFailureCircuitResetSeconds(policy)) * time.Second)
+		if now.Before(resetTime) || runningJobCount > 0 {
+			logger.Info("Not creating jobs, the circuit is open", "Consecutive failed jobs", failures.Consecutive, "Reset time", resetTime)
+			return 0
+		}
+		logger.Info("Creating a single job to probe whether the jobs recovered", "Consecutive failed jobs", failures.Consecutive)
+		return min(scaleTo, 1)
+	}
+
+	backoffTime := failures.LastFailureTime.Add(getJobCreationBackoff(policy, failures.Consecutive))
+	if now.Before(backoffTime) {
+		logger.Info("Not creating jobs, backing off after failed jobs", "Consecutive failed jobs", failures.Consecutive, "Backoff time", backoffTime)
+		return 0
+	}
+	return scaleTo
+}
+
+// getJobCreationBackoff returns backoffSeconds doubled for each consecutive failed Job after the first one, capped by maxBackoffSeconds
+func getJobCreationBackoff(policy *kedav1alpha1.FailurePolicy, consecutiveFailures int64) time.Duration {
+	backoff := time.Duration(getFailureBackoffSeconds(policy)) * time.Second
+	maxBackoff := time.Duration(getFailureMaxBackoffSeconds(policy)) * time.Second
+	for i := int64(1); i < consecutiveFailures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// updateJobFailures accounts the Jobs of the ScaledJob finished since the last update in its status
+func (e *scaleExecutor) updateJobFailures(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) error {
+	opts := []client.ListOption{
+		client.InNamespace(scaledJob.GetNamespace()),
+		client.MatchingLabels(map[string]string{"scaledjob.keda.sh/name": scaledJob.GetName()}),
+	}
+	jobs := &batchv1.JobList{}
+	if err := e.client.List(ctx, jobs, opts...); err != nil {
+		return err
+	}
+	return e.accountJobFailures(ctx, scaledJob, jobs.Items)
+}
+
+// accountJobFailures updates the streak of consecutive failed Jobs in the status of the ScaledJob with the finished Jobs
+// which weren't accounted yet, a succeeded Job resets the streak. The Jobs are tracked by name rather than by the time they
+// finished, as Jobs finished within the same second may be listed by different updates. The status is only patched when a Job was accounted
+func (e *scaleExecutor) accountJobFailures(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, jobs []batchv1.Job) error {
+	failures := &kedav1alpha1.ScaledJobFailures{}
+	if scaledJob.Status.JobFailures != nil {
+		failures = scaledJob.Status.JobFailures.DeepCopy()
+	}
+	accountedJobs := make(map[string]bool, len(failures.AccountedJobs))
+	for _, name := range failures.AccountedJobs {
+		accountedJobs[name] = true
+	}
+
+	var finishedJobs []batchv1.Job
+	// the Jobs cleaned up according to the history limits are dropped, so the accounted Jobs are bounded by the history limits
+	failures.AccountedJobs = nil
+	for _, job := range jobs {
+		job := job
+		if e.getFinishedJobConditionType(&job) == "" {
+			continue
+		}
+		failures.AccountedJobs = append(failures.AccountedJobs, job.Name)
+		if !accountedJobs[job.Name] {
+			finishedJobs = append(finishedJobs, job)
+		}
+	}
+	if len(finishedJobs) == 0 {
+		return nil
+	}
+	sort.Strings(failures.AccountedJobs)
+	sort.SliceStable(finishedJobs, func(i, j int) bool {
+		return getJobFinishedTime(&finishedJobs[i]).Before(getJobFinishedTime(&finishedJobs[j]))
+	})
+
+	for _, job := range finishedJobs {
+		job := job
+		if e.getFinishedJobConditionType(&job) == batchv1.JobFailed {
+			finishedTime := metav1.NewTime(getJobFinishedTime(&job))
+			failures.Consecutive++
+			failures.LastFailureTime = &finishedTime
+		} else {
+			failures.Consecutive = 0
+			failures.LastFailureTime = nil
+		}
+	}
+
+	patch := client.MergeFrom(scaledJob.DeepCopy())
+	scaledJob.Status.JobFailures = failures
+	return e.client.Status().Patch(ctx, scaledJob, patch)
+}
+
+// getJobFinishedTime returns the time the Complete or Failed condition of the Job was set
+func getJobFinishedTime(j *batchv1.Job) time.Time {
+	for _, c := range j.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return c.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
+
+// updateDegradedCondition reports the open circuit in the Degraded condition of the ScaledJob, with an Event when the circuit opens or closes
+func (e *scaleExecutor) updateDegradedCondition(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, circuitOpen bool, consecutiveFailures int64) error {
+	condition := scaledJob.Status.Conditions.GetDegradedCondition()
+	if !condition.IsUnknown() && condition.IsTrue() == circuitOpen {
+		return nil
+	}
+
+	if circuitOpen {
+		msg := fmt.Sprintf("Stopped creating jobs after %d consecutive failed jobs", consecutiveFailures)
+		e.recorder.Event(scaledJob, corev1.EventTypeWarning, eventreason.ScaledJobDegraded, msg)
+		return e.setDegradedCondition(ctx, logger, scaledJob, metav1.ConditionTrue, "JobsFailing", msg)
+	}
+
+	if condition.IsTrue() {
+		e.recorder.Event(scaledJob, corev1.EventTypeNormal, eventreason.ScaledJobRecovered, "Resumed creating jobs after a succeeded job")
+	}
+	return e.setDegradedCondition(ctx, logger, scaledJob, metav1.ConditionFalse, "JobsNotFailing", "Jobs are created because the jobs aren't failing")
+}
+
+func getFailureBackoffSeconds(policy *kedav1alpha1.FailurePolicy) int32 {
+	if policy.BackoffSeconds != nil {
+		return *policy.BackoffSeconds
+	}
+	return defaultFailureBackoffSeconds
+}
+
+func getFailureMaxBackoffSeconds(policy *kedav1alpha1.FailurePolicy) int32 {
+	if policy.MaxBackoffSeconds != nil {
+		return *policy.MaxBackoffSeconds
+	}
+	return defaultFailureMaxBackoffSeconds
+}
+
+func getFailureThreshold(policy *kedav1alpha1.FailurePolicy) int32 {
+	if policy.FailureThreshold != nil {
+		return *policy.FailureThreshold
+	}
+	return defaultFailureThreshold
+}
+
+func getFailureCircuitResetSeconds(policy *kedav1alpha1.FailurePolicy) int32 {
+	if policy.CircuitResetSeconds != nil {
+		return *policy.CircuitResetSeconds
+	}
+	return defaultFailureCircuitResetSeconds
+}
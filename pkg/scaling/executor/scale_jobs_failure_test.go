@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+)
+
+func TestGetJobCreationBackoff(t *testing.T) {
+	backoffSeconds := int32(10)
+	maxBackoffSeconds := int32(60)
+	policy := &kedav1alpha1.FailurePolicy{BackoffSeconds: &backoffSeconds, MaxBackoffSeconds: &maxBackoffSeconds}
+
+	assert.Equal(t, 10*time.Second, getJobCreationBackoff(policy, 1))
+	assert.Equal(t, 20*time.Second, getJobCreationBackoff(policy, 2))
+	assert.Equal(t, 40*time.Second, getJobCreationBackoff(policy, 3))
+	assert.Equal(t, 60*time.Second, getJobCreationBackoff(policy, 4))
+	assert.Equal(t, 60*time.Second, getJobCreationBackoff(policy, 1000))
+}
+
+func TestAccountJobFailuresCountsFailedJobsSinceLastSucceededJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	scaledJob := getMockScaledJobWithDefault()
+	now := time.Now().Truncate(time.Second)
+
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	err := scaleExecutor.accountJobFailures(context.Background(), scaledJob, []batchv1.Job{
+		*getJob(t, "failed-before-success", formatTime(now.Add(-time.Hour)), batchv1.JobFailed),
+		*getJob(t, "failed-1", formatTime(now.Add(-2*time.Minute)), batchv1.JobFailed),
+		*getJob(t, "succeeded", formatTime(now.Add(-30*time.Minute)), batchv1.JobComplete),
+		*getJob(t, "failed-2", formatTime(now.Add(-time.Minute)), batchv1.JobFailed),
+		{ObjectMeta: metav1.ObjectMeta{Name: "running"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), scaledJob.Status.JobFailures.Consecutive)
+	assert.True(t, scaledJob.Status.JobFailures.LastFailureTime.Time.Equal(now.Add(-time.Minute)))
+
+	// the failed jobs are still counted once they are cleaned up, and the accounted jobs aren't counted again
+	jobs := []batchv1.Job{
+		*getJob(t, "failed-2", formatTime(now.Add(-time.Minute)), batchv1.JobFailed),
+		*getJob(t, "failed-3", formatTime(now), batchv1.JobFailed),
+	}
+	assert.NoError(t, scaleExecutor.accountJobFailures(context.Background(), scaledJob, jobs))
+	assert.Equal(t, int64(3), scaledJob.Status.JobFailures.Consecutive)
+	assert.True(t, scaledJob.Status.JobFailures.LastFailureTime.Time.Equal(now))
+
+	// the status isn't patched without newly finished jobs
+	assert.NoError(t, scaleExecutor.accountJobFailures(context.Background(), scaledJob, jobs))
+	assert.Equal(t, int64(3), scaledJob.Status.JobFailures.Consecutive)
+}
+
+func TestAccountJobFailuresCountsJobsFinishedInTheSameSecond(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	scaledJob := getMockScaledJobWithDefault()
+	now := time.Now().Truncate(time.Second)
+
+	client.EXPECT().Status().Return(statusWriter).Times(2)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	failed1 := *getJob(t, "failed-1", formatTime(now), batchv1.JobFailed)
+	assert.NoError(t, scaleExecutor.accountJobFailures(context.Background(), scaledJob, []batchv1.Job{failed1}))
+	assert.Equal(t, int64(1), scaledJob.Status.JobFailures.Consecutive)
+
+	// the second job finished in the same second is only listed by the next update
+	failed2 := *getJob(t, "failed-2", formatTime(now), batchv1.JobFailed)
+	assert.NoError(t, scaleExecutor.accountJobFailures(context.Background(), scaledJob, []batchv1.Job{failed1, failed2}))
+	assert.Equal(t, int64(2), scaledJob.Status.JobFailures.Consecutive)
+	assert.Equal(t, []string{"failed-1", "failed-2"}, scaledJob.Status.JobFailures.AccountedJobs)
+}
+
+func TestApplyFailurePolicyBacksOff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	now := time.Now().Truncate(time.Second)
+	scaledJob := getMockFailurePolicyScaledJob()
+
+	client.EXPECT().Status().Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	// the backoff after 2 failed jobs is 20 seconds
+	failedJobs := []batchv1.Job{
+		*getJob(t, "failed-1", formatTime(now.Add(-time.Minute)), batchv1.JobFailed),
+		*getJob(t, "failed-2", formatTime(now.Add(-15*time.Second)), batchv1.JobFailed),
+	}
+	mockListJobs(client, failedJobs)
+	assert.Equal(t, int64(0), scaleExecutor.applyFailurePolicy(context.Background(), scaleExecutor.logger, scaledJob, 3, 0, now))
+
+	mockListJobs(client, failedJobs)
+	assert.Equal(t, int64(3), scaleExecutor.applyFailurePolicy(context.Background(), scaleExecutor.logger, scaledJob, 3, 0, now.Add(10*time.Second)))
+	assert.Empty(t, scaledJob.Status.Conditions.GetDegradedCondition().Status, "the degraded condition is only set once the circuit opens")
+}
+
+func TestApplyFailurePolicyOpensCircuit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	now := time.Now().Truncate(time.Second)
+	scaledJob := getMockFailurePolicyScaledJob()
+
+	failedJobs := []batchv1.Job{
+		*getJob(t, "failed-1", formatTime(now.Add(-3*time.Hour)), batchv1.JobFailed),
+		*getJob(t, "failed-2", formatTime(now.Add(-2*time.Hour)), batchv1.JobFailed),
+		*getJob(t, "failed-3", formatTime(now.Add(-time.Minute)), batchv1.JobFailed),
+	}
+	client.EXPECT().Status().Return(statusWriter).Times(4)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(4)
+
+	mockListJobs(client, failedJobs)
+	assert.Equal(t, int64(0), scaleExecutor.applyFailurePolicy(context.Background(), scaleExecutor.logger, scaledJob, 3, 0, now))
+	degraded := scaledJob.Status.Conditions.GetDegradedCondition()
+	assert.True(t, degraded.IsTrue())
+	assert.Contains(t, <-scaleExecutor.recorder.(*record.FakeRecorder).Events, "ScaledJobDegraded")
+
+	// a single job probes whether the jobs recovered after circuitResetSeconds, once no job is running
+	mockListJobs(client, failedJobs)
+	assert.Equal(t, int64(0), scaleExecutor.applyFailurePolicy(context.Background(), scaleExecutor.logger, scaledJob, 3, 1, now.Add(time.Hour)))
+	mockListJobs(client, failedJobs)
+	assert.Equal(t, int64(1), scaleExecutor.applyFailurePolicy(context.Background(), scaleExecutor.logger, scaledJob, 3, 0, now.Add(time.Hour)))
+
+	// the circuit closes once a job succeeds
+	mockListJobs(client, []batchv1.Job{*getJob(t, "succeeded", formatTime(now.Add(time.Hour)), batchv1.JobComplete)})
+	assert.Equal(t, int64(3), scaleExecutor.applyFailurePolicy(context.Background(), scaleExecutor.logger, scaledJob, 3, 0, now.Add(time.Hour)))
+	degraded = scaledJob.Status.Conditions.GetDegradedCondition()
+	assert.True(t, degraded.IsFalse())
+	assert.Contains(t, <-scaleExecutor.recorder.(*record.FakeRecorder).Events, "ScaledJobRecovered")
+}
+
+func TestScaleJobParallelismAppliesFailurePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	scaledJob := getMockFailurePolicyScaledJob()
+	scaledJob.Spec.ScalingMode = kedav1alpha1.ScaledJobScalingModeParallelism
+
+	client.EXPECT().Status().Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	// the failed Job isn't created again before the backoff
+	failedJobs := []batchv1.Job{*getJob(t, "failed", formatTime(time.Now()), batchv1.JobFailed)}
+	mockListJobs(client, failedJobs)
+	mockListJobs(client, failedJobs)
+	assert.Nil(t, scaleExecutor.scaleJobParallelism(context.Background(), scaleExecutor.logger, scaledJob, 3))
+}
+
+func getMockFailurePolicyScaledJob() *kedav1alpha1.ScaledJob {
+	backoffSeconds := int32(10)
+	failureThreshold := int32(3)
+	circuitResetSeconds := int32(1800)
+	scaledJob := getMockScaledJobWithDefault()
+	scaledJob.Spec.FailurePolicy = &kedav1alpha1.FailurePolicy{BackoffSeconds: &backoffSeconds, FailureThreshold: &failureThreshold, CircuitResetSeconds: &circuitResetSeconds}
+	scaledJob.Status.Conditions = *kedav1alpha1.GetInitializedConditions()
+	return scaledJob
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
+)
+
+// getJobParallelism returns the parallelism of the Job of a ScaledJob in parallelism mode, the scale bounded by the min and max
+// replica counts. It's the min replica count if the triggers aren't active, so the Job is only suspended if it's zero.
+// The active pods of the drained Jobs of a previous version of the ScaledJob count toward the max replica count
+func getJobParallelism(scaledJob *kedav1alpha1.ScaledJob, isActive bool, maxScale int64, drainingJobPods int64) int64 {
+	minReplicaCount := scaledJob.MinReplicaCount()
+	// MaxReplicaCount is the number of Jobs above the min replica count
+	maxReplicaCount := max(scaledJob.MaxReplicaCount()+minReplicaCount-drainingJobPods, 0)
+	if !isActive {
+		return min(minReplicaCount, maxReplicaCount)
+	}
+	return min(max(maxScale, minReplicaCount), maxReplicaCount)
+}
+
+// scaleJobParallelism scales the parallelism of the Job of a ScaledJob in parallelism mode, the Job is suspended when
+// the parallelism is zero and created if there is no unfinished Job. It returns the created Job, if any, as a batch of one Job
+func (e *scaleExecutor) scaleJobParallelism(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, parallelism int64) *kedav1alpha1.ScaledJobJobsCreation {
+	job, err := e.getParallelismJob(ctx, scaledJob)
+	if err != nil {
+		logger.Error(err, "Failed to get the Job to scale")
+		return nil
+	}
+
+	suspend := parallelism == 0
+	jobParallelism := int32(parallelism)
+
+	if job == nil {
+		if suspend {
+			return nil
+		}
+		// the Job is created again after it failed according to the failure policy
+		if e.applyFailurePolicy(ctx, logger, scaledJob, 1, 0, time.Now()) == 0 {
+			return nil
+		}
+
+		job = e.newJob(logger, scaledJob)
+		job.Spec.Parallelism = &jobParallelism
+		job.Spec.Suspend = &suspend
+		if err := e.client.Create(ctx, job); err != nil {
+			logger.Error(err, "Failed to create a new Job")
+			prommetrics.RecordScaledJobJobsCreated(scaledJob.Namespace, scaledJob.Name, 0, 1)
+			return &kedav1alpha1.ScaledJobJobsCreation{Time: metav1.Now(), RequestedJobs: 1, CreatedJobs: 0}
+		}
+		prommetrics.RecordScaledJobJobsCreated(scaledJob.Namespace, scaledJob.Name, 1, 0)
+		logger.Info("Created job", "Parallelism", parallelism)
+		e.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreated, "Created job with parallelism %d", parallelism)
+		return &kedav1alpha1.ScaledJobJobsCreation{Time: metav1.Now(), RequestedJobs: 1, CreatedJobs: 1}
+	}
+
+	// the parallelism of a suspended Job is kept, so it resumes with it until the next scaling
+	previous := job.DeepCopy()
+	job.Spec.Suspend = &suspend
+	if !suspend {
+		job.Spec.Parallelism = &jobParallelism
+	}
+	if equality.Semantic.DeepEqual(previous.Spec, job.Spec) {
+		return nil
+	}
+
+	if err := e.client.Patch(ctx, job, client.MergeFrom(previous)); err != nil {
+		logger.Error(err, "Failed to scale the parallelism of the Job", "job.Name", job.Name)
+		return nil
+	}
+	logger.Info("Scaled job", "job.Name", job.Name, "Parallelism", parallelism, "Suspended", suspend)
+	return nil
+}
+
+// getParallelismJob returns the newest unfinished Job of the ScaledJob which isn't drained, or nil if there is none
+func (e *scaleExecutor) getParallelismJob(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (*batchv1.Job, error) {
+	opts := []client.ListOption{
+		client.InNamespace(scaledJob.GetNamespace()),
+		client.MatchingLabels(map[string]string{"scaledjob.keda.sh/name": scaledJob.GetName()}),
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := e.client.List(ctx, jobs, opts...); err != nil {
+		return nil, err
+	}
+
+	var newest *batchv1.Job
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		// the drained Jobs of a previous version of the ScaledJob are left to finish
+		if e.isJobFinished(job) || job.Labels[kedav1alpha1.ScaledJobDrainingLabel] == "true" {
+			continue
+		}
+		if newest == nil || newest.CreationTimestamp.Before(&job.CreationTimestamp) {
+			newest = job
+		}
+	}
+	return newest, nil
+}
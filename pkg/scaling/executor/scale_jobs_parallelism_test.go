@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+)
+
+func TestGetJobParallelism(t *testing.T) {
+	minReplicaCount := int32(1)
+	maxReplicaCount := int32(10)
+	scaledJob := &kedav1alpha1.ScaledJob{
+		Spec: kedav1alpha1.ScaledJobSpec{MinReplicaCount: &minReplicaCount, MaxReplicaCount: &maxReplicaCount},
+	}
+
+	assert.Equal(t, int64(1), getJobParallelism(scaledJob, false, 5, 0), "the Job keeps the min replica count when the triggers aren't active")
+	assert.Equal(t, int64(5), getJobParallelism(scaledJob, true, 5, 0))
+	assert.Equal(t, int64(1), getJobParallelism(scaledJob, true, 0, 0))
+	assert.Equal(t, int64(10), getJobParallelism(scaledJob, true, 20, 0))
+
+	minReplicaCount = 0
+	assert.Equal(t, int64(0), getJobParallelism(scaledJob, false, 5, 0), "the Job is suspended when the triggers aren't active")
+}
+
+func TestGetJobParallelismWithDrainingJobs(t *testing.T) {
+	minReplicaCount := int32(1)
+	maxReplicaCount := int32(10)
+	scaledJob := &kedav1alpha1.ScaledJob{
+		Spec: kedav1alpha1.ScaledJobSpec{MinReplicaCount: &minReplicaCount, MaxReplicaCount: &maxReplicaCount},
+	}
+
+	assert.Equal(t, int64(6), getJobParallelism(scaledJob, true, 20, 4), "the pods of the drained Jobs count toward the max replica count")
+	assert.Equal(t, int64(5), getJobParallelism(scaledJob, true, 5, 4))
+	assert.Equal(t, int64(0), getJobParallelism(scaledJob, true, 20, 10))
+	assert.Equal(t, int64(1), getJobParallelism(scaledJob, false, 20, 4))
+	assert.Equal(t, int64(0), getJobParallelism(scaledJob, false, 20, 10), "the min replica count isn't kept above the max replica count")
+}
+
+func TestScaleJobParallelismCreatesJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	mockListJobs(client, []batchv1.Job{*getJob(t, "finished", "2020-07-29T15:37:00Z", batchv1.JobComplete)})
+
+	var created *batchv1.Job
+	client.EXPECT().Create(gomock.Any(), gomock.Any()).Do(func(_ context.Context, obj runtimeclient.Object, _ ...runtimeclient.CreateOption) {
+		created = obj.(*batchv1.Job)
+	}).Return(nil)
+
+	jobsCreation := scaleExecutor.scaleJobParallelism(context.Background(), scaleExecutor.logger, getMockParallelismScaledJob(), 3)
+
+	assert.Equal(t, int32(3), *created.Spec.Parallelism)
+	assert.False(t, *created.Spec.Suspend)
+	assert.Equal(t, int64(1), jobsCreation.CreatedJobs)
+}
+
+func TestScaleJobParallelismDoesNotCreateSuspendedJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	mockListJobs(client, []batchv1.Job{})
+
+	jobsCreation := scaleExecutor.scaleJobParallelism(context.Background(), scaleExecutor.logger, getMockParallelismScaledJob(), 0)
+
+	assert.Nil(t, jobsCreation)
+}
+
+func TestScaleJobParallelismScalesNewestJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+
+	parallelism := int32(1)
+	oldJob := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "old", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))}, Spec: batchv1.JobSpec{Parallelism: &parallelism}}
+	newJob := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "new", CreationTimestamp: metav1.Now()}, Spec: batchv1.JobSpec{Parallelism: &parallelism}}
+	mockListJobs(client, []batchv1.Job{oldJob, newJob})
+
+	var patched *batchv1.Job
+	client.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, obj runtimeclient.Object, _ runtimeclient.Patch, _ ...runtimeclient.PatchOption) {
+		patched = obj.(*batchv1.Job)
+	}).Return(nil).Times(2)
+
+	jobsCreation := scaleExecutor.scaleJobParallelism(context.Background(), scaleExecutor.logger, getMockParallelismScaledJob(), 4)
+	assert.Nil(t, jobsCreation)
+	assert.Equal(t, "new", patched.Name)
+	assert.Equal(t, int32(4), *patched.Spec.Parallelism)
+	assert.False(t, *patched.Spec.Suspend)
+
+	// the Job is suspended and keeps its parallelism when the triggers aren't active
+	mockListJobs(client, []batchv1.Job{*patched})
+	scaleExecutor.scaleJobParallelism(context.Background(), scaleExecutor.logger, getMockParallelismScaledJob(), 0)
+	assert.Equal(t, int32(4), *patched.Spec.Parallelism)
+	assert.True(t, *patched.Spec.Suspend)
+
+	// the Job isn't patched when its parallelism doesn't change
+	mockListJobs(client, []batchv1.Job{*patched})
+	scaleExecutor.scaleJobParallelism(context.Background(), scaleExecutor.logger, getMockParallelismScaledJob(), 0)
+}
+
+func mockListJobs(client *mock_client.MockClient, jobs []batchv1.Job) {
+	client.EXPECT().
+		List(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, list runtime.Object, _ ...runtimeclient.ListOption) {
+		j := list.(*batchv1.JobList)
+		for _, job := range jobs {
+			j.Items = append(j.Items, *job.DeepCopy())
+		}
+	}).
+		Return(nil)
+}
+
+func getMockParallelismScaledJob() *kedav1alpha1.ScaledJob {
+	scaledJob := &kedav1alpha1.ScaledJob{
+		Spec: kedav1alpha1.ScaledJobSpec{
+			JobTargetRef: &batchv1.JobSpec{},
+			ScalingMode:  kedav1alpha1.ScaledJobScalingModeParallelism,
+		},
+	}
+	scaledJob.ObjectMeta.Name = "azure-storage-queue-consumer"
+	return scaledJob
+}
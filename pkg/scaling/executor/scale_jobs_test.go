@@ -18,6 +18,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -28,6 +29,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -286,6 +289,107 @@ func TestGetPendingJobCount(t *testing.T) {
 	}
 }
 
+func TestUpdateJobsStatus(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	scaledJob := getMockScaledJobWithDefault()
+
+	decision := kedav1alpha1.ScaledJobScalingDecision{QueueLength: 5, MaxScale: 5, EffectiveMaxScale: 3}
+	jobsCreation := &kedav1alpha1.ScaledJobJobsCreation{Time: metav1.Now(), RequestedJobs: 5, CreatedJobs: 3}
+
+	// the status is patched once, as it doesn't change the second time
+	client.EXPECT().Status().Return(statusWriter).Times(1)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	err := scaleExecutor.updateJobsStatus(ctx, scaledJob, 2, 1, decision, jobsCreation)
+	assert.NoError(t, err)
+	err = scaleExecutor.updateJobsStatus(ctx, scaledJob, 2, 1, decision, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(2), *scaledJob.Status.RunningJobs)
+	assert.Equal(t, int64(1), *scaledJob.Status.PendingJobs)
+	assert.Equal(t, decision, *scaledJob.Status.LastScalingDecision)
+	assert.Equal(t, jobsCreation, scaledJob.Status.LastJobsCreation)
+}
+
+func TestCreateJobsRecordsCreatedJobs(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_client.NewMockClient(ctrl)
+	scaleExecutor := getMockScaleExecutor(client)
+	recorder := scaleExecutor.recorder.(*record.FakeRecorder)
+	scaledJob := getMockScaledJobWithDefault()
+	scaledJob.Spec.JobTargetRef = &batchv1.JobSpec{}
+
+	// no event is recorded when no Job is requested
+	assert.Nil(t, scaleExecutor.createJobs(ctx, scaleExecutor.logger, scaledJob, 0, 10, "", 0))
+	assert.Empty(t, recorder.Events)
+
+	// the event reports the created Jobs
+	gomock.InOrder(
+		client.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+		client.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("quota exceeded")),
+	)
+	jobsCreation := scaleExecutor.createJobs(ctx, scaleExecutor.logger, scaledJob, 2, 10, "", 2)
+	assert.Equal(t, int64(1), jobsCreation.CreatedJobs)
+	assert.Contains(t, <-recorder.Events, "Created 1 jobs")
+
+	// no event is recorded when no Job could be created
+	client.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("quota exceeded"))
+	jobsCreation = scaleExecutor.createJobs(ctx, scaleExecutor.logger, scaledJob, 1, 10, "", 1)
+	assert.Equal(t, int64(0), jobsCreation.CreatedJobs)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestSetJobBatchParameters(t *testing.T) {
+	job := &batchv1.Job{
+		Spec: batchv1.JobSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					InitContainers: []v1.Container{{Name: "init"}},
+					Containers: []v1.Container{
+						{Name: "worker"},
+						{Name: "overridden", Env: []v1.EnvVar{{Name: jobTriggerEnv, Value: "custom"}}},
+					},
+				},
+			},
+		},
+	}
+
+	setJobBatchParameters(job, 2, 5, "orders", 42)
+
+	expectedAnnotations := map[string]string{
+		jobIndexAnnotation:       "2",
+		jobBatchSizeAnnotation:   "5",
+		jobTriggerAnnotation:     "orders",
+		jobQueueLengthAnnotation: "42",
+	}
+	assert.Equal(t, expectedAnnotations, job.Annotations)
+	assert.Equal(t, expectedAnnotations, job.Spec.Template.Annotations)
+
+	expectedEnv := []v1.EnvVar{
+		{Name: jobIndexEnv, Value: "2"},
+		{Name: jobBatchSizeEnv, Value: "5"},
+		{Name: jobTriggerEnv, Value: "orders"},
+		{Name: jobQueueLengthEnv, Value: "42"},
+	}
+	assert.Equal(t, expectedEnv, job.Spec.Template.Spec.InitContainers[0].Env)
+	assert.Equal(t, expectedEnv, job.Spec.Template.Spec.Containers[0].Env)
+	assert.Equal(t, []v1.EnvVar{
+		{Name: jobTriggerEnv, Value: "custom"},
+		{Name: jobIndexEnv, Value: "2"},
+		{Name: jobBatchSizeEnv, Value: "5"},
+		{Name: jobQueueLengthEnv, Value: "42"},
+	}, job.Spec.Template.Spec.Containers[1].Env)
+}
+
 type mockJobParameter struct {
 	Name             string
 	CompletionTime   string
@@ -299,11 +403,14 @@ type pendingJobTestData struct {
 }
 
 func getMockScaleExecutor(client *mock_client.MockClient) *scaleExecutor {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
 	return &scaleExecutor{
 		client:           client,
 		scaleClient:      nil,
-		reconcilerScheme: nil,
+		reconcilerScheme: scheme,
 		logger:           logf.Log.WithName("scaleexecutor"),
+		recorder:         record.NewFakeRecorder(10),
 	}
 }
 
@@ -464,8 +571,9 @@ func getJob(t *testing.T, name string, completionTime string, jobConditionType b
 		Status: batchv1.JobStatus{
 			Conditions: []batchv1.JobCondition{
 				{
-					Type:   jobConditionType,
-					Status: v1.ConditionTrue,
+					Type:               jobConditionType,
+					Status:             v1.ConditionTrue,
+					LastTransitionTime: completionTimeT,
 				},
 			},
 			CompletionTime: &completionTimeT,
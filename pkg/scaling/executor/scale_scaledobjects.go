@@ -31,6 +31,7 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
 )
 
 func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool) {
@@ -81,6 +82,18 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		}
 	}
 
+	// if the ScaledObject's triggers aren't in the error state and no metric is falling back in the metrics server,
+	// but ScaledObject.Status.FallbackCondition is still set to 'true' -> the triggers recovered, set it to 'false'
+	fallbackCondition := scaledObject.Status.Conditions.GetFallbackCondition()
+	if !isError && fallbackCondition.IsTrue() && !hasFailingMetrics(scaledObject) {
+		if err := e.setFallbackCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "NoFallbackFound", "No fallbacks are active on this scaled object"); err != nil {
+			logger.Error(err, "Error setting fallback condition")
+		} else {
+			e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetFallbackExited,
+				"Triggers of %s %s/%s recovered from fallback", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name)
+		}
+	}
+
 	// Check if we are paused, and if we are then update the scale to the desired count.
 	pausedCount, err := GetPausedReplicaCount(scaledObject)
 	if err != nil {
@@ -112,6 +125,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 				return
 			}
 			logger.Info("Successfully scaled target to paused replicas count", "paused replicas", *pausedCount)
+			prommetrics.RecordScaleEvent(scaledObject.Namespace, scaledObject.Name, prommetrics.ScaleEventPaused, *pausedCount)
 		}
 		return
 	}
@@ -205,6 +219,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 				logger.Info("Successfully set ScaleTarget replicas count to ScaledObject minReplicaCount",
 					"Original Replicas Count", currentReplicas,
 					"New Replicas Count", *scaledObject.Spec.MinReplicaCount)
+				prommetrics.RecordScaleEvent(scaledObject.Namespace, scaledObject.Name, prommetrics.ScaleEventMinReplicas, *scaledObject.Spec.MinReplicaCount)
 			}
 		default:
 			// there are no active triggers
@@ -236,12 +251,31 @@ func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *ked
 		logger.Info("Successfully set ScaleTarget replicas count to ScaledObject fallback.replicas",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", scaledObject.Spec.Fallback.Replicas)
+		prommetrics.RecordScaleEvent(scaledObject.Namespace, scaledObject.Name, prommetrics.ScaleEventFallback, scaledObject.Spec.Fallback.Replicas)
+	}
+	if fallbackCondition := scaledObject.Status.Conditions.GetFallbackCondition(); !fallbackCondition.IsTrue() {
+		e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetFallbackEntered,
+			"Scaled %s %s/%s to fallback replicas count %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, scaledObject.Spec.Fallback.Replicas)
 	}
 	if e := e.setFallbackCondition(ctx, logger, scaledObject, metav1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled object"); e != nil {
 		logger.Error(e, "Error setting fallback condition")
 	}
 }
 
+// hasFailingMetrics returns whether a metric of the ScaledObject failed more times than the fallback failure threshold,
+// the metrics server keeps the Fallback condition true until the metric is read again
+func hasFailingMetrics(scaledObject *kedav1alpha1.ScaledObject) bool {
+	if scaledObject.Spec.Fallback == nil {
+		return false
+	}
+	for _, health := range scaledObject.Status.Health {
+		if health.Status == kedav1alpha1.HealthStatusFailing && health.NumberOfFailures != nil && *health.NumberOfFailures > scaledObject.Spec.Fallback.FailureThreshold {
+			return true
+		}
+	}
+	return false
+}
+
 // An object will be scaled down to 0 only if it's passed its cooldown period
 // or if LastActiveTime is nil
 func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
@@ -270,6 +304,7 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 				msg += " minReplicaCount"
 			}
 			logger.Info(msg, "Original Replicas Count", currentReplicas, "New Replicas Count", scaleToReplicas)
+			prommetrics.RecordScaleEvent(scaledObject.Namespace, scaledObject.Name, prommetrics.ScaleEventDeactivated, scaleToReplicas)
 
 			e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated,
 				"Deactivated %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, scaleToReplicas)
@@ -310,6 +345,7 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 		logger.Info("Successfully updated ScaleTarget",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", replicas)
+		prommetrics.RecordScaleEvent(scaledObject.Namespace, scaledObject.Name, prommetrics.ScaleEventActivated, replicas)
 		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
 
 		// Scale was successful. Update lastScaleTime and lastActiveTime on the scaledObject
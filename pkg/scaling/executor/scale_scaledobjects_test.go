@@ -28,6 +28,7 @@ import (
 	"k8s.io/client-go/tools/record"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_scale"
 )
@@ -92,6 +93,117 @@ func TestScaleToFallbackReplicasWhenNotActiveAndIsError(t *testing.T) {
 	assert.Equal(t, int32(5), scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetFallbackCondition()
 	assert.Equal(t, true, condition.IsTrue())
+	assert.Contains(t, <-recorder.Events, eventreason.KEDAScaleTargetFallbackEntered)
+}
+
+func TestFallbackIsExitedWhenNotError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, nil, nil, recorder)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			Fallback: &v1alpha1.Fallback{
+				FailureThreshold: 3,
+				Replicas:         5,
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+	scaledObject.Status.Conditions.SetReadyCondition(v1.ConditionTrue, "ScaledObjectReady", "")
+	scaledObject.Status.Conditions.SetActiveCondition(v1.ConditionTrue, "ScalerActive", "")
+	scaledObject.Status.Conditions.SetFallbackCondition(v1.ConditionTrue, "FallbackExists", "")
+
+	numberOfReplicas := int32(5)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+	// fallback condition and last active time are updated
+	client.EXPECT().Status().Times(2).Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false)
+
+	condition := scaledObject.Status.Conditions.GetFallbackCondition()
+	assert.Equal(t, true, condition.IsFalse())
+	assert.Contains(t, <-recorder.Events, eventreason.KEDAScaleTargetFallbackExited)
+}
+
+func TestFallbackIsNotExitedWhenMetricsAreFailing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, nil, nil, recorder)
+
+	numberOfFailures := int32(4)
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			Fallback: &v1alpha1.Fallback{
+				FailureThreshold: 3,
+				Replicas:         5,
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+			Health: map[string]v1alpha1.HealthStatus{
+				"s0-metric": {NumberOfFailures: &numberOfFailures, Status: v1alpha1.HealthStatusFailing},
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+	scaledObject.Status.Conditions.SetReadyCondition(v1.ConditionTrue, "ScaledObjectReady", "")
+	scaledObject.Status.Conditions.SetActiveCondition(v1.ConditionTrue, "ScalerActive", "")
+	scaledObject.Status.Conditions.SetFallbackCondition(v1.ConditionTrue, "FallbackExists", "")
+
+	numberOfReplicas := int32(5)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+	// only the last active time is updated
+	client.EXPECT().Status().Times(1).Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false)
+
+	condition := scaledObject.Status.Conditions.GetFallbackCondition()
+	assert.Equal(t, true, condition.IsTrue())
+	assert.Empty(t, recorder.Events)
 }
 
 func TestScaleToMinReplicasWhenNotActive(t *testing.T) {
@@ -0,0 +1,302 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"strconv"
+
+	"k8s.io/api/autoscaling/v2beta2"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+var errDivisionByZero = errors.New("division by zero")
+
+// Validate checks that ScalingModifiers defined in the ScaledObject are correctly specified,
+// ie. that the formula is parsable and references only named external triggers of the ScaledObject
+func Validate(scaledObject *kedav1alpha1.ScaledObject) error {
+	if !scaledObject.IsUsingModifiers() {
+		return nil
+	}
+	sm := scaledObject.Spec.Advanced.ScalingModifiers
+
+	if sm.Formula == "" {
+		return fmt.Errorf("ScaledObject.spec.advanced.scalingModifiers.formula is missing")
+	}
+	if _, err := parseTarget(sm.Target); err != nil {
+		return fmt.Errorf("ScaledObject.spec.advanced.scalingModifiers.target is invalid: %s", err)
+	}
+	if sm.ActivationTarget != "" {
+		if _, err := parseTarget(sm.ActivationTarget); err != nil {
+			return fmt.Errorf("ScaledObject.spec.advanced.scalingModifiers.activationTarget is invalid: %s", err)
+		}
+	}
+	if sm.MetricType == v2beta2.UtilizationMetricType {
+		return fmt.Errorf("ScaledObject.spec.advanced.scalingModifiers.metricType %s is not supported", sm.MetricType)
+	}
+
+	triggers := make(map[string]kedav1alpha1.ScaleTriggers, len(scaledObject.Spec.Triggers))
+	for _, trigger := range scaledObject.Spec.Triggers {
+		if trigger.Name == "" {
+			continue
+		}
+		if _, exists := triggers[trigger.Name]; exists {
+			return fmt.Errorf("trigger name %s is defined multiple times in ScaledObject", trigger.Name)
+		}
+		triggers[trigger.Name] = trigger
+	}
+
+	names, err := ReferencedTriggers(sm.Formula)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("formula %q doesn't reference any trigger", sm.Formula)
+	}
+	values := make(map[string]float64, len(names))
+	for _, name := range names {
+		trigger, exists := triggers[name]
+		if !exists {
+			return fmt.Errorf("formula references trigger %s which is not defined in ScaledObject", name)
+		}
+		if trigger.Type == "cpu" || trigger.Type == "memory" {
+			return fmt.Errorf("formula references %s trigger %s, only triggers providing external metrics can be used in formula", trigger.Type, name)
+		}
+		if trigger.UseCachedMetrics {
+			return fmt.Errorf("formula references trigger %s with useCachedMetrics, which is not supported for triggers combined into the composite metric", name)
+		}
+		values[name] = 1
+	}
+
+	// dry run the formula to catch unsupported operators and functions
+	if _, err := Evaluate(sm.Formula, values); err != nil && !errors.Is(err, errDivisionByZero) {
+		return err
+	}
+	return nil
+}
+
+// GetMetricSpec returns the External MetricSpec of the composite metric which replaces
+// the metrics of the individual triggers in the HPA
+func GetMetricSpec(scaledObject *kedav1alpha1.ScaledObject) (v2beta2.MetricSpec, error) {
+	sm := scaledObject.Spec.Advanced.ScalingModifiers
+	target, err := parseTarget(sm.Target)
+	if err != nil {
+		return v2beta2.MetricSpec{}, fmt.Errorf("error parsing scalingModifiers target: %s", err)
+	}
+
+	metricType := sm.MetricType
+	if metricType == "" {
+		metricType = v2beta2.AverageValueMetricType
+	}
+
+	return v2beta2.MetricSpec{
+		External: &v2beta2.ExternalMetricSource{
+			Metric: v2beta2.MetricIdentifier{
+				Name: kedav1alpha1.CompositeMetricName,
+			},
+			Target: scalers.GetMetricTargetMili(metricType, target),
+		},
+		Type: v2beta2.ExternalMetricSourceType,
+	}, nil
+}
+
+// GetActivationTarget returns the value the composite metric has to exceed for the ScaledObject to be active
+func GetActivationTarget(scaledObject *kedav1alpha1.ScaledObject) (float64, error) {
+	sm := scaledObject.Spec.Advanced.ScalingModifiers
+	if sm.ActivationTarget == "" {
+		return 0, nil
+	}
+	return parseTarget(sm.ActivationTarget)
+}
+
+// IsReferenced returns whether the trigger is referenced in the formula of ScalingModifiers defined in the ScaledObject,
+// metrics of the referenced triggers are combined into the composite metric instead of being used by the HPA
+func IsReferenced(scaledObject *kedav1alpha1.ScaledObject, triggerName string) (bool, error) {
+	if !scaledObject.IsUsingModifiers() || triggerName == "" {
+		return false, nil
+	}
+	names, err := ReferencedTriggers(scaledObject.Spec.Advanced.ScalingModifiers.Formula)
+	if err != nil {
+		return false, err
+	}
+	for _, name := range names {
+		if name == triggerName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ReferencedTriggers returns names of the triggers used in the formula, in order of their first occurrence
+func ReferencedTriggers(formula string) ([]string, error) {
+	expr, err := parser.ParseExpr(formula)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing formula %q: %s", formula, err)
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	ast.Inspect(expr, func(node ast.Node) bool {
+		return collectIdent(node, seen, &names)
+	})
+	return names, nil
+}
+
+// collectIdent appends names of identifiers to names, function names of call expressions are skipped
+func collectIdent(node ast.Node, seen map[string]bool, names *[]string) bool {
+	if call, ok := node.(*ast.CallExpr); ok {
+		for _, arg := range call.Args {
+			ast.Inspect(arg, func(node ast.Node) bool {
+				return collectIdent(node, seen, names)
+			})
+		}
+		return false
+	}
+	if ident, ok := node.(*ast.Ident); ok && !seen[ident.Name] {
+		seen[ident.Name] = true
+		*names = append(*names, ident.Name)
+	}
+	return true
+}
+
+// Evaluate computes the value of the formula, trigger names are substituted by the values passed in
+func Evaluate(formula string, values map[string]float64) (float64, error) {
+	expr, err := parser.ParseExpr(formula)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing formula %q: %s", formula, err)
+	}
+	return eval(expr, values)
+}
+
+func eval(expr ast.Expr, values map[string]float64) (float64, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT && e.Kind != token.FLOAT {
+			return 0, fmt.Errorf("unsupported literal %s in formula", e.Value)
+		}
+		return strconv.ParseFloat(e.Value, 64)
+	case *ast.Ident:
+		value, ok := values[e.Name]
+		if !ok {
+			return 0, fmt.Errorf("no value found for trigger %s", e.Name)
+		}
+		return value, nil
+	case *ast.ParenExpr:
+		return eval(e.X, values)
+	case *ast.UnaryExpr:
+		x, err := eval(e.X, values)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return x, nil
+		case token.SUB:
+			return -x, nil
+		}
+		return 0, fmt.Errorf("unsupported operator %s in formula", e.Op)
+	case *ast.BinaryExpr:
+		x, err := eval(e.X, values)
+		if err != nil {
+			return 0, err
+		}
+		y, err := eval(e.Y, values)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return x + y, nil
+		case token.SUB:
+			return x - y, nil
+		case token.MUL:
+			return x * y, nil
+		case token.QUO:
+			if y == 0 {
+				return 0, errDivisionByZero
+			}
+			return x / y, nil
+		}
+		return 0, fmt.Errorf("unsupported operator %s in formula", e.Op)
+	case *ast.CallExpr:
+		return evalCall(e, values)
+	}
+	return 0, fmt.Errorf("unsupported expression in formula")
+}
+
+func evalCall(call *ast.CallExpr, values map[string]float64) (float64, error) {
+	fn, ok := call.Fun.(*ast.Ident)
+	if !ok {
+		return 0, fmt.Errorf("unsupported function call in formula")
+	}
+
+	args := make([]float64, 0, len(call.Args))
+	for _, arg := range call.Args {
+		value, err := eval(arg, values)
+		if err != nil {
+			return 0, err
+		}
+		args = append(args, value)
+	}
+
+	switch fn.Name {
+	case "min", "max":
+		if len(args) == 0 {
+			return 0, fmt.Errorf("function %s requires at least one argument", fn.Name)
+		}
+		result := args[0]
+		for _, arg := range args[1:] {
+			if fn.Name == "min" {
+				result = math.Min(result, arg)
+			} else {
+				result = math.Max(result, arg)
+			}
+		}
+		return result, nil
+	case "abs", "ceil", "floor":
+		if len(args) != 1 {
+			return 0, fmt.Errorf("function %s requires exactly one argument", fn.Name)
+		}
+		switch fn.Name {
+		case "abs":
+			return math.Abs(args[0]), nil
+		case "ceil":
+			return math.Ceil(args[0]), nil
+		default:
+			return math.Floor(args[0]), nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported function %s in formula", fn.Name)
+}
+
+func parseTarget(target string) (float64, error) {
+	value, err := strconv.ParseFloat(target, 64)
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("value %s must not be negative", target)
+	}
+	return value, nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modifiers
+
+import (
+	"testing"
+
+	"k8s.io/api/autoscaling/v2beta2"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+type evaluateTestData struct {
+	formula string
+	values  map[string]float64
+	result  float64
+	isError bool
+}
+
+var evaluateTestDataset = []evaluateTestData{
+	{formula: "(kafka + rabbit) / 2", values: map[string]float64{"kafka": 10, "rabbit": 20}, result: 15},
+	{formula: "min(queue, cpu*3)", values: map[string]float64{"queue": 10, "cpu": 2}, result: 6},
+	{formula: "max(a, b, c)", values: map[string]float64{"a": 1, "b": 5, "c": 3}, result: 5},
+	{formula: "-a + 2.5", values: map[string]float64{"a": 1}, result: 1.5},
+	{formula: "abs(a - b)", values: map[string]float64{"a": 1, "b": 5}, result: 4},
+	{formula: "ceil(a / 3)", values: map[string]float64{"a": 10}, result: 4},
+	{formula: "floor(a / 3)", values: map[string]float64{"a": 10}, result: 3},
+	// division by zero
+	{formula: "a / b", values: map[string]float64{"a": 1, "b": 0}, isError: true},
+	// missing value
+	{formula: "a + b", values: map[string]float64{"a": 1}, isError: true},
+	// unsupported operator
+	{formula: "a % 2", values: map[string]float64{"a": 1}, isError: true},
+	// unsupported function
+	{formula: "sqrt(a)", values: map[string]float64{"a": 1}, isError: true},
+	// wrong number of arguments
+	{formula: "abs(a, a)", values: map[string]float64{"a": 1}, isError: true},
+	// string literal
+	{formula: `a + "1"`, values: map[string]float64{"a": 1}, isError: true},
+	// invalid syntax
+	{formula: "a +", values: map[string]float64{"a": 1}, isError: true},
+}
+
+func TestEvaluate(t *testing.T) {
+	for _, testData := range evaluateTestDataset {
+		result, err := Evaluate(testData.formula, testData.values)
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success for formula %q but got error %s", testData.formula, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error for formula %q but got success", testData.formula)
+		}
+		if !testData.isError && result != testData.result {
+			t.Errorf("Expected %v for formula %q but got %v", testData.result, testData.formula, result)
+		}
+	}
+}
+
+func TestReferencedTriggers(t *testing.T) {
+	names, err := ReferencedTriggers("min(queue, max(cpu, queue) * 3) + other")
+	if err != nil {
+		t.Fatalf("Expected success but got error %s", err)
+	}
+	expected := []string{"queue", "cpu", "other"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v but got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected %v but got %v", expected, names)
+		}
+	}
+}
+
+type validateTestData struct {
+	name      string
+	modifiers *kedav1alpha1.ScalingModifiers
+	isError   bool
+}
+
+var validateTestDataset = []validateTestData{
+	{name: "no modifiers", modifiers: nil},
+	{name: "valid formula", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "(kafka + rabbit) / 2", Target: "10"}},
+	{name: "valid formula with activation target", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "kafka / 0", Target: "10", ActivationTarget: "1.5", MetricType: v2beta2.ValueMetricType}},
+	{name: "missing formula", modifiers: &kedav1alpha1.ScalingModifiers{Target: "10"}, isError: true},
+	{name: "missing target", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "kafka"}, isError: true},
+	{name: "invalid target", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "kafka", Target: "ten"}, isError: true},
+	{name: "negative activation target", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "kafka", Target: "10", ActivationTarget: "-1"}, isError: true},
+	{name: "utilization metric type", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "kafka", Target: "10", MetricType: v2beta2.UtilizationMetricType}, isError: true},
+	{name: "unknown trigger", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "kafka + unknown", Target: "10"}, isError: true},
+	{name: "trigger with useCachedMetrics", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "kafka + cached", Target: "10"}, isError: true},
+	{name: "cpu trigger", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "kafka + cpu", Target: "10"}, isError: true},
+	{name: "no trigger", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "1 + 2", Target: "10"}, isError: true},
+	{name: "unsupported function", modifiers: &kedav1alpha1.ScalingModifiers{Formula: "pow(kafka, 2)", Target: "10"}, isError: true},
+}
+
+func TestValidate(t *testing.T) {
+	for _, testData := range validateTestDataset {
+		scaledObject := &kedav1alpha1.ScaledObject{
+			Spec: kedav1alpha1.ScaledObjectSpec{
+				Triggers: []kedav1alpha1.ScaleTriggers{
+					{Type: "kafka", Name: "kafka"},
+					{Type: "rabbitmq", Name: "rabbit"},
+					{Type: "cpu", Name: "cpu"},
+					{Type: "redis", Name: "cached", UseCachedMetrics: true},
+				},
+			},
+		}
+		if testData.modifiers != nil {
+			scaledObject.Spec.Advanced = &kedav1alpha1.AdvancedConfig{ScalingModifiers: testData.modifiers}
+		}
+
+		err := Validate(scaledObject)
+		if err != nil && !testData.isError {
+			t.Errorf("Test %q: expected success but got error %s", testData.name, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Test %q: expected error but got success", testData.name)
+		}
+	}
+}
+
+func TestIsReferenced(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "max(kafka, rabbit)", Target: "10"},
+			},
+		},
+	}
+
+	for name, expected := range map[string]bool{"kafka": true, "rabbit": true, "redis": false, "max": false, "": false} {
+		isReferenced, err := IsReferenced(scaledObject, name)
+		if err != nil {
+			t.Fatalf("Expected success but got error %s", err)
+		}
+		if isReferenced != expected {
+			t.Errorf("Expected trigger %q to be referenced %v but got %v", name, expected, isReferenced)
+		}
+	}
+}
+
+func TestGetMetricSpec(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "kafka", Target: "2.5"},
+			},
+		},
+	}
+
+	metricSpec, err := GetMetricSpec(scaledObject)
+	if err != nil {
+		t.Fatalf("Expected success but got error %s", err)
+	}
+	if metricSpec.External.Metric.Name != kedav1alpha1.CompositeMetricName {
+		t.Errorf("Expected metric name %s but got %s", kedav1alpha1.CompositeMetricName, metricSpec.External.Metric.Name)
+	}
+	if metricSpec.External.Target.Type != v2beta2.AverageValueMetricType {
+		t.Errorf("Expected metric type %s but got %s", v2beta2.AverageValueMetricType, metricSpec.External.Target.Type)
+	}
+	if metricSpec.External.Target.AverageValue.MilliValue() != 2500 {
+		t.Errorf("Expected target 2500m but got %s", metricSpec.External.Target.AverageValue.String())
+	}
+}
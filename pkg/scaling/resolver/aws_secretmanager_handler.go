@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
+)
+
+type AwsSecretManagerHandler struct {
+	secretManager *kedav1alpha1.AwsSecretManager
+	client        secretsmanageriface.SecretsManagerAPI
+	podIdentity   kedav1alpha1.AuthPodIdentity
+}
+
+func NewAwsSecretManagerHandler(a *kedav1alpha1.AwsSecretManager, podIdentity kedav1alpha1.AuthPodIdentity) *AwsSecretManagerHandler {
+	return &AwsSecretManagerHandler{
+		secretManager: a,
+		podIdentity:   podIdentity,
+	}
+}
+
+// Initialize creates the AWS Secrets Manager client, authenticated with the credentials of the secret or
+// with the role of the service account of the workload when using aws-eks pod identity
+func (ah *AwsSecretManagerHandler) Initialize(ctx context.Context, client client.Client, logger logr.Logger,
+	triggerNamespace string, podSpec *corev1.PodSpec, namespace string) error {
+	if ah.secretManager.Region == "" {
+		return fmt.Errorf("region is required to read secrets from AWS Secrets Manager")
+	}
+
+	authorization, err := ah.getAuthorization(ctx, client, logger, triggerNamespace, podSpec, namespace)
+	if err != nil {
+		return err
+	}
+
+	sess, config := awsutils.GetAwsConfig(ah.secretManager.Region, authorization)
+	ah.client = secretsmanager.New(sess, config)
+
+	return nil
+}
+
+// Read returns the value of the secret, or the value of the key if the secret string is a JSON object
+func (ah *AwsSecretManagerHandler) Read(ctx context.Context, secret kedav1alpha1.AwsSecretManagerSecret) (string, error) {
+	input := &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secret.Name),
+	}
+	if secret.VersionID != "" {
+		input.VersionId = aws.String(secret.VersionID)
+	}
+	if secret.VersionStage != "" {
+		input.VersionStage = aws.String(secret.VersionStage)
+	}
+
+	result, err := ah.client.GetSecretValueWithContext(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	switch {
+	case result.SecretString != nil:
+		value = *result.SecretString
+	case result.SecretBinary != nil:
+		value = string(result.SecretBinary)
+	default:
+		return "", fmt.Errorf("secret %s has no value", secret.Name)
+	}
+
+	if secret.Key == "" {
+		return value, nil
+	}
+
+	data := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return "", fmt.Errorf("unable to parse secret %s as JSON object to get key %s: %s", secret.Name, secret.Key, err)
+	}
+	keyValue, ok := data[secret.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s", secret.Key, secret.Name)
+	}
+	if s, ok := keyValue.(string); ok {
+		return s, nil
+	}
+	raw, err := json.Marshal(keyValue)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func (ah *AwsSecretManagerHandler) getAuthorization(ctx context.Context, client client.Client, logger logr.Logger,
+	triggerNamespace string, podSpec *corev1.PodSpec, namespace string) (awsutils.AuthorizationMetadata, error) {
+	authParams := make(map[string]string)
+
+	switch ah.podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		credentials := ah.secretManager.Credentials
+		if credentials == nil || credentials.AccessKey == nil || credentials.AccessSecretKey == nil {
+			return awsutils.AuthorizationMetadata{}, fmt.Errorf("accessKey and accessSecretKey are expected when not using a pod identity provider")
+		}
+		authParams["awsAccessKeyID"] = resolveAuthSecret(ctx, client, logger, credentials.AccessKey.ValueFrom.SecretKeyRef.Name,
+			triggerNamespace, credentials.AccessKey.ValueFrom.SecretKeyRef.Key)
+		authParams["awsSecretAccessKey"] = resolveAuthSecret(ctx, client, logger, credentials.AccessSecretKey.ValueFrom.SecretKeyRef.Name,
+			triggerNamespace, credentials.AccessSecretKey.ValueFrom.SecretKeyRef.Key)
+		if credentials.AccessToken != nil {
+			authParams["awsSessionToken"] = resolveAuthSecret(ctx, client, logger, credentials.AccessToken.ValueFrom.SecretKeyRef.Name,
+				triggerNamespace, credentials.AccessToken.ValueFrom.SecretKeyRef.Key)
+		}
+		if authParams["awsAccessKeyID"] == "" || authParams["awsSecretAccessKey"] == "" {
+			return awsutils.AuthorizationMetadata{}, fmt.Errorf("accessKey and accessSecretKey can't be resolved")
+		}
+	case kedav1alpha1.PodIdentityProviderAwsEKS:
+		if podSpec == nil {
+			return awsutils.AuthorizationMetadata{}, fmt.Errorf("%w for pod identity provider %s", errScaleTargetRequired, ah.podIdentity.Provider)
+		}
+		serviceAccount := &corev1.ServiceAccount{}
+		err := client.Get(ctx, types.NamespacedName{Name: podSpec.ServiceAccountName, Namespace: namespace}, serviceAccount)
+		if err != nil {
+			return awsutils.AuthorizationMetadata{}, fmt.Errorf("error getting service account: '%s', error: %s", podSpec.ServiceAccountName, err)
+		}
+		authParams["awsRoleArn"] = serviceAccount.Annotations[kedav1alpha1.PodIdentityAnnotationEKS]
+		if authParams["awsRoleArn"] == "" {
+			return awsutils.AuthorizationMetadata{}, fmt.Errorf("service account '%s' has no %s annotation", podSpec.ServiceAccountName, kedav1alpha1.PodIdentityAnnotationEKS)
+		}
+	default:
+		return awsutils.AuthorizationMetadata{}, fmt.Errorf("aws secret manager does not support pod identity provider - %s", ah.podIdentity.Provider)
+	}
+
+	return awsutils.GetAwsAuthorization(authParams, map[string]string{}, map[string]string{}, ah.podIdentity.Provider)
+}
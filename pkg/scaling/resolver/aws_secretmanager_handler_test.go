@@ -0,0 +1,231 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const (
+	testAwsRoleArn = "arn:aws:iam::123456789012:role/keda"
+)
+
+type mockSecretsManager struct {
+	secretsmanageriface.SecretsManagerAPI
+}
+
+func (m *mockSecretsManager) GetSecretValueWithContext(_ aws.Context, input *secretsmanager.GetSecretValueInput, _ ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	switch *input.SecretId {
+	case "plain":
+		if input.VersionStage != nil && *input.VersionStage == "AWSPREVIOUS" {
+			return &secretsmanager.GetSecretValueOutput{SecretString: aws.String("previous-value")}, nil
+		}
+		return &secretsmanager.GetSecretValueOutput{SecretString: aws.String("value")}, nil
+	case "json":
+		return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(`{"username":"keda","port":5432}`)}, nil
+	case "binary":
+		return &secretsmanager.GetSecretValueOutput{SecretBinary: []byte("binary-value")}, nil
+	default:
+		return nil, fmt.Errorf("secret %s not found", *input.SecretId)
+	}
+}
+
+type awsSecretManagerReadTestData struct {
+	name          string
+	secret        kedav1alpha1.AwsSecretManagerSecret
+	expectedValue string
+	isError       bool
+}
+
+var awsSecretManagerReadTestDataset = []awsSecretManagerReadTestData{
+	{
+		name:          "secret string",
+		secret:        kedav1alpha1.AwsSecretManagerSecret{Name: "plain"},
+		expectedValue: "value",
+	},
+	{
+		name:          "secret string of version stage",
+		secret:        kedav1alpha1.AwsSecretManagerSecret{Name: "plain", VersionStage: "AWSPREVIOUS"},
+		expectedValue: "previous-value",
+	},
+	{
+		name:          "secret binary",
+		secret:        kedav1alpha1.AwsSecretManagerSecret{Name: "binary"},
+		expectedValue: "binary-value",
+	},
+	{
+		name:          "string key of JSON secret",
+		secret:        kedav1alpha1.AwsSecretManagerSecret{Name: "json", Key: "username"},
+		expectedValue: "keda",
+	},
+	{
+		name:          "number key of JSON secret",
+		secret:        kedav1alpha1.AwsSecretManagerSecret{Name: "json", Key: "port"},
+		expectedValue: "5432",
+	},
+	{
+		name:    "missing key of JSON secret",
+		secret:  kedav1alpha1.AwsSecretManagerSecret{Name: "json", Key: "password"},
+		isError: true,
+	},
+	{
+		name:    "key of secret which isn't JSON",
+		secret:  kedav1alpha1.AwsSecretManagerSecret{Name: "plain", Key: "username"},
+		isError: true,
+	},
+	{
+		name:    "missing secret",
+		secret:  kedav1alpha1.AwsSecretManagerSecret{Name: "missing"},
+		isError: true,
+	},
+}
+
+func TestAwsSecretManagerRead(t *testing.T) {
+	ah := NewAwsSecretManagerHandler(&kedav1alpha1.AwsSecretManager{}, kedav1alpha1.AuthPodIdentity{})
+	ah.client = &mockSecretsManager{}
+
+	for _, testData := range awsSecretManagerReadTestDataset {
+		value, err := ah.Read(context.TODO(), testData.secret)
+		if err != nil && !testData.isError {
+			t.Errorf("test %s: expected success but got error - %s", testData.name, err)
+		}
+		if err == nil && testData.isError {
+			t.Errorf("test %s: expected error but got success", testData.name)
+		}
+		if value != testData.expectedValue {
+			t.Errorf("test %s: expected value %s but got %s", testData.name, testData.expectedValue, value)
+		}
+	}
+}
+
+type awsSecretManagerAuthorizationTestData struct {
+	name             string
+	secretManager    kedav1alpha1.AwsSecretManager
+	podIdentity      kedav1alpha1.AuthPodIdentity
+	podSpec          *corev1.PodSpec
+	expectedKeyID    string
+	expectedRoleArn  string
+	expectedPodOwner bool
+	isError          bool
+}
+
+var awsSecretManagerCredentials = &kedav1alpha1.AwsSecretManagerCredentials{
+	AccessKey: &kedav1alpha1.AwsSecretManagerValue{
+		ValueFrom: kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: secretName, Key: "accessKey"}},
+	},
+	AccessSecretKey: &kedav1alpha1.AwsSecretManagerValue{
+		ValueFrom: kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: secretName, Key: "secretKey"}},
+	},
+}
+
+var awsSecretManagerAuthorizationTestDataset = []awsSecretManagerAuthorizationTestData{
+	{
+		name:             "static credentials",
+		secretManager:    kedav1alpha1.AwsSecretManager{Credentials: awsSecretManagerCredentials},
+		expectedKeyID:    "keyID",
+		expectedPodOwner: true,
+	},
+	{
+		name:          "missing credentials",
+		secretManager: kedav1alpha1.AwsSecretManager{},
+		isError:       true,
+	},
+	{
+		name:             "aws-eks pod identity",
+		secretManager:    kedav1alpha1.AwsSecretManager{},
+		podIdentity:      kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS},
+		podSpec:          &corev1.PodSpec{ServiceAccountName: "keda-sa"},
+		expectedRoleArn:  testAwsRoleArn,
+		expectedPodOwner: true,
+	},
+	{
+		name:          "aws-eks pod identity without scale target",
+		secretManager: kedav1alpha1.AwsSecretManager{},
+		podIdentity:   kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS},
+		isError:       true,
+	},
+	{
+		name:          "aws-eks pod identity with service account without role",
+		secretManager: kedav1alpha1.AwsSecretManager{},
+		podIdentity:   kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS},
+		podSpec:       &corev1.PodSpec{ServiceAccountName: "default"},
+		isError:       true,
+	},
+	{
+		name:          "unsupported pod identity",
+		secretManager: kedav1alpha1.AwsSecretManager{},
+		podIdentity:   kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzure},
+		isError:       true,
+	},
+}
+
+func TestAwsSecretManagerGetAuthorization(t *testing.T) {
+	existing := []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace},
+			Data:       map[string][]byte{"accessKey": []byte("keyID"), "secretKey": []byte("secret")},
+		},
+		&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "keda-sa",
+				Namespace:   namespace,
+				Annotations: map[string]string{kedav1alpha1.PodIdentityAnnotationEKS: testAwsRoleArn},
+			},
+		},
+		&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: namespace},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(existing...).Build()
+
+	for _, testData := range awsSecretManagerAuthorizationTestDataset {
+		ah := NewAwsSecretManagerHandler(&testData.secretManager, testData.podIdentity)
+		authorization, err := ah.getAuthorization(context.TODO(), client, logf.Log.WithName("test"), namespace, testData.podSpec, namespace)
+		if err != nil && !testData.isError {
+			t.Errorf("test %s: expected success but got error - %s", testData.name, err)
+			continue
+		}
+		if err == nil && testData.isError {
+			t.Errorf("test %s: expected error but got success", testData.name)
+			continue
+		}
+		if authorization.AwsAccessKeyID != testData.expectedKeyID {
+			t.Errorf("test %s: expected access key id %s but got %s", testData.name, testData.expectedKeyID, authorization.AwsAccessKeyID)
+		}
+		if authorization.AwsRoleArn != testData.expectedRoleArn {
+			t.Errorf("test %s: expected role arn %s but got %s", testData.name, testData.expectedRoleArn, authorization.AwsRoleArn)
+		}
+		if authorization.PodIdentityOwner != testData.expectedPodOwner {
+			t.Errorf("test %s: expected pod identity owner %t but got %t", testData.name, testData.expectedPodOwner, authorization.PodIdentityOwner)
+		}
+	}
+}
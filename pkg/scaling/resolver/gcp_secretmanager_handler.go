@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/compute/metadata"
+	"github.com/go-logr/logr"
+	"google.golang.org/api/secretmanager/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
+)
+
+const gcpSecretManagerLatestVersion = "latest"
+
+type GcpSecretManagerHandler struct {
+	secretManager *kedav1alpha1.GcpSecretManager
+	service       *secretmanager.Service
+	podIdentity   kedav1alpha1.AuthPodIdentity
+	projectID     string
+}
+
+func NewGcpSecretManagerHandler(g *kedav1alpha1.GcpSecretManager, podIdentity kedav1alpha1.AuthPodIdentity) *GcpSecretManagerHandler {
+	return &GcpSecretManagerHandler{
+		secretManager: g,
+		podIdentity:   podIdentity,
+	}
+}
+
+// Initialize creates the GCP Secret Manager client, authenticated with the service account of the secret
+// or with the workload identity when using gcp pod identity
+func (gh *GcpSecretManagerHandler) Initialize(ctx context.Context, client client.Client, logger logr.Logger, triggerNamespace string) error {
+	authParams := make(map[string]string)
+	switch gh.podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		if gh.secretManager.Credentials == nil {
+			return fmt.Errorf("credentials are expected when not using a pod identity provider")
+		}
+		secretKeyRef := gh.secretManager.Credentials.ClientSecret.ValueFrom.SecretKeyRef
+		authParams["GoogleApplicationCredentials"] = resolveAuthSecret(ctx, client, logger, secretKeyRef.Name, triggerNamespace, secretKeyRef.Key)
+	case kedav1alpha1.PodIdentityProviderGCP:
+		// rely on the default credentials of the workload identity
+	default:
+		return fmt.Errorf("gcp secret manager does not support pod identity provider - %s", gh.podIdentity.Provider)
+	}
+
+	authorization, err := gcp.GetGCPAuthorization(authParams, map[string]string{}, map[string]string{}, gh.podIdentity)
+	if err != nil {
+		return err
+	}
+
+	gh.projectID, err = gh.getProjectID(authorization)
+	if err != nil {
+		return err
+	}
+
+	gh.service, err = secretmanager.NewService(ctx, authorization.GetClientOptions()...)
+	return err
+}
+
+// Read returns the value of the version of the secret, the latest version is read if no version is specified
+func (gh *GcpSecretManagerHandler) Read(ctx context.Context, secretID, version string) (string, error) {
+	if version == "" {
+		version = gcpSecretManagerLatestVersion
+	}
+	name := secretID
+	if !strings.HasPrefix(secretID, "projects/") {
+		name = fmt.Sprintf("projects/%s/secrets/%s", gh.projectID, secretID)
+	}
+
+	result, err := gh.service.Projects.Secrets.Versions.Access(fmt.Sprintf("%s/versions/%s", name, version)).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	if result.Payload == nil {
+		return "", fmt.Errorf("secret %s has no payload", name)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(result.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode payload of secret %s: %s", name, err)
+	}
+	return string(data), nil
+}
+
+func (gh *GcpSecretManagerHandler) getProjectID(authorization *gcp.AuthorizationMetadata) (string, error) {
+	if gh.secretManager.ProjectID != "" {
+		return gh.secretManager.ProjectID, nil
+	}
+
+	if authorization.PodIdentityProviderEnabled {
+		return metadata.ProjectID()
+	}
+
+	var credentials struct {
+		ProjectID string `json:"project_id"`
+	}
+	if err := json.Unmarshal([]byte(authorization.GoogleApplicationCredentials), &credentials); err != nil {
+		return "", fmt.Errorf("unable to parse service account credentials: %s", err)
+	}
+	if credentials.ProjectID == "" {
+		return "", fmt.Errorf("projectId is missing and can't be read from service account credentials")
+	}
+	return credentials.ProjectID, nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/secretmanager/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
+)
+
+type gcpSecretManagerReadTestData struct {
+	name          string
+	secretID      string
+	version       string
+	expectedValue string
+	isError       bool
+}
+
+var gcpSecretManagerReadTestDataset = []gcpSecretManagerReadTestData{
+	{
+		name:          "latest version of secret",
+		secretID:      "password",
+		expectedValue: "latest-value",
+	},
+	{
+		name:          "version of secret",
+		secretID:      "password",
+		version:       "1",
+		expectedValue: "first-value",
+	},
+	{
+		name:          "secret of other project",
+		secretID:      "projects/other-project/secrets/password",
+		expectedValue: "other-project-value",
+	},
+	{
+		name:     "missing secret",
+		secretID: "missing",
+		isError:  true,
+	},
+}
+
+func TestGcpSecretManagerRead(t *testing.T) {
+	values := map[string]string{
+		"/v1/projects/test-project/secrets/password/versions/latest":  "latest-value",
+		"/v1/projects/test-project/secrets/password/versions/1":       "first-value",
+		"/v1/projects/other-project/secrets/password/versions/latest": "other-project-value",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := values[r.URL.Path[:len(r.URL.Path)-len(":access")]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"payload":{"data":"%s"}}`, base64.StdEncoding.EncodeToString([]byte(value)))
+	}))
+	defer server.Close()
+
+	service, err := secretmanager.NewService(context.TODO(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	gh := NewGcpSecretManagerHandler(&kedav1alpha1.GcpSecretManager{}, kedav1alpha1.AuthPodIdentity{})
+	gh.service = service
+	gh.projectID = "test-project"
+
+	for _, testData := range gcpSecretManagerReadTestDataset {
+		value, err := gh.Read(context.TODO(), testData.secretID, testData.version)
+		if err != nil && !testData.isError {
+			t.Errorf("test %s: expected success but got error - %s", testData.name, err)
+		}
+		if err == nil && testData.isError {
+			t.Errorf("test %s: expected error but got success", testData.name)
+		}
+		if value != testData.expectedValue {
+			t.Errorf("test %s: expected value %s but got %s", testData.name, testData.expectedValue, value)
+		}
+	}
+}
+
+type gcpSecretManagerProjectIDTestData struct {
+	name              string
+	secretManager     kedav1alpha1.GcpSecretManager
+	authorization     gcp.AuthorizationMetadata
+	expectedProjectID string
+	isError           bool
+}
+
+var gcpSecretManagerProjectIDTestDataset = []gcpSecretManagerProjectIDTestData{
+	{
+		name:              "project of secret manager",
+		secretManager:     kedav1alpha1.GcpSecretManager{ProjectID: "secret-project"},
+		authorization:     gcp.AuthorizationMetadata{GoogleApplicationCredentials: `{"project_id":"sa-project"}`},
+		expectedProjectID: "secret-project",
+	},
+	{
+		name:              "project of service account",
+		authorization:     gcp.AuthorizationMetadata{GoogleApplicationCredentials: `{"project_id":"sa-project"}`},
+		expectedProjectID: "sa-project",
+	},
+	{
+		name:          "service account without project",
+		authorization: gcp.AuthorizationMetadata{GoogleApplicationCredentials: `{}`},
+		isError:       true,
+	},
+	{
+		name:          "invalid service account",
+		authorization: gcp.AuthorizationMetadata{GoogleApplicationCredentials: "invalid"},
+		isError:       true,
+	},
+}
+
+func TestGcpSecretManagerGetProjectID(t *testing.T) {
+	for _, testData := range gcpSecretManagerProjectIDTestDataset {
+		gh := NewGcpSecretManagerHandler(&testData.secretManager, kedav1alpha1.AuthPodIdentity{})
+		projectID, err := gh.getProjectID(&testData.authorization)
+		if err != nil && !testData.isError {
+			t.Errorf("test %s: expected success but got error - %s", testData.name, err)
+		}
+		if err == nil && testData.isError {
+			t.Errorf("test %s: expected error but got success", testData.name)
+		}
+		if projectID != testData.expectedProjectID {
+			t.Errorf("test %s: expected project %s but got %s", testData.name, testData.expectedProjectID, projectID)
+		}
+	}
+}
@@ -17,21 +17,35 @@ limitations under the License.
 package resolver
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	vaultapi "github.com/hashicorp/vault/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
 // HashicorpVaultHandler is specification of Hashi Corp Vault
 type HashicorpVaultHandler struct {
-	vault  *kedav1alpha1.HashiCorpVault
-	client *vaultapi.Client
-	stopCh chan struct{}
+	vault    *kedav1alpha1.HashiCorpVault
+	client   *vaultapi.Client
+	logger   logr.Logger
+	leaseIDs []string
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// VaultLeases tracks the leases of the Hashicorp Vault secrets resolved in the authentication parameters of a trigger
+type VaultLeases struct {
+	refreshTime time.Time
+	vault       *HashicorpVaultHandler
 }
 
 // NewHashicorpVaultHandler creates a HashicorpVaultHandler object
@@ -41,60 +55,66 @@ func NewHashicorpVaultHandler(v *kedav1alpha1.HashiCorpVault) *HashicorpVaultHan
 	}
 }
 
-// Initialize the Vault client
-func (vh *HashicorpVaultHandler) Initialize(logger logr.Logger) error {
+// Initialize the Vault client, the credentials of the authentication method are read from the secrets in the trigger namespace
+func (vh *HashicorpVaultHandler) Initialize(ctx context.Context, client client.Client, logger logr.Logger, triggerNamespace string) error {
+	vh.logger = logger
+
 	config := vaultapi.DefaultConfig()
-	client, err := vaultapi.NewClient(config)
+	vaultClient, err := vaultapi.NewClient(config)
 	if err != nil {
 		return err
 	}
 
-	err = client.SetAddress(vh.vault.Address)
+	err = vaultClient.SetAddress(vh.vault.Address)
 	if err != nil {
 		return err
 	}
 
 	if len(vh.vault.Namespace) > 0 {
-		client.SetNamespace(vh.vault.Namespace)
+		vaultClient.SetNamespace(vh.vault.Namespace)
 	}
 
-	token, err := vh.token(client)
+	token, err := vh.token(ctx, client, logger, vaultClient, triggerNamespace)
 	if err != nil {
 		return err
 	}
 
 	if len(token) > 0 {
-		client.SetToken(token)
+		vaultClient.SetToken(token)
 	}
 
-	lookup, err := client.Auth().Token().LookupSelf()
+	lookup, err := vaultClient.Auth().Token().LookupSelf()
 	// If token is not valid so get out of here early
 	if err != nil {
 		return err
 	}
 
-	renew := lookup.Data["renewable"].(bool)
+	vh.client = vaultClient
+
+	renew, _ := lookup.Data["renewable"].(bool)
 	if renew {
 		vh.stopCh = make(chan struct{})
 		go vh.renewToken(logger)
 	}
 
-	vh.client = client
-
 	return nil
 }
 
-func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error) {
+func (vh *HashicorpVaultHandler) token(ctx context.Context, client client.Client, logger logr.Logger, vaultClient *vaultapi.Client, triggerNamespace string) (string, error) {
 	var token string
+	credential := vh.vault.Credential
+	if credential == nil {
+		credential = &kedav1alpha1.Credential{}
+	}
 
 	switch vh.vault.Authentication {
 	case kedav1alpha1.VaultAuthenticationToken:
 		// Got token from VAULT_TOKEN env variable
 		switch {
-		case len(client.Token()) > 0:
+		case len(vaultClient.Token()) > 0:
 			break
-		case len(vh.vault.Credential.Token) > 0:
-			token = vh.vault.Credential.Token
+		case len(credential.Token) > 0:
+			token = credential.Token
 		default:
 			return token, errors.New("could not get Vault token")
 		}
@@ -107,23 +127,59 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 			return token, errors.New("k8s role not in config")
 		}
 
-		if len(vh.vault.Credential.ServiceAccount) == 0 {
+		if len(credential.ServiceAccount) == 0 {
 			return token, errors.New("k8s SA file not in config")
 		}
 
 		// Get the JWT from POD
-		jwt, err := os.ReadFile(vh.vault.Credential.ServiceAccount)
+		jwt, err := os.ReadFile(credential.ServiceAccount)
 		if err != nil {
 			return token, err
 		}
 
 		data := map[string]interface{}{"jwt": string(jwt), "role": vh.vault.Role}
-		secret, err := client.Logical().Write(fmt.Sprintf("auth/%s/login", vh.vault.Mount), data)
-		if err != nil {
-			return token, err
+		return vh.login(vaultClient, data)
+	case kedav1alpha1.VaultAuthenticationAppRole:
+		if len(vh.vault.Mount) == 0 {
+			return token, errors.New("auth mount not in config")
+		}
+
+		if len(credential.RoleID) == 0 || credential.SecretID == nil {
+			return token, errors.New("approle roleId and secretId not in config")
 		}
 
-		token = secret.Auth.ClientToken
+		secretKeyRef := credential.SecretID.ValueFrom.SecretKeyRef
+		secretID := resolveAuthSecret(ctx, client, logger, secretKeyRef.Name, triggerNamespace, secretKeyRef.Key)
+
+		data := map[string]interface{}{"role_id": credential.RoleID, "secret_id": secretID}
+		return vh.login(vaultClient, data)
+	case kedav1alpha1.VaultAuthenticationJWT:
+		if len(vh.vault.Mount) == 0 {
+			return token, errors.New("auth mount not in config")
+		}
+
+		if len(vh.vault.Role) == 0 {
+			return token, errors.New("jwt role not in config")
+		}
+
+		var jwt string
+		switch {
+		case credential.JWT != nil:
+			secretKeyRef := credential.JWT.ValueFrom.SecretKeyRef
+			jwt = resolveAuthSecret(ctx, client, logger, secretKeyRef.Name, triggerNamespace, secretKeyRef.Key)
+		case len(credential.ServiceAccount) > 0:
+			// Use the projected service account token of POD as JWT
+			content, err := os.ReadFile(credential.ServiceAccount)
+			if err != nil {
+				return token, err
+			}
+			jwt = string(content)
+		default:
+			return token, errors.New("jwt or SA file not in config")
+		}
+
+		data := map[string]interface{}{"jwt": jwt, "role": vh.vault.Role}
+		return vh.login(vaultClient, data)
 	default:
 		return token, fmt.Errorf("vault auth method %s is not supported", vh.vault.Authentication)
 	}
@@ -131,10 +187,24 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 	return token, nil
 }
 
+// login authenticates with the auth method mounted on the configured mount and returns the client token
+func (vh *HashicorpVaultHandler) login(vaultClient *vaultapi.Client, data map[string]interface{}) (string, error) {
+	secret, err := vaultClient.Logical().Write(fmt.Sprintf("auth/%s/login", vh.vault.Mount), data)
+	if err != nil {
+		return "", err
+	}
+	if secret == nil || secret.Auth == nil {
+		return "", fmt.Errorf("vault login with %s auth method didn't return a token", vh.vault.Authentication)
+	}
+
+	return secret.Auth.ClientToken, nil
+}
+
 func (vh *HashicorpVaultHandler) renewToken(logger logr.Logger) {
 	secret, err := vh.client.Auth().Token().RenewSelf(0)
 	if err != nil {
 		logger.Error(err, "Vault renew token: failed to create the payload")
+		return
 	}
 
 	renewer, err := vh.client.NewLifetimeWatcher(&vaultapi.RenewerInput{
@@ -144,13 +214,11 @@ func (vh *HashicorpVaultHandler) renewToken(logger logr.Logger) {
 	})
 	if err != nil {
 		logger.Error(err, "Vault renew token: cannot create the renewer")
+		return
 	}
 
 	go renewer.Renew()
-	defer func() {
-		renewer.Stop()
-		close(vh.stopCh)
-	}()
+	defer renewer.Stop()
 
 RenewWatcherLoop:
 	for {
@@ -166,13 +234,95 @@ RenewWatcherLoop:
 	}
 }
 
-func (vh *HashicorpVaultHandler) Read(path string) (*vaultapi.Secret, error) {
-	return vh.client.Logical().Read(path)
+// RequestSecret reads the secret, or issues a certificate with the data of the PKI secret, the leases of the returned
+// dynamic secrets are tracked to be revoked once they aren't used anymore
+func (vh *HashicorpVaultHandler) RequestSecret(ctx context.Context, secret kedav1alpha1.VaultSecret) (*vaultapi.Secret, error) {
+	var result *vaultapi.Secret
+	var err error
+	if secret.Type == kedav1alpha1.VaultSecretTypePki {
+		if secret.PkiData == nil {
+			return nil, fmt.Errorf("pkiData is required to issue a certificate")
+		}
+		result, err = vh.client.Logical().WriteWithContext(ctx, secret.Path, vaultPkiRequestData(secret.PkiData))
+	} else {
+		result, err = vh.client.Logical().ReadWithContext(ctx, secret.Path)
+	}
+	if err != nil || result == nil {
+		return result, err
+	}
+
+	if result.LeaseID != "" {
+		vh.leaseIDs = append(vh.leaseIDs, result.LeaseID)
+	}
+	return result, nil
+}
+
+// RevokeLeases revokes the leases of the dynamic secrets requested with the handler and stops the renewal of its token
+func (vh *HashicorpVaultHandler) RevokeLeases(ctx context.Context) {
+	for _, leaseID := range vh.leaseIDs {
+		if err := vh.client.Sys().RevokeWithContext(ctx, leaseID); err != nil {
+			vh.logger.Error(err, "Error revoking Vault lease", "leaseID", leaseID)
+		}
+	}
+	vh.leaseIDs = nil
+	vh.Stop()
 }
 
 // Stop is responsible for stoping the renew token process
 func (vh *HashicorpVaultHandler) Stop() {
 	if vh.stopCh != nil {
-		vh.stopCh <- struct{}{}
+		vh.stopOnce.Do(func() { close(vh.stopCh) })
+	}
+}
+
+// RefreshTime returns the time when the authentication parameters have to be resolved again before the leases expire,
+// zero if they don't expire
+func (l *VaultLeases) RefreshTime() time.Time {
+	if l == nil {
+		return time.Time{}
+	}
+	return l.refreshTime
+}
+
+// Revoke revokes the leases of the dynamic secrets, the credentials resolved from them are invalid afterwards
+func (l *VaultLeases) Revoke(ctx context.Context) {
+	if l == nil || l.vault == nil {
+		return
+	}
+	l.vault.RevokeLeases(ctx)
+}
+
+func vaultPkiRequestData(pkiData *kedav1alpha1.VaultPkiData) map[string]interface{} {
+	data := map[string]interface{}{"common_name": pkiData.CommonName}
+	optionalData := map[string]string{
+		"alt_names":  pkiData.AltNames,
+		"ip_sans":    pkiData.IPSans,
+		"uri_sans":   pkiData.URISans,
+		"other_sans": pkiData.OtherSans,
+		"ttl":        pkiData.TTL,
+		"format":     pkiData.Format,
+	}
+	for key, value := range optionalData {
+		if value != "" {
+			data[key] = value
+		}
+	}
+	return data
+}
+
+// vaultSecretLeaseDuration returns the lifetime of the secret in seconds, certificates issued by the PKI engine
+// aren't leased by default so the lifetime left until their expiration is used
+func vaultSecretLeaseDuration(secret *vaultapi.Secret, secretType kedav1alpha1.VaultSecretType) int {
+	if secret.LeaseDuration > 0 || secretType != kedav1alpha1.VaultSecretTypePki {
+		return secret.LeaseDuration
+	}
+	expiration, ok := secret.Data["expiration"].(json.Number)
+	if !ok {
+		return 0
+	}
+	seconds, err := expiration.Int64()
+	if err != nil {
+		return 0
 	}
+	return int(time.Until(time.Unix(seconds, 0)).Seconds())
 }
@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const vaultTestToken = "approle-token"
+
+type vaultTestServer struct {
+	*httptest.Server
+	mutex         sync.Mutex
+	loginData     map[string]interface{}
+	pkiData       map[string]interface{}
+	revokedLeases []string
+}
+
+func newVaultTestServer(t *testing.T) *vaultTestServer {
+	vs := &vaultTestServer{}
+	vs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vs.mutex.Lock()
+		defer vs.mutex.Unlock()
+
+		var body map[string]interface{}
+		if r.Body != nil {
+			content, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(content, &body)
+		}
+		if r.URL.Path != "/v1/auth/approle/login" && r.Header.Get("X-Vault-Token") != vaultTestToken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/auth/approle/login":
+			vs.loginData = body
+			_, _ = io.WriteString(w, `{"auth":{"client_token":"`+vaultTestToken+`"}}`)
+		case "/v1/auth/token/lookup-self":
+			_, _ = io.WriteString(w, `{"data":{"renewable":false}}`)
+		case "/v1/secret/data/keda":
+			_, _ = io.WriteString(w, `{"data":{"data":{"password":"kv-password"}}}`)
+		case "/v1/database/creds/keda":
+			_, _ = io.WriteString(w, `{"lease_id":"database/creds/keda/lease","lease_duration":300,"data":{"username":"keda","password":"dynamic-password"}}`)
+		case "/v1/pki/issue/keda":
+			vs.pkiData = body
+			_, _ = io.WriteString(w, `{"data":{"certificate":"cert","private_key":"key","expiration":4102444800}}`)
+		case "/v1/sys/leases/revoke":
+			vs.revokedLeases = append(vs.revokedLeases, body["lease_id"].(string))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected Vault request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return vs
+}
+
+func TestHashicorpVaultHandlerAppRoleAndDynamicSecrets(t *testing.T) {
+	t.Setenv("VAULT_TOKEN", "")
+	vs := newVaultTestServer(t)
+	defer vs.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "approle", Namespace: namespace},
+		Data:       map[string][]byte{"secretId": []byte("approle-secret-id")},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(secret).Build()
+
+	vault := &kedav1alpha1.HashiCorpVault{
+		Address:        vs.URL,
+		Authentication: kedav1alpha1.VaultAuthenticationAppRole,
+		Mount:          "approle",
+		Credential: &kedav1alpha1.Credential{
+			RoleID: "keda-role",
+			SecretID: &kedav1alpha1.VaultCredentialValue{
+				ValueFrom: kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "approle", Key: "secretId"}},
+			},
+		},
+	}
+	vh := NewHashicorpVaultHandler(vault)
+	if err := vh.Initialize(context.TODO(), client, logf.Log.WithName("test"), namespace); err != nil {
+		t.Fatal(err)
+	}
+	if vs.loginData["role_id"] != "keda-role" || vs.loginData["secret_id"] != "approle-secret-id" {
+		t.Errorf("Expected approle login with role and secret id, got %v", vs.loginData)
+	}
+
+	kvSecret, err := vh.RequestSecret(context.TODO(), kedav1alpha1.VaultSecret{Path: "secret/data/keda"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := resolveVaultSecret(kvSecret.Data, "password", ""); err != nil || value != "kv-password" {
+		t.Errorf("Expected kv-password, got %s", value)
+	}
+
+	dynamicSecret, err := vh.RequestSecret(context.TODO(), kedav1alpha1.VaultSecret{Path: "database/creds/keda", Type: kedav1alpha1.VaultSecretTypeSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := resolveVaultSecret(dynamicSecret.Data, "password", kedav1alpha1.VaultSecretTypeSecret); err != nil || value != "dynamic-password" {
+		t.Errorf("Expected dynamic-password, got %s", value)
+	}
+	if duration := vaultSecretLeaseDuration(dynamicSecret, kedav1alpha1.VaultSecretTypeSecret); duration != 300 {
+		t.Errorf("Expected lease duration of 300 seconds, got %d", duration)
+	}
+
+	pkiSecret, err := vh.RequestSecret(context.TODO(), kedav1alpha1.VaultSecret{
+		Path:    "pki/issue/keda",
+		Type:    kedav1alpha1.VaultSecretTypePki,
+		PkiData: &kedav1alpha1.VaultPkiData{CommonName: "keda.example.com", TTL: "1h"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vs.pkiData["common_name"] != "keda.example.com" || vs.pkiData["ttl"] != "1h" || len(vs.pkiData) != 2 {
+		t.Errorf("Expected PKI request with common name and ttl, got %v", vs.pkiData)
+	}
+	if value, err := resolveVaultSecret(pkiSecret.Data, "certificate", kedav1alpha1.VaultSecretTypePki); err != nil || value != "cert" {
+		t.Errorf("Expected cert, got %s", value)
+	}
+	if duration := vaultSecretLeaseDuration(pkiSecret, kedav1alpha1.VaultSecretTypePki); duration <= 0 {
+		t.Errorf("Expected lease duration until the certificate expiration, got %d", duration)
+	}
+	if _, err := resolveVaultSecret(kvSecret.Data, "missing", ""); err == nil {
+		t.Error("Expected an error for a key missing in the secret")
+	}
+
+	vh.RevokeLeases(context.TODO())
+	if strings.Join(vs.revokedLeases, ",") != "database/creds/keda/lease" {
+		t.Errorf("Expected the lease of the dynamic secret to be revoked, got %v", vs.revokedLeases)
+	}
+}
+
+func TestVaultLeasesWithoutLeases(t *testing.T) {
+	var leases *VaultLeases
+	if !leases.RefreshTime().IsZero() {
+		t.Errorf("Expected no refresh time without leases")
+	}
+	// revoking without leases is a no-op
+	leases.Revoke(context.TODO())
+	(&VaultLeases{}).Revoke(context.TODO())
+}
@@ -19,8 +19,11 @@ package resolver
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -40,6 +43,12 @@ const (
 	referenceCloser   = ')'
 )
 
+// errScaleTargetRequired is returned when a parameter can only be resolved for the scale target of a ScaledObject or ScaledJob
+var errScaleTargetRequired = errors.New("the scale target is required")
+
+// errAuthRefNotAllowed is returned when the ScaledObject or ScaledJob isn't allowed to reference the TriggerAuthentication
+var errAuthRefNotAllowed = errors.New("the authentication reference isn't allowed")
+
 // ResolveScaleTargetPodSpec for given scalableObject inspects the scale target workload,
 // which could be almost any k8s resource (Deployment, StatefulSet, CustomResource...)
 // and for the given resource returns *corev1.PodTemplateSpec and a name of the container
@@ -128,118 +137,307 @@ func ResolveContainerEnv(ctx context.Context, client client.Client, logger logr.
 	return resolveEnv(ctx, client, logger, &container, namespace)
 }
 
-// ResolveAuthRefAndPodIdentity provides authentication parameters and pod identity needed authenticate scaler with the environment.
+// ResolveAuthRefAndPodIdentity provides authentication parameters and pod identity needed authenticate scaler with the environment,
+// and the leases of the Vault secrets in the authentication parameters which have to be revoked once they aren't used anymore
 func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, logger logr.Logger,
 	triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, podTemplateSpec *corev1.PodTemplateSpec,
-	namespace string) (map[string]string, kedav1alpha1.AuthPodIdentity, error) {
+	namespace string) (map[string]string, kedav1alpha1.AuthPodIdentity, *VaultLeases, error) {
 	if podTemplateSpec != nil {
-		authParams, podIdentity := resolveAuthRef(ctx, client, logger, triggerAuthRef, &podTemplateSpec.Spec, namespace)
+		authParams, podIdentity, vaultLeases, err := resolveAuthRef(ctx, client, logger, triggerAuthRef, &podTemplateSpec.Spec, namespace)
+		if err != nil {
+			return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, nil, err
+		}
 
 		if podIdentity.Provider == kedav1alpha1.PodIdentityProviderAwsEKS {
 			serviceAccountName := podTemplateSpec.Spec.ServiceAccountName
 			serviceAccount := &corev1.ServiceAccount{}
 			err := client.Get(ctx, types.NamespacedName{Name: serviceAccountName, Namespace: namespace}, serviceAccount)
 			if err != nil {
-				return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
+				vaultLeases.Revoke(ctx)
+				return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, nil,
 					fmt.Errorf("error getting service account: '%s', error: %s", serviceAccountName, err)
 			}
 			authParams["awsRoleArn"] = serviceAccount.Annotations[kedav1alpha1.PodIdentityAnnotationEKS]
 		} else if podIdentity.Provider == kedav1alpha1.PodIdentityProviderAwsKiam {
 			authParams["awsRoleArn"] = podTemplateSpec.ObjectMeta.Annotations[kedav1alpha1.PodIdentityAnnotationKiam]
 		}
-		return authParams, podIdentity, nil
+		return authParams, podIdentity, vaultLeases, nil
 	}
 
-	authParams, _ := resolveAuthRef(ctx, client, logger, triggerAuthRef, nil, namespace)
-	return authParams, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, nil
+	authParams, _, vaultLeases, err := resolveAuthRef(ctx, client, logger, triggerAuthRef, nil, namespace)
+	if err != nil {
+		return nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, nil, err
+	}
+	return authParams, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone}, vaultLeases, nil
 }
 
 // resolveAuthRef provides authentication parameters needed authenticate scaler with the environment.
-// based on authentication method defined in TriggerAuthentication, authParams and podIdentity is returned
+// based on authentication method defined in TriggerAuthentication, authParams, podIdentity and the leases of Vault secrets is returned.
+// An error is returned if the reference to the TriggerAuthentication isn't allowed, so the scaler isn't built without authentication
 func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logger,
 	triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, podSpec *corev1.PodSpec,
-	namespace string) (map[string]string, kedav1alpha1.AuthPodIdentity) {
+	namespace string) (map[string]string, kedav1alpha1.AuthPodIdentity, *VaultLeases, error) {
 	result := make(map[string]string)
 	var podIdentity kedav1alpha1.AuthPodIdentity
+	var vaultLeases *VaultLeases
 
 	if namespace != "" && triggerAuthRef != nil && triggerAuthRef.Name != "" {
 		triggerAuthSpec, triggerNamespace, err := getTriggerAuthSpec(ctx, client, triggerAuthRef, namespace)
-		if err != nil {
+		switch {
+		case errors.Is(err, errAuthRefNotAllowed):
+			return nil, podIdentity, nil, err
+		case err != nil:
 			logger.Error(err, "Error getting triggerAuth", "triggerAuthRef.Name", triggerAuthRef.Name)
-		} else {
-			if triggerAuthSpec.PodIdentity != nil {
-				podIdentity = *triggerAuthSpec.PodIdentity
-			}
-			if triggerAuthSpec.Env != nil {
-				for _, e := range triggerAuthSpec.Env {
-					if podSpec == nil {
-						result[e.Parameter] = ""
-						continue
-					}
-					env, err := ResolveContainerEnv(ctx, client, logger, podSpec, e.ContainerName, namespace)
-					if err != nil {
-						result[e.Parameter] = ""
-					} else {
-						result[e.Parameter] = env[e.Name]
-					}
+		default:
+			result, podIdentity, vaultLeases = resolveTriggerAuthSpec(ctx, client, logger, triggerAuthRef.Name, triggerAuthSpec, podSpec, namespace, triggerNamespace)
+		}
+	}
+
+	return result, podIdentity, vaultLeases, nil
+}
+
+// UsesSecretStore returns whether the TriggerAuthentication or ClusterTriggerAuthentication referenced by the trigger reads
+// parameters from a secret store, resolving them reads or issues the secrets in HashiCorp Vault, Azure Key Vault,
+// AWS Secrets Manager or GCP Secret Manager
+func UsesSecretStore(ctx context.Context, client client.Client, triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, namespace string) (bool, error) {
+	if namespace == "" || triggerAuthRef == nil || triggerAuthRef.Name == "" {
+		return false, nil
+	}
+	triggerAuthSpec, _, err := getTriggerAuthSpec(ctx, client, triggerAuthRef, namespace)
+	if err != nil {
+		return false, err
+	}
+	return usesSecretStore(triggerAuthSpec), nil
+}
+
+func usesSecretStore(triggerAuthSpec *kedav1alpha1.TriggerAuthenticationSpec) bool {
+	return (triggerAuthSpec.HashiCorpVault != nil && len(triggerAuthSpec.HashiCorpVault.Secrets) > 0) ||
+		(triggerAuthSpec.AzureKeyVault != nil && len(triggerAuthSpec.AzureKeyVault.Secrets) > 0) ||
+		(triggerAuthSpec.AwsSecretManager != nil && len(triggerAuthSpec.AwsSecretManager.Secrets) > 0) ||
+		(triggerAuthSpec.GcpSecretManager != nil && len(triggerAuthSpec.GcpSecretManager.Secrets) > 0)
+}
+
+// CheckTriggerAuthenticationParameters checks the references of the parameters of a TriggerAuthentication or ClusterTriggerAuthentication
+// to report whether each of them resolves, without reading the secret stores: the parameters from Secrets are reported, and the parameters
+// from secret stores are only reported as unresolved if the Secrets holding the credentials of the secret store don't resolve
+func CheckTriggerAuthenticationParameters(ctx context.Context, client client.Client,
+	triggerAuthSpec *kedav1alpha1.TriggerAuthenticationSpec, triggerNamespace string) []kedav1alpha1.TriggerAuthenticationParameterStatus {
+	statuses := []kedav1alpha1.TriggerAuthenticationParameterStatus{}
+	record := func(source, parameter string, err error) {
+		status := kedav1alpha1.TriggerAuthenticationParameterStatus{Parameter: parameter, Source: source, Resolved: err == nil}
+		if err != nil {
+			status.Message = err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	checkCredentials := func(source string, parameters []string, secretKeyRefs ...kedav1alpha1.SecretKeyRef) {
+		for _, ref := range secretKeyRefs {
+			if _, err := resolveAuthSecretValue(ctx, client, ref.Name, triggerNamespace, ref.Key); err != nil {
+				for _, parameter := range parameters {
+					record(source, parameter, fmt.Errorf("error resolving the credentials of %s: %s", source, err))
 				}
+				return
 			}
-			if triggerAuthSpec.SecretTargetRef != nil {
-				for _, e := range triggerAuthSpec.SecretTargetRef {
-					result[e.Parameter] = resolveAuthSecret(ctx, client, logger, e.Name, triggerNamespace, e.Key)
-				}
+		}
+	}
+
+	for _, e := range triggerAuthSpec.SecretTargetRef {
+		_, err := resolveAuthSecretValue(ctx, client, e.Name, triggerNamespace, e.Key)
+		record("secretTargetRef", e.Parameter, err)
+	}
+	if vault := triggerAuthSpec.HashiCorpVault; vault != nil && vault.Credential != nil {
+		var parameters []string
+		for _, e := range vault.Secrets {
+			parameters = append(parameters, e.Parameter)
+		}
+		var refs []kedav1alpha1.SecretKeyRef
+		for _, value := range []*kedav1alpha1.VaultCredentialValue{vault.Credential.SecretID, vault.Credential.JWT} {
+			if value != nil {
+				refs = append(refs, value.ValueFrom.SecretKeyRef)
+			}
+		}
+		checkCredentials("hashiCorpVault", parameters, refs...)
+	}
+	if keyVault := triggerAuthSpec.AzureKeyVault; keyVault != nil && keyVault.Credentials != nil && keyVault.Credentials.ClientSecret != nil {
+		var parameters []string
+		for _, secret := range keyVault.Secrets {
+			parameters = append(parameters, secret.Parameter)
+		}
+		checkCredentials("azureKeyVault", parameters, keyVault.Credentials.ClientSecret.ValueFrom.SecretKeyRef)
+	}
+	if secretManager := triggerAuthSpec.AwsSecretManager; secretManager != nil && secretManager.Credentials != nil {
+		var parameters []string
+		for _, secret := range secretManager.Secrets {
+			parameters = append(parameters, secret.Parameter)
+		}
+		var refs []kedav1alpha1.SecretKeyRef
+		for _, value := range []*kedav1alpha1.AwsSecretManagerValue{secretManager.Credentials.AccessKey, secretManager.Credentials.AccessSecretKey, secretManager.Credentials.AccessToken} {
+			if value != nil {
+				refs = append(refs, value.ValueFrom.SecretKeyRef)
+			}
+		}
+		checkCredentials("awsSecretManager", parameters, refs...)
+	}
+	if secretManager := triggerAuthSpec.GcpSecretManager; secretManager != nil && secretManager.Credentials != nil {
+		var parameters []string
+		for _, secret := range secretManager.Secrets {
+			parameters = append(parameters, secret.Parameter)
+		}
+		checkCredentials("gcpSecretManager", parameters, secretManager.Credentials.ClientSecret.ValueFrom.SecretKeyRef)
+	}
+	return statuses
+}
+
+// resolveTriggerAuthSpec resolves the authentication parameters of the TriggerAuthentication spec, parameters which
+// can't be resolved are left out
+func resolveTriggerAuthSpec(ctx context.Context, client client.Client, logger logr.Logger, triggerAuthName string,
+	triggerAuthSpec *kedav1alpha1.TriggerAuthenticationSpec, podSpec *corev1.PodSpec,
+	namespace, triggerNamespace string) (map[string]string, kedav1alpha1.AuthPodIdentity, *VaultLeases) {
+	result := make(map[string]string)
+	var podIdentity kedav1alpha1.AuthPodIdentity
+	var vaultLeases *VaultLeases
+
+	if triggerAuthSpec.PodIdentity != nil {
+		podIdentity = *triggerAuthSpec.PodIdentity
+	}
+	if triggerAuthSpec.Env != nil {
+		for _, e := range triggerAuthSpec.Env {
+			if podSpec == nil {
+				result[e.Parameter] = ""
+				continue
+			}
+			env, err := ResolveContainerEnv(ctx, client, logger, podSpec, e.ContainerName, namespace)
+			if err != nil {
+				result[e.Parameter] = ""
+			} else {
+				result[e.Parameter] = env[e.Name]
+			}
+		}
+	}
+	if triggerAuthSpec.SecretTargetRef != nil {
+		for _, e := range triggerAuthSpec.SecretTargetRef {
+			value, err := resolveAuthSecretValue(ctx, client, e.Name, triggerNamespace, e.Key)
+			if err != nil {
+				logger.Error(err, "Error resolving secretTargetRef parameter", "triggerAuthRef.Name", triggerAuthName, "parameter", e.Parameter)
+			} else {
+				result[e.Parameter] = value
 			}
-			if triggerAuthSpec.HashiCorpVault != nil && len(triggerAuthSpec.HashiCorpVault.Secrets) > 0 {
-				vault := NewHashicorpVaultHandler(triggerAuthSpec.HashiCorpVault)
-				err := vault.Initialize(logger)
+		}
+	}
+	if triggerAuthSpec.HashiCorpVault != nil && len(triggerAuthSpec.HashiCorpVault.Secrets) > 0 {
+		vault := NewHashicorpVaultHandler(triggerAuthSpec.HashiCorpVault)
+		err := vault.Initialize(ctx, client, logger, triggerNamespace)
+		if err != nil {
+			logger.Error(err, "Error authenticate to Vault", "triggerAuthRef.Name", triggerAuthName)
+		} else {
+			vaultLeases = &VaultLeases{}
+			for _, e := range triggerAuthSpec.HashiCorpVault.Secrets {
+				secret, err := vault.RequestSecret(ctx, e)
 				if err != nil {
-					logger.Error(err, "Error authenticate to Vault", "triggerAuthRef.Name", triggerAuthRef.Name)
+					logger.Error(err, "Error trying to read secret from Vault", "triggerAuthRef.Name", triggerAuthName,
+						"secret.path", e.Path)
 				} else {
-					for _, e := range triggerAuthSpec.HashiCorpVault.Secrets {
-						secret, err := vault.Read(e.Path)
+					if secret == nil {
+						// sometimes there is no error, but `vault.RequestSecret(ctx, e)` is not being able to parse the secret and returns nil
+						err = fmt.Errorf("unable to parse secret, is the provided path correct?")
+						logger.Error(err, "Error trying to read secret from Vault",
+							"triggerAuthRef.Name", triggerAuthName, "secret.path", e.Path)
+					} else {
+						var value string
+						value, err = resolveVaultSecret(secret.Data, e.Key, e.Type)
 						if err != nil {
-							logger.Error(err, "Error trying to read secret from Vault", "triggerAuthRef.Name", triggerAuthRef.Name,
+							logger.Error(err, "Error trying to get key from Vault secret", "triggerAuthRef.Name", triggerAuthName,
 								"secret.path", e.Path)
 						} else {
-							if secret == nil {
-								// sometimes there is no error, but `vault.Read(e.Path)` is not being able to parse the secret and returns nil
-								logger.Error(fmt.Errorf("unable to parse secret, is the provided path correct?"), "Error trying to read secret from Vault",
-									"triggerAuthRef.Name", triggerAuthRef.Name, "secret.path", e.Path)
-							} else {
-								result[e.Parameter] = resolveVaultSecret(logger, secret.Data, e.Key)
-							}
+							result[e.Parameter] = value
 						}
+						vaultLeases.refreshTime = earliestLeaseRefreshTime(vaultLeases.refreshTime, vaultSecretLeaseDuration(secret, e.Type))
 					}
+				}
+			}
 
-					vault.Stop()
+			// the Vault token is kept renewed until the leases of the dynamic secrets are revoked
+			if len(vault.leaseIDs) > 0 {
+				vaultLeases.vault = vault
+			} else {
+				vault.Stop()
+			}
+		}
+	}
+	if triggerAuthSpec.AzureKeyVault != nil && len(triggerAuthSpec.AzureKeyVault.Secrets) > 0 {
+		vaultHandler := NewAzureKeyVaultHandler(triggerAuthSpec.AzureKeyVault, podIdentity)
+		err := vaultHandler.Initialize(ctx, client, logger, triggerNamespace)
+		if err != nil {
+			logger.Error(err, "Error authenticating to Azure Key Vault", "triggerAuthRef.Name", triggerAuthName)
+		} else {
+			for _, secret := range triggerAuthSpec.AzureKeyVault.Secrets {
+				res, err := vaultHandler.Read(ctx, secret.Name, secret.Version)
+				if err != nil {
+					logger.Error(err, "Error trying to read secret from Azure Key Vault", "triggerAuthRef.Name", triggerAuthName,
+						"secret.Name", secret.Name, "secret.Version", secret.Version)
+				} else {
+					result[secret.Parameter] = res
 				}
 			}
-			if triggerAuthSpec.AzureKeyVault != nil && len(triggerAuthSpec.AzureKeyVault.Secrets) > 0 {
-				vaultHandler := NewAzureKeyVaultHandler(triggerAuthSpec.AzureKeyVault, podIdentity)
-				err := vaultHandler.Initialize(ctx, client, logger, triggerNamespace)
+		}
+	}
+	if triggerAuthSpec.AwsSecretManager != nil && len(triggerAuthSpec.AwsSecretManager.Secrets) > 0 {
+		secretManagerHandler := NewAwsSecretManagerHandler(triggerAuthSpec.AwsSecretManager, podIdentity)
+		err := secretManagerHandler.Initialize(ctx, client, logger, triggerNamespace, podSpec, namespace)
+		if err != nil {
+			if !errors.Is(err, errScaleTargetRequired) {
+				logger.Error(err, "Error authenticating to AWS Secrets Manager", "triggerAuthRef.Name", triggerAuthName)
+			}
+		} else {
+			for _, secret := range triggerAuthSpec.AwsSecretManager.Secrets {
+				res, err := secretManagerHandler.Read(ctx, secret)
 				if err != nil {
-					logger.Error(err, "Error authenticating to Azure Key Vault", "triggerAuthRef.Name", triggerAuthRef.Name)
+					logger.Error(err, "Error trying to read secret from AWS Secrets Manager", "triggerAuthRef.Name", triggerAuthName,
+						"secret.Name", secret.Name, "secret.VersionID", secret.VersionID, "secret.VersionStage", secret.VersionStage)
 				} else {
-					for _, secret := range triggerAuthSpec.AzureKeyVault.Secrets {
-						res, err := vaultHandler.Read(ctx, secret.Name, secret.Version)
-						if err != nil {
-							logger.Error(err, "Error trying to read secret from Azure Key Vault", "triggerAuthRef.Name", triggerAuthRef.Name,
-								"secret.Name", secret.Name, "secret.Version", secret.Version)
-						} else {
-							result[secret.Parameter] = res
-						}
-					}
+					result[secret.Parameter] = res
 				}
 			}
 		}
 	}
+	if triggerAuthSpec.GcpSecretManager != nil && len(triggerAuthSpec.GcpSecretManager.Secrets) > 0 {
+		secretManagerHandler := NewGcpSecretManagerHandler(triggerAuthSpec.GcpSecretManager, podIdentity)
+		err := secretManagerHandler.Initialize(ctx, client, logger, triggerNamespace)
+		if err != nil {
+			logger.Error(err, "Error authenticating to GCP Secret Manager", "triggerAuthRef.Name", triggerAuthName)
+		} else {
+			for _, secret := range triggerAuthSpec.GcpSecretManager.Secrets {
+				res, err := secretManagerHandler.Read(ctx, secret.ID, secret.Version)
+				if err != nil {
+					logger.Error(err, "Error trying to read secret from GCP Secret Manager", "triggerAuthRef.Name", triggerAuthName,
+						"secret.ID", secret.ID, "secret.Version", secret.Version)
+				} else {
+					result[secret.Parameter] = res
+				}
+			}
+		}
+	}
+
+	return result, podIdentity, vaultLeases
+}
 
-	return result, podIdentity
+// earliestLeaseRefreshTime returns the earlier of the refresh time and the time when two thirds of the Vault lease
+// of the secret have elapsed, so the secret is read again before the lease expires
+func earliestLeaseRefreshTime(refreshTime time.Time, leaseDuration int) time.Time {
+	if leaseDuration <= 0 {
+		return refreshTime
+	}
+	leaseRefreshTime := time.Now().Add(time.Duration(leaseDuration) * time.Second * 2 / 3)
+	if refreshTime.IsZero() || leaseRefreshTime.Before(refreshTime) {
+		return leaseRefreshTime
+	}
+	return refreshTime
 }
 
 var clusterObjectNamespaceCache *string
 
-func getClusterObjectNamespace() (string, error) {
+// GetClusterObjectNamespace returns the namespace of the secrets referenced by ClusterTriggerAuthentications
+func GetClusterObjectNamespace() (string, error) {
 	// Check if a cached value is available.
 	if clusterObjectNamespaceCache != nil {
 		return *clusterObjectNamespaceCache, nil
@@ -260,14 +458,24 @@ func getClusterObjectNamespace() (string, error) {
 
 func getTriggerAuthSpec(ctx context.Context, client client.Client, triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, namespace string) (*kedav1alpha1.TriggerAuthenticationSpec, string, error) {
 	if triggerAuthRef.Kind == "" || triggerAuthRef.Kind == "TriggerAuthentication" {
+		triggerAuthNamespace := namespace
+		if triggerAuthRef.Namespace != "" && triggerAuthRef.Namespace != namespace {
+			if err := checkTriggerAuthenticationGrant(ctx, client, triggerAuthRef.Name, triggerAuthRef.Namespace, namespace); err != nil {
+				return nil, "", err
+			}
+			triggerAuthNamespace = triggerAuthRef.Namespace
+		}
 		triggerAuth := &kedav1alpha1.TriggerAuthentication{}
-		err := client.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name, Namespace: namespace}, triggerAuth)
+		err := client.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name, Namespace: triggerAuthNamespace}, triggerAuth)
 		if err != nil {
 			return nil, "", err
 		}
-		return &triggerAuth.Spec, namespace, nil
+		return &triggerAuth.Spec, triggerAuthNamespace, nil
 	} else if triggerAuthRef.Kind == "ClusterTriggerAuthentication" {
-		clusterNamespace, err := getClusterObjectNamespace()
+		if triggerAuthRef.Namespace != "" {
+			return nil, "", fmt.Errorf("%w: namespace can't be set on a reference to ClusterTriggerAuthentication %s", errAuthRefNotAllowed, triggerAuthRef.Name)
+		}
+		clusterNamespace, err := GetClusterObjectNamespace()
 		if err != nil {
 			return nil, "", err
 		}
@@ -281,6 +489,21 @@ func getTriggerAuthSpec(ctx context.Context, client client.Client, triggerAuthRe
 	return nil, "", fmt.Errorf("unknown trigger auth kind %s", triggerAuthRef.Kind)
 }
 
+// checkTriggerAuthenticationGrant returns an error if no TriggerAuthenticationGrant in the namespace of the TriggerAuthentication
+// allows the namespace of the ScaledObject or ScaledJob to reference it
+func checkTriggerAuthenticationGrant(ctx context.Context, kubeClient client.Client, name, triggerAuthNamespace, namespace string) error {
+	grants := &kedav1alpha1.TriggerAuthenticationGrantList{}
+	if err := kubeClient.List(ctx, grants, client.InNamespace(triggerAuthNamespace)); err != nil {
+		return fmt.Errorf("%w: error listing TriggerAuthenticationGrants in namespace %s: %s", errAuthRefNotAllowed, triggerAuthNamespace, err)
+	}
+	for _, grant := range grants.Items {
+		if grant.Spec.Allows(namespace, name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no TriggerAuthenticationGrant in namespace %s allows namespace %s to reference TriggerAuthentication %s", errAuthRefNotAllowed, triggerAuthNamespace, namespace, name)
+}
+
 func resolveEnv(ctx context.Context, client client.Client, logger logr.Logger, container *corev1.Container, namespace string) (map[string]string, error) {
 	resolved := make(map[string]string)
 
@@ -447,38 +670,59 @@ func resolveConfigValue(ctx context.Context, client client.Client, configKeyRef
 }
 
 func resolveAuthSecret(ctx context.Context, client client.Client, logger logr.Logger, name, namespace, key string) string {
-	if name == "" || namespace == "" || key == "" {
-		logger.Error(fmt.Errorf("error trying to get secret"), "name, namespace and key are required", "Secret.Namespace", namespace, "Secret.Name", name, "key", key)
+	value, err := resolveAuthSecretValue(ctx, client, name, namespace, key)
+	if err != nil {
+		logger.Error(err, "Error trying to get secret", "Secret.Namespace", namespace, "Secret.Name", name, "key", key)
 		return ""
 	}
+	return value
+}
+
+// resolveAuthSecretValue returns the value of the key of the Secret, or an error if the Secret or its key doesn't exist
+func resolveAuthSecretValue(ctx context.Context, client client.Client, name, namespace, key string) (string, error) {
+	if name == "" || namespace == "" || key == "" {
+		return "", fmt.Errorf("name, namespace and key of the secret are required")
+	}
 
 	secret := &corev1.Secret{}
 	err := client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret)
 	if err != nil {
-		logger.Error(err, "Error trying to get secret from namespace", "Secret.Namespace", namespace, "Secret.Name", name)
-		return ""
+		return "", fmt.Errorf("error getting secret %s/%s: %s", namespace, name, err)
 	}
-	result := secret.Data[key]
-
-	if result == nil {
-		return ""
+	result, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s/%s", key, namespace, name)
 	}
 
-	return string(result)
+	return string(result), nil
 }
 
-func resolveVaultSecret(logger logr.Logger, data map[string]interface{}, key string) string {
-	if v2Data, ok := data["data"].(map[string]interface{}); ok {
-		if value, ok := v2Data[key]; ok {
-			if s, ok := value.(string); ok {
-				return s
-			}
-		} else {
-			logger.Error(fmt.Errorf("key '%s' not found", key), "Error trying to get key from Vault secret")
-			return ""
+// resolveVaultSecret returns the value of the key in the data of the secret, which is nested in the data of KV version 2
+// secrets, secrets of unspecified type are looked up as KV version 2 secrets first
+func resolveVaultSecret(data map[string]interface{}, key string, secretType kedav1alpha1.VaultSecretType) (string, error) {
+	switch secretType {
+	case kedav1alpha1.VaultSecretTypeSecretV2:
+		v2Data, ok := data["data"].(map[string]interface{})
+		if !ok {
+			break
+		}
+		data = v2Data
+	case kedav1alpha1.VaultSecretTypeSecret, kedav1alpha1.VaultSecretTypePki:
+	default:
+		if v2Data, ok := data["data"].(map[string]interface{}); ok {
+			data = v2Data
 		}
 	}
 
-	logger.Error(fmt.Errorf("unable to convert Vault Data value"), "Error trying to convert Data secret vaule")
-	return ""
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key '%s' not found in Vault secret", key)
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("unable to convert the value of key '%s' of Vault secret", key)
 }
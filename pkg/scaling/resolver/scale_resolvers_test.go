@@ -19,6 +19,7 @@ package resolver
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
@@ -34,6 +35,7 @@ import (
 var (
 	namespace                 = "test-namespace"
 	clusterNamespace          = "keda"
+	credentialsNamespace      = "credentials-namespace"
 	triggerAuthenticationName = "triggerauth"
 	secretName                = "supersecret"
 	secretKey                 = "mysecretkey"
@@ -174,6 +176,7 @@ func TestResolveAuthRef(t *testing.T) {
 		podSpec             *corev1.PodSpec
 		expected            map[string]string
 		expectedPodIdentity kedav1alpha1.AuthPodIdentity
+		isError             bool
 	}{
 		{
 			name:     "foo",
@@ -209,7 +212,7 @@ func TestResolveAuthRef(t *testing.T) {
 				},
 			},
 			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName},
-			expected: map[string]string{"host": ""},
+			expected: make(map[string]string),
 		},
 		{
 			name: "triggerauth exists and secret",
@@ -262,7 +265,7 @@ func TestResolveAuthRef(t *testing.T) {
 				},
 			},
 			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
-			expected: map[string]string{"host": ""},
+			expected: make(map[string]string),
 		},
 		{
 			name: "clustertriggerauth exists and secret",
@@ -323,22 +326,86 @@ func TestResolveAuthRef(t *testing.T) {
 					Data: map[string][]byte{secretKey: []byte(secretData)}},
 			},
 			soar:                &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
-			expected:            map[string]string{"host": ""},
+			expected:            make(map[string]string),
 			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
 		},
+
+		{
+			name: "triggerauth in other namespace granted",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{Namespace: credentialsNamespace, Name: triggerAuthenticationName},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: secretName, Key: secretKey}},
+					},
+				},
+				&kedav1alpha1.TriggerAuthenticationGrant{
+					ObjectMeta: metav1.ObjectMeta{Namespace: credentialsNamespace, Name: "grant"},
+					Spec: kedav1alpha1.TriggerAuthenticationGrantSpec{
+						From: []kedav1alpha1.TriggerAuthenticationGrantFrom{{Namespace: namespace}},
+						To:   []kedav1alpha1.TriggerAuthenticationGrantTo{{Name: triggerAuthenticationName}},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: credentialsNamespace, Name: secretName},
+					Data:       map[string][]byte{secretKey: []byte(secretData)},
+				},
+			},
+			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Namespace: credentialsNamespace},
+			expected: map[string]string{"host": secretData},
+		},
+		{
+			name: "triggerauth in other namespace without grant",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{Namespace: credentialsNamespace, Name: triggerAuthenticationName},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: secretName, Key: secretKey}},
+					},
+				},
+				&kedav1alpha1.TriggerAuthenticationGrant{
+					ObjectMeta: metav1.ObjectMeta{Namespace: credentialsNamespace, Name: "grant"},
+					Spec: kedav1alpha1.TriggerAuthenticationGrantSpec{
+						From: []kedav1alpha1.TriggerAuthenticationGrantFrom{{Namespace: "other-namespace"}},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: credentialsNamespace, Name: secretName},
+					Data:       map[string][]byte{secretKey: []byte(secretData)},
+				},
+			},
+			soar:    &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Namespace: credentialsNamespace},
+			isError: true,
+		},
+		{
+			name: "clustertriggerauth with namespace",
+			existing: []runtime.Object{
+				&kedav1alpha1.ClusterTriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{Name: triggerAuthenticationName},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: secretName, Key: secretKey}},
+					},
+				},
+			},
+			soar:    &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication", Namespace: credentialsNamespace},
+			isError: true,
+		},
 	}
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
 			ctx := context.Background()
 			clusterObjectNamespaceCache = &clusterNamespace // Inject test cluster namespace.
-			gotMap, gotPodIdentity := resolveAuthRef(
+			gotMap, gotPodIdentity, _, err := resolveAuthRef(
 				ctx,
 				fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.existing...).Build(),
 				logf.Log.WithName("test"),
 				test.soar,
 				test.podSpec,
 				namespace)
+			if test.isError != (err != nil) {
+				t.Errorf("Expected error %v, got %v", test.isError, err)
+			}
 			if diff := cmp.Diff(gotMap, test.expected); diff != "" {
 				t.Errorf("Returned authParams are different: %s", diff)
 			}
@@ -463,3 +530,112 @@ func TestResolveDependentEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestEarliestLeaseRefreshTime(t *testing.T) {
+	now := time.Now()
+
+	if refreshTime := earliestLeaseRefreshTime(time.Time{}, 0); !refreshTime.IsZero() {
+		t.Errorf("Expected no refresh time for secret without lease, got %s", refreshTime)
+	}
+
+	refreshTime := earliestLeaseRefreshTime(time.Time{}, 300)
+	if refreshTime.Before(now.Add(200*time.Second)) || refreshTime.After(time.Now().Add(200*time.Second)) {
+		t.Errorf("Expected refresh time after two thirds of the lease, got %s", refreshTime.Sub(now))
+	}
+
+	if earlier := earliestLeaseRefreshTime(refreshTime, 3000); !earlier.Equal(refreshTime) {
+		t.Errorf("Expected the earlier refresh time %s, got %s", refreshTime, earlier)
+	}
+
+	if earlier := earliestLeaseRefreshTime(refreshTime, 30); !earlier.Before(refreshTime) {
+		t.Errorf("Expected refresh time before %s, got %s", refreshTime, earlier)
+	}
+}
+
+func TestCheckTriggerAuthenticationParameters(t *testing.T) {
+	spec := &kedav1alpha1.TriggerAuthenticationSpec{
+		SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+			{Parameter: "host", Name: secretName, Key: secretKey},
+			{Parameter: "password", Name: secretName, Key: "missing"},
+		},
+		Env: []kedav1alpha1.AuthEnvironment{{Parameter: "user", Name: envKey}},
+		HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+			Address:        "http://vault:8200",
+			Authentication: kedav1alpha1.VaultAuthenticationAppRole,
+			Credential: &kedav1alpha1.Credential{
+				RoleID:   "keda-role",
+				SecretID: &kedav1alpha1.VaultCredentialValue{ValueFrom: kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "approle", Key: "secretId"}}},
+			},
+			Secrets: []kedav1alpha1.VaultSecret{{Parameter: "token", Path: "secret/data/keda", Key: "token"}},
+		},
+		AzureKeyVault: &kedav1alpha1.AzureKeyVault{
+			VaultURI: "https://keda.vault.azure.net",
+			Credentials: &kedav1alpha1.AzureKeyVaultCredentials{
+				ClientSecret: &kedav1alpha1.AzureKeyVaultClientSecret{ValueFrom: kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: secretName, Key: secretKey}}},
+			},
+			Secrets: []kedav1alpha1.AzureKeyVaultSecret{{Parameter: "connection", Name: "connection"}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: secretName},
+		Data:       map[string][]byte{secretKey: []byte(secretData)},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(secret).Build()
+
+	// the secret stores aren't read, only the missing Secret holding the Vault credentials is reported
+	statuses := CheckTriggerAuthenticationParameters(context.TODO(), client, spec, namespace)
+	expected := []kedav1alpha1.TriggerAuthenticationParameterStatus{
+		{Parameter: "host", Source: "secretTargetRef", Resolved: true},
+		{Parameter: "password", Source: "secretTargetRef", Resolved: false, Message: "key missing not found in secret test-namespace/supersecret"},
+		{Parameter: "token", Source: "hashiCorpVault", Resolved: false,
+			Message: "error resolving the credentials of hashiCorpVault: error getting secret test-namespace/approle: secrets \"approle\" not found"},
+	}
+	if diff := cmp.Diff(statuses, expected); diff != "" {
+		t.Errorf("Returned parameter statuses are different: %s", diff)
+	}
+}
+
+func TestUsesSecretStore(t *testing.T) {
+	if err := kedav1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		t.Errorf("Expected Error because: %v", err)
+	}
+	vaultTriggerAuth := &kedav1alpha1.TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "vault"},
+		Spec: kedav1alpha1.TriggerAuthenticationSpec{
+			HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+				Secrets: []kedav1alpha1.VaultSecret{{Parameter: "password", Path: "secret/data/keda", Key: "password"}},
+			},
+		},
+	}
+	secretTriggerAuth := &kedav1alpha1.TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "secret"},
+		Spec: kedav1alpha1.TriggerAuthenticationSpec{
+			SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "password", Name: secretName, Key: secretKey}},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(vaultTriggerAuth, secretTriggerAuth).Build()
+
+	tests := []struct {
+		name     string
+		authRef  *kedav1alpha1.ScaledObjectAuthRef
+		expected bool
+		isError  bool
+	}{
+		{name: "no authentication", authRef: nil, expected: false},
+		{name: "secret store", authRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "vault"}, expected: true},
+		{name: "kubernetes secret", authRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "secret"}, expected: false},
+		{name: "missing TriggerAuthentication", authRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "missing"}, isError: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			usesSecretStore, err := UsesSecretStore(context.TODO(), client, test.authRef, namespace)
+			if test.isError != (err != nil) {
+				t.Errorf("Expected error %v, got %v", test.isError, err)
+			}
+			if usesSecretStore != test.expected {
+				t.Errorf("Expected %v, got %v", test.expected, usesSecretStore)
+			}
+		})
+	}
+}
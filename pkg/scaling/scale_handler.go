@@ -28,11 +28,14 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/metrics/pkg/apis/external_metrics"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
+	"github.com/kedacore/keda/v2/pkg/metricscache"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/executor"
@@ -46,6 +49,8 @@ type ScaleHandler interface {
 	DeleteScalableObject(ctx context.Context, scalableObject interface{}) error
 	GetScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, error)
 	ClearScalersCache(ctx context.Context, scalableObject interface{}) error
+	RefreshScalersUsingAuth(ctx context.Context, authRefKind, namespace, name string)
+	GetMetricsCache() *metricscache.MetricsCache
 }
 
 type scaleHandler struct {
@@ -57,6 +62,7 @@ type scaleHandler struct {
 	recorder          record.EventRecorder
 	scalerCaches      map[string]*cache.ScalersCache
 	lock              *sync.RWMutex
+	metricsCache      *metricscache.MetricsCache
 }
 
 // NewScaleHandler creates a ScaleHandler object
@@ -70,6 +76,7 @@ func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, recon
 		recorder:          recorder,
 		scalerCaches:      map[string]*cache.ScalersCache{},
 		lock:              &sync.RWMutex{},
+		metricsCache:      metricscache.NewMetricsCache(),
 	}
 }
 
@@ -125,6 +132,9 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 			cancel()
 		}
 		h.scaleLoopContexts.Delete(key)
+		if obj, ok := scalableObject.(*kedav1alpha1.ScaledObject); ok {
+			h.metricsCache.Delete(metricscache.ScaledObjectIdentifier(obj.Namespace, obj.Name))
+		}
 		err := h.ClearScalersCache(ctx, scalableObject)
 		if err != nil {
 			h.logger.Error(err, "error clearing scalers cache")
@@ -191,7 +201,7 @@ func (h *scaleHandler) GetScalersCache(ctx context.Context, scalableObject inter
 		return nil, err
 	}
 
-	scalers, err := h.buildScalers(ctx, withTriggers, podTemplateSpec, containerName)
+	scalers, err := h.buildScalers(ctx, withTriggers, getScaleTargetRef(scalableObject), podTemplateSpec, containerName)
 	if err != nil {
 		return nil, err
 	}
@@ -225,6 +235,43 @@ func (h *scaleHandler) ClearScalersCache(ctx context.Context, scalableObject int
 	return nil
 }
 
+// RefreshScalersUsingAuth rebuilds the cached scalers which reference the TriggerAuthentication in the namespace
+// or the ClusterTriggerAuthentication, so they use the updated authentication without restarting the scale loops.
+// The caches with scalers which can't be rebuilt, eg. because the reference to the TriggerAuthentication isn't
+// granted anymore, are closed and removed so the scalers aren't used with the previous authentication
+func (h *scaleHandler) RefreshScalersUsingAuth(ctx context.Context, authRefKind, namespace, name string) {
+	h.logger.V(1).Info("Refreshing scalers using authentication", "kind", authRefKind, "namespace", namespace, "name", name)
+
+	h.lock.RLock()
+	caches := make(map[string]*cache.ScalersCache, len(h.scalerCaches))
+	for key, cache := range h.scalerCaches {
+		caches[key] = cache
+	}
+	h.lock.RUnlock()
+
+	for key, scalersCache := range caches {
+		err := scalersCache.RefreshScalers(ctx, func(config *scalers.ScalerConfig) bool {
+			return usesAuthRef(config, authRefKind, namespace, name)
+		})
+		if err == nil {
+			continue
+		}
+
+		h.logger.Error(err, "Error refreshing scalers using authentication, clearing scalers cache", "key", key)
+		h.lock.Lock()
+		if h.scalerCaches[key] == scalersCache {
+			delete(h.scalerCaches, key)
+		}
+		h.lock.Unlock()
+		scalersCache.Close(ctx)
+	}
+}
+
+// GetMetricsCache returns cache with metrics of triggers with useCachedMetrics enabled, collected during polling of ScaledObjects
+func (h *scaleHandler) GetMetricsCache() *metricscache.MetricsCache {
+	return h.metricsCache
+}
+
 func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, scalingMutex sync.Locker) {
 	logger := h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
 	cache, err := h.GetScalersCache(ctx, scalableObject)
@@ -268,6 +315,8 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 
 	scalingMutex.Lock()
 	defer scalingMutex.Unlock()
+	cache.RefreshExpiredScalers(ctx)
+	start := time.Now()
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
 		err = h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
@@ -275,21 +324,24 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			h.logger.Error(err, "Error getting scaledObject", "object", scalableObject)
 			return
 		}
-		isActive, isError, _ := cache.IsScaledObjectActive(ctx, obj)
+		isActive, isError, metrics := cache.IsScaledObjectActive(ctx, obj)
+		h.storeCachedMetrics(obj, metrics)
 		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError)
+		prommetrics.RecordScaleLoopLatency(obj.Namespace, prommetrics.ScaledObjectType, obj.Name, time.Since(start))
 	case *kedav1alpha1.ScaledJob:
 		err = h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
 		if err != nil {
 			h.logger.Error(err, "Error getting scaledJob", "object", scalableObject)
 			return
 		}
-		isActive, scaleTo, maxScale := cache.IsScaledJobActive(ctx, obj)
-		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, scaleTo, maxScale)
+		isActive, scaleTo, maxScale, triggerName := cache.IsScaledJobActive(ctx, obj)
+		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, scaleTo, maxScale, triggerName)
+		prommetrics.RecordScaleLoopLatency(obj.Namespace, prommetrics.ScaledJobType, obj.Name, time.Since(start))
 	}
 }
 
 // buildScalers returns list of Scalers for the specified triggers
-func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, podTemplateSpec *corev1.PodTemplateSpec, containerName string) ([]cache.ScalerBuilder, error) {
+func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scaleTargetRef *kedav1alpha1.ScaleTarget, podTemplateSpec *corev1.PodTemplateSpec, containerName string) ([]cache.ScalerBuilder, error) {
 	logger := h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
 	var err error
 	resolvedEnv := make(map[string]string)
@@ -298,34 +350,31 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 	for i, t := range withTriggers.Spec.Triggers {
 		triggerIndex, trigger := i, t
 
-		factory := func() (scalers.Scaler, error) {
+		factory := func() (scalers.Scaler, *scalers.ScalerConfig, error) {
 			if podTemplateSpec != nil {
 				resolvedEnv, err = resolver.ResolveContainerEnv(ctx, h.client, logger, &podTemplateSpec.Spec, containerName, withTriggers.Namespace)
 				if err != nil {
-					return nil, fmt.Errorf("error resolving secrets for ScaleTarget: %s", err)
+					return nil, nil, fmt.Errorf("error resolving secrets for ScaleTarget: %s", err)
 				}
 			}
-			config := &scalers.ScalerConfig{
-				ScalableObjectName:      withTriggers.Name,
-				ScalableObjectNamespace: withTriggers.Namespace,
-				ScalableObjectType:      withTriggers.Kind,
-				TriggerMetadata:         trigger.Metadata,
-				ResolvedEnv:             resolvedEnv,
-				AuthParams:              make(map[string]string),
-				GlobalHTTPTimeout:       h.globalHTTPTimeout,
-				ScalerIndex:             triggerIndex,
-				MetricType:              trigger.MetricType,
-			}
+			config := newScalerConfig(withTriggers, scaleTargetRef, trigger, triggerIndex, resolvedEnv, h.globalHTTPTimeout)
 
-			config.AuthParams, config.PodIdentity, err = resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace)
+			var vaultLeases *resolver.VaultLeases
+			config.AuthParams, config.PodIdentity, vaultLeases, err = resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
+			config.AuthRefreshTime = vaultLeases.RefreshTime()
+			config.RevokeAuthLeases = vaultLeases.Revoke
 
-			return buildScaler(ctx, h.client, trigger.Type, config)
+			scaler, err := buildScaler(ctx, h.client, trigger.Type, config)
+			if err != nil {
+				vaultLeases.Revoke(ctx)
+			}
+			return scaler, config, err
 		}
 
-		scaler, err := factory()
+		scaler, scalerConfig, err := factory()
 		if err != nil {
 			h.recorder.Event(withTriggers, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
 			h.logger.Error(err, "error resolving auth params", "scalerIndex", triggerIndex, "object", withTriggers)
@@ -333,20 +382,167 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 				scaler.Close(ctx)
 			}
 			for _, builder := range result {
-				builder.Scaler.Close(ctx)
+				builder.Close(ctx)
 			}
 			return nil, err
 		}
 
 		result = append(result, cache.ScalerBuilder{
-			Scaler:  scaler,
-			Factory: factory,
+			Scaler:       scaler,
+			ScalerConfig: *scalerConfig,
+			Factory:      factory,
 		})
 	}
 
 	return result, nil
 }
 
+// storeCachedMetrics stores metrics of the triggers with useCachedMetrics enabled, records expire
+// if they are not refreshed within two polling intervals of the ScaledObject
+func (h *scaleHandler) storeCachedMetrics(scaledObject *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue) {
+	withTriggers, err := asDuckWithTriggers(scaledObject)
+	if err != nil {
+		h.logger.Error(err, "error duck typing object into withTrigger")
+		return
+	}
+
+	expiresAt := time.Now().Add(2 * withTriggers.GetPollingInterval())
+	records := map[string]metricscache.MetricsRecord{}
+	for _, metric := range metrics {
+		record := records[metric.MetricName]
+		record.Metrics = append(record.Metrics, metric)
+		record.ExpiresAt = expiresAt
+		records[metric.MetricName] = record
+	}
+	h.metricsCache.StoreRecords(metricscache.ScaledObjectIdentifier(scaledObject.Namespace, scaledObject.Name), records)
+}
+
+// ValidateTriggers validates the metadata of all triggers of the scalable object, so misconfigured
+// trigger metadata is detected before the object is reconciled. Triggers are not validated if the scale target
+// or their authentication can't be resolved yet, as the referenced resources might be created later on.
+func ValidateTriggers(ctx context.Context, client client.Client, logger logr.Logger, scalableObject interface{}, globalHTTPTimeout time.Duration) error {
+	withTriggers, err := asDuckWithTriggers(scalableObject)
+	if err != nil {
+		return err
+	}
+
+	for triggerIndex, trigger := range withTriggers.Spec.Triggers {
+		if authRef := trigger.AuthenticationRef; authRef != nil && authRef.Kind == "ClusterTriggerAuthentication" && authRef.Namespace != "" {
+			return fmt.Errorf("trigger %d of type %s is invalid: namespace can't be set on a reference to ClusterTriggerAuthentication", triggerIndex, trigger.Type)
+		}
+	}
+
+	podTemplateSpec, containerName, err := resolver.ResolveScaleTargetPodSpec(ctx, client, logger, scalableObject)
+	if err != nil {
+		logger.V(1).Info("Skipping validation of triggers, scale target can't be resolved", "reason", err.Error())
+		return nil
+	}
+
+	resolvedEnv := make(map[string]string)
+	if podTemplateSpec != nil {
+		resolvedEnv, err = resolver.ResolveContainerEnv(ctx, client, logger, &podTemplateSpec.Spec, containerName, withTriggers.Namespace)
+		if err != nil {
+			logger.V(1).Info("Skipping validation of triggers, environment of scale target can't be resolved", "reason", err.Error())
+			return nil
+		}
+	}
+
+	for triggerIndex, trigger := range withTriggers.Spec.Triggers {
+		// secrets aren't read or issued from secret stores on admission, the trigger is validated once its scaler is created
+		usesSecretStore, err := resolver.UsesSecretStore(ctx, client, trigger.AuthenticationRef, withTriggers.Namespace)
+		if err != nil || usesSecretStore {
+			logger.V(1).Info("Skipping validation of trigger, authentication is read from a secret store or can't be resolved", "scalerIndex", triggerIndex)
+			continue
+		}
+
+		config := newScalerConfig(withTriggers, getScaleTargetRef(scalableObject), trigger, triggerIndex, resolvedEnv, globalHTTPTimeout)
+		config.AuthParams, config.PodIdentity, _, err = resolver.ResolveAuthRefAndPodIdentity(ctx, client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace)
+		if err != nil {
+			logger.V(1).Info("Skipping validation of trigger, authentication can't be resolved", "scalerIndex", triggerIndex, "reason", err.Error())
+			continue
+		}
+
+		err = validateTrigger(ctx, client, trigger.Type, config)
+		if err != nil {
+			return fmt.Errorf("trigger %d of type %s is invalid: %s", triggerIndex, trigger.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// validateTrigger validates the metadata of the trigger. Scalers which connect to their backend when they are created
+// only have their metadata parsed, so that the validation doesn't fail when the backend is briefly unreachable
+func validateTrigger(ctx context.Context, client client.Client, triggerType string, config *scalers.ScalerConfig) error {
+	if validated, err := scalers.ValidateMetadata(triggerType, config); validated {
+		return err
+	}
+
+	scaler, err := buildScaler(ctx, client, triggerType, config)
+	if scaler != nil {
+		scaler.Close(ctx)
+	}
+	return err
+}
+
+func newScalerConfig(withTriggers *kedav1alpha1.WithTriggers, scaleTargetRef *kedav1alpha1.ScaleTarget, trigger kedav1alpha1.ScaleTriggers, triggerIndex int, resolvedEnv map[string]string, globalHTTPTimeout time.Duration) *scalers.ScalerConfig {
+	return &scalers.ScalerConfig{
+		ScalableObjectName:      withTriggers.Name,
+		ScalableObjectNamespace: withTriggers.Namespace,
+		ScalableObjectType:      withTriggers.Kind,
+		ScaleTargetRef:          scaleTargetRef,
+		TriggerMetadata:         trigger.Metadata,
+		ResolvedEnv:             resolvedEnv,
+		AuthParams:              make(map[string]string),
+		GlobalHTTPTimeout:       globalHTTPTimeout,
+		ScalerIndex:             triggerIndex,
+		TriggerType:             trigger.Type,
+		TriggerName:             trigger.Name,
+		MetricType:              trigger.MetricType,
+		AuthenticationRef:       trigger.AuthenticationRef,
+	}
+}
+
+// usesAuthRef returns whether the trigger of the scaler references the TriggerAuthentication in the namespace, which can be
+// another namespace than the one of the scaler, or the ClusterTriggerAuthentication
+func usesAuthRef(config *scalers.ScalerConfig, authRefKind, namespace, name string) bool {
+	authRef := config.AuthenticationRef
+	if authRef == nil || authRef.Name != name {
+		return false
+	}
+	kind := authRef.Kind
+	if kind == "" {
+		kind = "TriggerAuthentication"
+	}
+	if kind != authRefKind {
+		return false
+	}
+	if kind == "ClusterTriggerAuthentication" {
+		return true
+	}
+	authRefNamespace := authRef.Namespace
+	if authRefNamespace == "" {
+		authRefNamespace = config.ScalableObjectNamespace
+	}
+	return authRefNamespace == namespace
+}
+
+// getScaleTargetRef returns the scale target of a ScaledObject with the kind and apiVersion resolved by KEDA Operator,
+// nil is returned for ScaledJob as it doesn't have a named scale target
+func getScaleTargetRef(scalableObject interface{}) *kedav1alpha1.ScaleTarget {
+	scaledObject, ok := scalableObject.(*kedav1alpha1.ScaledObject)
+	if !ok || scaledObject.Spec.ScaleTargetRef == nil {
+		return nil
+	}
+
+	scaleTargetRef := scaledObject.Spec.ScaleTargetRef.DeepCopy()
+	if gvkr := scaledObject.Status.ScaleTargetGVKR; gvkr != nil {
+		scaleTargetRef.APIVersion = gvkr.GroupVersion().String()
+		scaleTargetRef.Kind = gvkr.Kind
+	}
+	return scaleTargetRef
+}
+
 func buildScaler(ctx context.Context, client client.Client, triggerType string, config *scalers.ScalerConfig) (scalers.Scaler, error) {
 	// TRIGGERS-START
 	switch triggerType {
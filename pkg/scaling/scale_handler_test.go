@@ -19,17 +19,22 @@ package scaling
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
+	"github.com/kedacore/keda/v2/pkg/metricscache"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
@@ -39,13 +44,13 @@ func TestCheckScaledObjectScalersWithError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	recorder := record.NewFakeRecorder(1)
 
-	factory := func() (scalers.Scaler, error) {
+	factory := func() (scalers.Scaler, *scalers.ScalerConfig, error) {
 		scaler := mock_scalers.NewMockScaler(ctrl)
 		scaler.EXPECT().IsActive(gomock.Any()).Return(false, errors.New("some error"))
 		scaler.EXPECT().Close(gomock.Any())
-		return scaler, nil
+		return scaler, &scalers.ScalerConfig{}, nil
 	}
-	scaler, err := factory()
+	scaler, _, err := factory()
 	assert.Nil(t, err)
 
 	scaledObject := kedav1alpha1.ScaledObject{
@@ -82,23 +87,23 @@ func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 
 	metricsSpecs := []v2beta2.MetricSpec{createMetricSpec(1)}
 
-	activeFactory := func() (scalers.Scaler, error) {
+	activeFactory := func() (scalers.Scaler, *scalers.ScalerConfig, error) {
 		scaler := mock_scalers.NewMockScaler(ctrl)
 		scaler.EXPECT().IsActive(gomock.Any()).Return(true, nil)
 		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Times(2).Return(metricsSpecs)
 		scaler.EXPECT().Close(gomock.Any())
-		return scaler, nil
+		return scaler, &scalers.ScalerConfig{}, nil
 	}
-	activeScaler, err := activeFactory()
+	activeScaler, _, err := activeFactory()
 	assert.Nil(t, err)
 
-	failingFactory := func() (scalers.Scaler, error) {
+	failingFactory := func() (scalers.Scaler, *scalers.ScalerConfig, error) {
 		scaler := mock_scalers.NewMockScaler(ctrl)
 		scaler.EXPECT().IsActive(gomock.Any()).Return(false, errors.New("some error"))
 		scaler.EXPECT().Close(gomock.Any())
-		return scaler, nil
+		return scaler, &scalers.ScalerConfig{}, nil
 	}
-	failingScaler, err := failingFactory()
+	failingScaler, _, err := failingFactory()
 	assert.Nil(t, err)
 
 	scaledObject := &kedav1alpha1.ScaledObject{
@@ -134,6 +139,96 @@ func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 	assert.Equal(t, true, isError)
 }
 
+func TestRefreshScalersUsingAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	authRef := &kedav1alpha1.ScaledObjectAuthRef{Name: "auth"}
+	usingAuthConfig := scalers.ScalerConfig{ScalableObjectNamespace: "test", AuthenticationRef: authRef}
+	newScaler := func() scalers.Scaler {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().Close(gomock.Any())
+		return scaler
+	}
+
+	refreshedScaler := mock_scalers.NewMockScaler(ctrl)
+	refreshedCache := &cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       newScaler(),
+			ScalerConfig: usingAuthConfig,
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return refreshedScaler, &usingAuthConfig, nil
+			},
+		}},
+		Logger: logf.Log.WithName("scalercache"),
+	}
+	// the scaler can't be rebuilt, eg. the reference to the TriggerAuthentication isn't granted anymore
+	failingCache := &cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       newScaler(),
+			ScalerConfig: usingAuthConfig,
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				return nil, nil, errors.New("reference not granted")
+			},
+		}},
+		Logger: logf.Log.WithName("scalercache"),
+	}
+	otherScaler := mock_scalers.NewMockScaler(ctrl)
+	otherCache := &cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{{
+			Scaler:       otherScaler,
+			ScalerConfig: scalers.ScalerConfig{ScalableObjectNamespace: "other", AuthenticationRef: authRef},
+			Factory: func() (scalers.Scaler, *scalers.ScalerConfig, error) {
+				t.Error("Scaler not using the TriggerAuthentication was refreshed")
+				return nil, nil, nil
+			},
+		}},
+		Logger: logf.Log.WithName("scalercache"),
+	}
+
+	h := &scaleHandler{
+		logger: logf.Log.WithName("scalehandler"),
+		scalerCaches: map[string]*cache.ScalersCache{
+			"refreshed": refreshedCache,
+			"failing":   failingCache,
+			"other":     otherCache,
+		},
+		lock: &sync.RWMutex{},
+	}
+	h.RefreshScalersUsingAuth(context.TODO(), "TriggerAuthentication", "test", "auth")
+
+	assert.Equal(t, []scalers.Scaler{refreshedScaler}, refreshedCache.GetScalers())
+	assert.Equal(t, []scalers.Scaler{otherScaler}, otherCache.GetScalers())
+	assert.Empty(t, failingCache.GetScalers())
+	assert.NotContains(t, h.scalerCaches, "failing")
+	assert.Contains(t, h.scalerCaches, "refreshed")
+	assert.Contains(t, h.scalerCaches, "other")
+}
+
+func TestUsesAuthRef(t *testing.T) {
+	tests := []struct {
+		name        string
+		authRef     *kedav1alpha1.ScaledObjectAuthRef
+		authRefKind string
+		namespace   string
+		expected    bool
+	}{
+		{name: "no authentication", authRefKind: "TriggerAuthentication", namespace: "test", expected: false},
+		{name: "same namespace", authRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "auth"}, authRefKind: "TriggerAuthentication", namespace: "test", expected: true},
+		{name: "other name", authRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "other"}, authRefKind: "TriggerAuthentication", namespace: "test", expected: false},
+		{name: "other namespace", authRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "auth"}, authRefKind: "TriggerAuthentication", namespace: "other", expected: false},
+		{name: "cross namespace", authRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "auth", Namespace: "credentials"}, authRefKind: "TriggerAuthentication", namespace: "credentials", expected: true},
+		{name: "cluster", authRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "auth", Kind: "ClusterTriggerAuthentication"}, authRefKind: "ClusterTriggerAuthentication", expected: true},
+		{name: "other kind", authRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "auth", Kind: "ClusterTriggerAuthentication"}, authRefKind: "TriggerAuthentication", namespace: "test", expected: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			config := &scalers.ScalerConfig{ScalableObjectNamespace: "test", AuthenticationRef: test.authRef}
+			assert.Equal(t, test.expected, usesAuthRef(config, test.authRefKind, test.namespace, "auth"))
+		})
+	}
+}
+
 func createMetricSpec(averageValue int64) v2beta2.MetricSpec {
 	qty := resource.NewQuantity(averageValue, resource.DecimalSI)
 	return v2beta2.MetricSpec{
@@ -144,3 +239,36 @@ func createMetricSpec(averageValue int64) v2beta2.MetricSpec {
 		},
 	}
 }
+
+func TestDeleteScalableObjectKeepsMetricsOfPausedScaledObject(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "keda.sh/v1alpha1", Kind: "ScaledObject"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "paused", Name: "so"},
+	}
+	withTriggers, err := asDuckWithTriggers(scaledObject)
+	assert.NoError(t, err)
+
+	h := &scaleHandler{
+		logger:            logf.Log.WithName("scalehandler"),
+		scaleLoopContexts: &sync.Map{},
+		scalerCaches:      map[string]*cache.ScalersCache{},
+		lock:              &sync.RWMutex{},
+		recorder:          record.NewFakeRecorder(1),
+		metricsCache:      metricscache.NewMetricsCache(),
+	}
+	_, cancel := context.WithCancel(context.Background())
+	h.scaleLoopContexts.Store(withTriggers.GenerateIdenitifier(), cancel)
+	prommetrics.RecordScalerActive("paused", prommetrics.ScaledObjectType, "so", "cronScaler", 0, true)
+	before, err := testutil.GatherAndCount(ctrlmetrics.Registry, "keda_operator_scaler_active")
+	assert.NoError(t, err)
+
+	// the scale loop of a paused ScaledObject is stopped, the series are only removed once the ScaledObject is finalized
+	assert.NoError(t, h.DeleteScalableObject(context.TODO(), scaledObject))
+
+	after, err := testutil.GatherAndCount(ctrlmetrics.Registry, "keda_operator_scaler_active")
+	assert.NoError(t, err)
+	assert.Equal(t, before, after)
+	_, loaded := h.scaleLoopContexts.Load(withTriggers.GenerateIdenitifier())
+	assert.False(t, loaded)
+	prommetrics.DeleteScalableObjectMetrics("paused", prommetrics.ScaledObjectType, "so")
+}
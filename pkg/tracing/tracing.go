@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/version"
+)
+
+const tracerName = "github.com/kedacore/keda/v2"
+
+// Attributes carried by the spans of scaler calls
+const (
+	NamespaceKey    = attribute.Key("namespace")
+	ScaledObjectKey = attribute.Key("scaledObject")
+	ScaledJobKey    = attribute.Key("scaledJob")
+	TriggerTypeKey  = attribute.Key("triggerType")
+	ScalerIndexKey  = attribute.Key("scalerIndex")
+)
+
+// Config holds the configuration of the OTLP exporter of spans
+type Config struct {
+	// Endpoint of the OTLP gRPC receiver, tracing is disabled if it is empty
+	Endpoint string
+	// Insecure disables TLS for the connection to the OTLP receiver
+	Insecure bool
+	// SamplingRatio is the ratio of traces that are sampled
+	SamplingRatio float64
+}
+
+// InitTracerProvider registers a global tracer provider exporting spans over OTLP, the returned function
+// flushes and stops the export. If no endpoint is configured, spans are not recorded at all.
+func InitTracerProvider(ctx context.Context, serviceName string, config Config) (func(context.Context) error, error) {
+	if config.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if config.SamplingRatio < 0 || config.SamplingRatio > 1 {
+		return nil, fmt.Errorf("sampling ratio must be between 0 and 1, got %v", config.SamplingRatio)
+	}
+
+	options := []otlpgrpc.Option{otlpgrpc.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlpgrpc.WithInsecure())
+	}
+	exporter, err := otlp.NewExporter(ctx, otlpgrpc.NewDriver(options...))
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP exporter: %s", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SamplingRatio))),
+		sdktrace.WithResource(sdkresource.NewWithAttributes(
+			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceVersionKey.String(version.Version),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tracerProvider.Shutdown, nil
+}
+
+// StartSpan starts a span of KEDA tracer with the attributes
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan records the error on the span, if there is any, and ends the span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ScalableObjectAttributes returns the attributes identifying a ScaledObject or ScaledJob
+func ScalableObjectAttributes(namespace string, scalableObjectType string, name string) []attribute.KeyValue {
+	if scalableObjectType == "ScaledJob" {
+		return []attribute.KeyValue{NamespaceKey.String(namespace), ScaledJobKey.String(name)}
+	}
+	return []attribute.KeyValue{NamespaceKey.String(namespace), ScaledObjectKey.String(name)}
+}
+
+// ScalerAttributes returns the attributes identifying the trigger of a scaler
+func ScalerAttributes(config *scalers.ScalerConfig) []attribute.KeyValue {
+	return append(ScalableObjectAttributes(config.ScalableObjectNamespace, config.ScalableObjectType, config.ScalableObjectName),
+		TriggerTypeKey.String(config.TriggerType),
+		ScalerIndexKey.Int(config.ScalerIndex),
+	)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+func TestInitTracerProviderWithoutEndpoint(t *testing.T) {
+	shutdown, err := InitTracerProvider(context.TODO(), "keda-operator", Config{SamplingRatio: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.TODO()))
+
+	_, span := StartSpan(context.TODO(), "test")
+	assert.False(t, span.IsRecording())
+	EndSpan(span, nil)
+}
+
+func TestInitTracerProviderInvalidSamplingRatio(t *testing.T) {
+	_, err := InitTracerProvider(context.TODO(), "keda-operator", Config{Endpoint: "localhost:4317", SamplingRatio: 1.5})
+	assert.Error(t, err)
+}
+
+func TestScalerAttributes(t *testing.T) {
+	config := &scalers.ScalerConfig{
+		ScalableObjectName:      "sj",
+		ScalableObjectNamespace: "test",
+		ScalableObjectType:      "ScaledJob",
+		TriggerType:             "prometheus",
+		ScalerIndex:             1,
+	}
+	attributes := ScalerAttributes(config)
+	assert.Contains(t, attributes, ScaledJobKey.String("sj"))
+	assert.Contains(t, attributes, NamespaceKey.String("test"))
+	assert.Contains(t, attributes, TriggerTypeKey.String("prometheus"))
+	assert.Contains(t, attributes, ScalerIndexKey.Int(1))
+}
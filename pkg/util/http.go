@@ -20,6 +20,8 @@ import (
 	"crypto/tls"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // HTTPDoer is an interface that matches the Do method on
@@ -31,7 +33,8 @@ type HTTPDoer interface {
 
 // CreateHTTPClient returns a new HTTP client with the timeout set to
 // timeoutMS milliseconds, or 300 milliseconds if timeoutMS <= 0.
-// unsafeSsl parameter allows to avoid tls cert validation if it's required
+// unsafeSsl parameter allows to avoid tls cert validation if it's required.
+// Requests made by the client are traced with OpenTelemetry.
 func CreateHTTPClient(timeout time.Duration, unsafeSsl bool) *http.Client {
 	// default the timeout to 300ms
 	if timeout <= 0 {
@@ -39,10 +42,10 @@ func CreateHTTPClient(timeout time.Duration, unsafeSsl bool) *http.Client {
 	}
 	httpClient := &http.Client{
 		Timeout: timeout,
-		Transport: &http.Transport{
+		Transport: otelhttp.NewTransport(&http.Transport{
 			TLSClientConfig: &tls.Config{InsecureSkipVerify: unsafeSsl},
 			Proxy:           http.ProxyFromEnvironment,
-		},
+		}),
 	}
 
 	return httpClient
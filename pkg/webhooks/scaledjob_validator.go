@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+)
+
+type scaledJobValidator struct {
+	client            client.Client
+	decoder           *admission.Decoder
+	globalHTTPTimeout time.Duration
+}
+
+// Handle validates ScaledJob on create and update
+func (v *scaledJobValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	scaledJob := &kedav1alpha1.ScaledJob{}
+	if err := v.decoder.Decode(req, scaledJob); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// updates of metadata (eg. finalizers added by KEDA Operator) don't need to be validated again
+	if req.Operation == admissionv1.Update {
+		oldScaledJob := &kedav1alpha1.ScaledJob{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldScaledJob); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if equality.Semantic.DeepEqual(oldScaledJob.Spec, scaledJob.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	if err := v.validate(ctx, scaledJob); err != nil {
+		log.V(1).Info("ScaledJob rejected", "namespace", scaledJob.Namespace, "name", scaledJob.Name, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func (v *scaledJobValidator) validate(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) error {
+	if scaledJob.Spec.MinReplicaCount != nil && scaledJob.Spec.MaxReplicaCount != nil &&
+		*scaledJob.Spec.MinReplicaCount > *scaledJob.Spec.MaxReplicaCount {
+		return fmt.Errorf("MinReplicaCount=%d must be less than MaxReplicaCount=%d", *scaledJob.Spec.MinReplicaCount, *scaledJob.Spec.MaxReplicaCount)
+	}
+
+	for i, trigger := range scaledJob.Spec.Triggers {
+		if trigger.UseCachedMetrics {
+			return fmt.Errorf("useCachedMetrics is not supported for ScaledJob, it's set for trigger %d", i)
+		}
+	}
+
+	return scaling.ValidateTriggers(ctx, v.client, log, scaledJob, v.globalHTTPTimeout)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type scaledObjectValidator struct {
+	client            client.Client
+	restMapper        meta.RESTMapper
+	decoder           *admission.Decoder
+	globalHTTPTimeout time.Duration
+}
+
+// Handle validates ScaledObject on create and update
+func (v *scaledObjectValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	if err := v.decoder.Decode(req, scaledObject); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// updates of metadata (eg. labels or finalizers added by KEDA Operator) don't need to be validated again
+	if req.Operation == admissionv1.Update {
+		oldScaledObject := &kedav1alpha1.ScaledObject{}
+		if err := v.decoder.DecodeRaw(req.OldObject, oldScaledObject); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if equality.Semantic.DeepEqual(oldScaledObject.Spec, scaledObject.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	if err := v.validate(ctx, scaledObject); err != nil {
+		log.V(1).Info("ScaledObject rejected", "namespace", scaledObject.Namespace, "name", scaledObject.Name, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func (v *scaledObjectValidator) validate(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) error {
+	if scaledObject.Spec.ScaleTargetRef == nil || scaledObject.Spec.ScaleTargetRef.Name == "" {
+		return fmt.Errorf("ScaledObject.spec.scaleTargetRef.name is missing")
+	}
+
+	if err := kedav1alpha1.CheckReplicaCountBoundsAreValid(scaledObject); err != nil {
+		return err
+	}
+
+	if err := modifiers.Validate(scaledObject); err != nil {
+		return err
+	}
+
+	gvkr, err := kedautil.ParseGVKR(v.restMapper, scaledObject.Spec.ScaleTargetRef.APIVersion, scaledObject.Spec.ScaleTargetRef.Kind)
+	if err != nil {
+		return fmt.Errorf("ScaledObject.spec.scaleTargetRef is invalid: %s", err)
+	}
+
+	if err := v.verifyScaledObjects(ctx, scaledObject, gvkr); err != nil {
+		return err
+	}
+
+	if err := v.verifyHpas(ctx, scaledObject, gvkr); err != nil {
+		return err
+	}
+
+	// the scale target is resolved through the GVKR stored in status, which is not populated yet on a new ScaledObject
+	scaledObject = scaledObject.DeepCopy()
+	scaledObject.Status.ScaleTargetGVKR = &gvkr
+	return scaling.ValidateTriggers(ctx, v.client, log, scaledObject, v.globalHTTPTimeout)
+}
+
+// verifyScaledObjects checks that the scale target isn't managed by another ScaledObject
+func (v *scaledObjectValidator) verifyScaledObjects(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, gvkr kedav1alpha1.GroupVersionKindResource) error {
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := v.client.List(ctx, scaledObjects, client.InNamespace(scaledObject.Namespace)); err != nil {
+		return err
+	}
+
+	for _, so := range scaledObjects.Items {
+		if so.Name == scaledObject.Name || so.Spec.ScaleTargetRef == nil || so.Spec.ScaleTargetRef.Name != scaledObject.Spec.ScaleTargetRef.Name {
+			continue
+		}
+
+		soGvkr, err := kedautil.ParseGVKR(v.restMapper, so.Spec.ScaleTargetRef.APIVersion, so.Spec.ScaleTargetRef.Kind)
+		if err != nil {
+			continue
+		}
+		if soGvkr.Group == gvkr.Group && soGvkr.Kind == gvkr.Kind {
+			return fmt.Errorf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'", scaledObject.Spec.ScaleTargetRef.Name, gvkr.GroupVersionKind().GroupKind(), so.Name)
+		}
+	}
+
+	return nil
+}
+
+// verifyHpas checks that the scale target isn't already autoscaled by an HPA not managed by this ScaledObject
+func (v *scaledObjectValidator) verifyHpas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, gvkr kedav1alpha1.GroupVersionKindResource) error {
+	hpas := &autoscalingv2beta2.HorizontalPodAutoscalerList{}
+	if err := v.client.List(ctx, hpas, client.InNamespace(scaledObject.Namespace)); err != nil {
+		return err
+	}
+
+	for _, hpa := range hpas.Items {
+		if isOwnedByScaledObject(hpa.OwnerReferences, scaledObject.Name) {
+			continue
+		}
+
+		targetRef := hpa.Spec.ScaleTargetRef
+		if targetRef.Name != scaledObject.Spec.ScaleTargetRef.Name || targetRef.Kind != gvkr.Kind {
+			continue
+		}
+		groupVersion, err := schema.ParseGroupVersion(targetRef.APIVersion)
+		if err != nil || groupVersion.Group != gvkr.Group {
+			continue
+		}
+
+		return fmt.Errorf("the workload '%s' of type '%s' is already autoscaled by the HPA '%s'", scaledObject.Spec.ScaleTargetRef.Name, gvkr.GroupVersionKind().GroupKind(), hpa.Name)
+	}
+
+	return nil
+}
+
+func isOwnedByScaledObject(ownerReferences []metav1.OwnerReference, name string) bool {
+	for _, owner := range ownerReferences {
+		if owner.Kind == "ScaledObject" && owner.Name == name {
+			return true
+		}
+	}
+	return false
+}
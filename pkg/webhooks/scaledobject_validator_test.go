@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const namespace = "test"
+
+type scaledObjectValidatorTestData struct {
+	name         string
+	scaledObject *kedav1alpha1.ScaledObject
+	isError      bool
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func newScaledObject(name, target string, triggers ...kedav1alpha1.ScaleTriggers) *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: target},
+			Triggers:       triggers,
+		},
+	}
+}
+
+var cpuTrigger = kedav1alpha1.ScaleTriggers{Type: "cpu", MetricType: autoscalingv2beta2.UtilizationMetricType, Metadata: map[string]string{"value": "50"}}
+
+var scaledObjectValidatorTestDataset = []scaledObjectValidatorTestData{
+	{
+		name:         "valid ScaledObject",
+		scaledObject: newScaledObject("so", "app", cpuTrigger),
+	},
+	{
+		name:         "missing scale target",
+		scaledObject: newScaledObject("so", "", cpuTrigger),
+		isError:      true,
+	},
+	{
+		name: "min greater than max",
+		scaledObject: func() *kedav1alpha1.ScaledObject {
+			so := newScaledObject("so", "app", cpuTrigger)
+			so.Spec.MinReplicaCount = int32Ptr(10)
+			so.Spec.MaxReplicaCount = int32Ptr(5)
+			return so
+		}(),
+		isError: true,
+	},
+	{
+		name:         "target managed by another ScaledObject",
+		scaledObject: newScaledObject("so", "taken", cpuTrigger),
+		isError:      true,
+	},
+	{
+		name:         "updating ScaledObject managing the target",
+		scaledObject: newScaledObject("other", "taken", cpuTrigger),
+	},
+	{
+		name:         "target autoscaled by user managed HPA",
+		scaledObject: newScaledObject("so", "autoscaled", cpuTrigger),
+		isError:      true,
+	},
+	{
+		name:         "target autoscaled by HPA owned by the ScaledObject",
+		scaledObject: newScaledObject("keda-managed", "keda-autoscaled", cpuTrigger),
+	},
+	{
+		name:         "unparsable trigger metadata",
+		scaledObject: newScaledObject("so", "app", kedav1alpha1.ScaleTriggers{Type: "cpu", MetricType: autoscalingv2beta2.UtilizationMetricType, Metadata: map[string]string{"value": "fifty"}}),
+		isError:      true,
+	},
+	{
+		name:         "unknown trigger type",
+		scaledObject: newScaledObject("so", "app", kedav1alpha1.ScaleTriggers{Type: "unknown"}),
+		isError:      true,
+	},
+	{
+		name: "namespace set on a reference to ClusterTriggerAuthentication",
+		scaledObject: newScaledObject("so", "app", kedav1alpha1.ScaleTriggers{Type: "cpu", MetricType: autoscalingv2beta2.UtilizationMetricType, Metadata: map[string]string{"value": "50"},
+			AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "auth", Kind: "ClusterTriggerAuthentication", Namespace: "credentials"}}),
+		isError: true,
+	},
+	{
+		name:         "triggers are not validated when target doesn't exist",
+		scaledObject: newScaledObject("so", "missing", kedav1alpha1.ScaleTriggers{Type: "unknown"}),
+	},
+}
+
+func TestScaledObjectValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = kedav1alpha1.AddToScheme(scheme)
+
+	objects := []runtime.Object{
+		newDeployment("app"),
+		newDeployment("taken"),
+		newDeployment("autoscaled"),
+		newDeployment("keda-autoscaled"),
+		newScaledObject("other", "taken", cpuTrigger),
+		newHpa("user-hpa", "autoscaled", nil),
+		newHpa("keda-hpa-keda-managed", "keda-autoscaled", []metav1.OwnerReference{{Kind: "ScaledObject", Name: "keda-managed"}}),
+	}
+	validator := &scaledObjectValidator{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build(),
+	}
+
+	for _, testData := range scaledObjectValidatorTestDataset {
+		err := validator.validate(context.TODO(), testData.scaledObject)
+		if err != nil && !testData.isError {
+			t.Errorf("Test %q: expected success but got error %s", testData.name, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Test %q: expected error but got success", testData.name)
+		}
+	}
+}
+
+func newDeployment(name string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+}
+
+func newHpa(name, target string, ownerReferences []metav1.OwnerReference) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	return &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, OwnerReferences: ownerReferences},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: target},
+			MaxReplicas:    10,
+		},
+	}
+}
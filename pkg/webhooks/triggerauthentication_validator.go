@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+type triggerAuthenticationValidator struct {
+	decoder   *admission.Decoder
+	isCluster bool
+}
+
+// Handle validates TriggerAuthentication and ClusterTriggerAuthentication on create and update
+func (v *triggerAuthenticationValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	var spec kedav1alpha1.TriggerAuthenticationSpec
+	kind := "TriggerAuthentication"
+	if v.isCluster {
+		kind = "ClusterTriggerAuthentication"
+		triggerAuth := &kedav1alpha1.ClusterTriggerAuthentication{}
+		if err := v.decoder.Decode(req, triggerAuth); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		spec = triggerAuth.Spec
+	} else {
+		triggerAuth := &kedav1alpha1.TriggerAuthentication{}
+		if err := v.decoder.Decode(req, triggerAuth); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		spec = triggerAuth.Spec
+	}
+
+	if err := validateTriggerAuthenticationSpec(&spec); err != nil {
+		log.V(1).Info(fmt.Sprintf("%s rejected", kind), "namespace", req.Namespace, "name", req.Name, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func validateTriggerAuthenticationSpec(spec *kedav1alpha1.TriggerAuthenticationSpec) error {
+	if spec.PodIdentity != nil {
+		switch spec.PodIdentity.Provider {
+		case kedav1alpha1.PodIdentityProviderNone, kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload,
+			kedav1alpha1.PodIdentityProviderGCP, kedav1alpha1.PodIdentityProviderSpiffe, kedav1alpha1.PodIdentityProviderAwsEKS,
+			kedav1alpha1.PodIdentityProviderAwsKiam:
+		default:
+			return fmt.Errorf("podIdentity.provider %s is not supported", spec.PodIdentity.Provider)
+		}
+	}
+
+	for i, secretRef := range spec.SecretTargetRef {
+		if secretRef.Parameter == "" || secretRef.Name == "" || secretRef.Key == "" {
+			return fmt.Errorf("secretTargetRef[%d] must specify parameter, name and key", i)
+		}
+	}
+
+	for i, env := range spec.Env {
+		if env.Parameter == "" || env.Name == "" {
+			return fmt.Errorf("env[%d] must specify parameter and name", i)
+		}
+	}
+
+	if vault := spec.HashiCorpVault; vault != nil {
+		if vault.Address == "" {
+			return fmt.Errorf("hashiCorpVault.address is missing")
+		}
+		switch vault.Authentication {
+		case kedav1alpha1.VaultAuthenticationToken:
+		case kedav1alpha1.VaultAuthenticationKubernetes:
+			if vault.Mount == "" || vault.Role == "" || vault.Credential == nil || vault.Credential.ServiceAccount == "" {
+				return fmt.Errorf("hashiCorpVault with kubernetes authentication must specify mount, role and credential.serviceAccount")
+			}
+		case kedav1alpha1.VaultAuthenticationAppRole:
+			if vault.Mount == "" || vault.Credential == nil || vault.Credential.RoleID == "" || vault.Credential.SecretID == nil {
+				return fmt.Errorf("hashiCorpVault with approle authentication must specify mount, credential.roleId and credential.secretId")
+			}
+		case kedav1alpha1.VaultAuthenticationJWT:
+			if vault.Mount == "" || vault.Role == "" || vault.Credential == nil || (vault.Credential.JWT == nil && vault.Credential.ServiceAccount == "") {
+				return fmt.Errorf("hashiCorpVault with jwt authentication must specify mount, role and credential.jwt or credential.serviceAccount")
+			}
+		default:
+			return fmt.Errorf("hashiCorpVault.authentication %s is not supported", vault.Authentication)
+		}
+		for i, secret := range vault.Secrets {
+			if secret.Parameter == "" || secret.Path == "" || secret.Key == "" {
+				return fmt.Errorf("hashiCorpVault.secrets[%d] must specify parameter, path and key", i)
+			}
+			switch secret.Type {
+			case "", kedav1alpha1.VaultSecretTypeSecretV2, kedav1alpha1.VaultSecretTypeSecret:
+			case kedav1alpha1.VaultSecretTypePki:
+				if secret.PkiData == nil || secret.PkiData.CommonName == "" {
+					return fmt.Errorf("hashiCorpVault.secrets[%d] of type pki must specify pkiData.commonName", i)
+				}
+			default:
+				return fmt.Errorf("hashiCorpVault.secrets[%d].type %s is not supported", i, secret.Type)
+			}
+		}
+	}
+
+	if keyVault := spec.AzureKeyVault; keyVault != nil {
+		if keyVault.VaultURI == "" {
+			return fmt.Errorf("azureKeyVault.vaultUri is missing")
+		}
+		for i, secret := range keyVault.Secrets {
+			if secret.Parameter == "" || secret.Name == "" {
+				return fmt.Errorf("azureKeyVault.secrets[%d] must specify parameter and name", i)
+			}
+		}
+	}
+
+	if secretManager := spec.AwsSecretManager; secretManager != nil {
+		if secretManager.Region == "" {
+			return fmt.Errorf("awsSecretManager.region is missing")
+		}
+		for i, secret := range secretManager.Secrets {
+			if secret.Parameter == "" || secret.Name == "" {
+				return fmt.Errorf("awsSecretManager.secrets[%d] must specify parameter and name", i)
+			}
+		}
+	}
+
+	if secretManager := spec.GcpSecretManager; secretManager != nil {
+		for i, secret := range secretManager.Secrets {
+			if secret.Parameter == "" || secret.ID == "" {
+				return fmt.Errorf("gcpSecretManager.secrets[%d] must specify parameter and id", i)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+type triggerAuthenticationValidatorTestData struct {
+	name    string
+	spec    kedav1alpha1.TriggerAuthenticationSpec
+	isError bool
+}
+
+var triggerAuthenticationValidatorTestDataset = []triggerAuthenticationValidatorTestData{
+	{
+		name: "valid secretTargetRef",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "password", Name: "secret", Key: "password"}}},
+	},
+	{
+		name:    "secretTargetRef without key",
+		spec:    kedav1alpha1.TriggerAuthenticationSpec{SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "password", Name: "secret"}}},
+		isError: true,
+	},
+	{
+		name:    "env without name",
+		spec:    kedav1alpha1.TriggerAuthenticationSpec{Env: []kedav1alpha1.AuthEnvironment{{Parameter: "password"}}},
+		isError: true,
+	},
+	{
+		name: "valid podIdentity",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{PodIdentity: &kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS}},
+	},
+	{
+		name:    "unknown podIdentity provider",
+		spec:    kedav1alpha1.TriggerAuthenticationSpec{PodIdentity: &kedav1alpha1.AuthPodIdentity{Provider: "unknown"}},
+		isError: true,
+	},
+	{
+		name: "valid hashiCorpVault with kubernetes authentication",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+			Address:        "http://vault:8200",
+			Authentication: kedav1alpha1.VaultAuthenticationKubernetes,
+			Mount:          "kubernetes",
+			Role:           "keda",
+			Credential:     &kedav1alpha1.Credential{ServiceAccount: "/var/run/secrets/kubernetes.io/serviceaccount/token"},
+			Secrets:        []kedav1alpha1.VaultSecret{{Parameter: "password", Path: "secret/data/keda", Key: "password"}},
+		}},
+	},
+	{
+		name: "hashiCorpVault with kubernetes authentication without role",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+			Address:        "http://vault:8200",
+			Authentication: kedav1alpha1.VaultAuthenticationKubernetes,
+			Mount:          "kubernetes",
+			Credential:     &kedav1alpha1.Credential{ServiceAccount: "/var/run/secrets/kubernetes.io/serviceaccount/token"},
+		}},
+		isError: true,
+	},
+	{
+		name: "valid hashiCorpVault with approle authentication and dynamic secrets",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+			Address:        "http://vault:8200",
+			Authentication: kedav1alpha1.VaultAuthenticationAppRole,
+			Mount:          "approle",
+			Credential: &kedav1alpha1.Credential{
+				RoleID:   "keda",
+				SecretID: &kedav1alpha1.VaultCredentialValue{ValueFrom: kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "approle", Key: "secretId"}}},
+			},
+			Secrets: []kedav1alpha1.VaultSecret{
+				{Parameter: "password", Path: "database/creds/keda", Key: "password", Type: kedav1alpha1.VaultSecretTypeSecret},
+				{Parameter: "cert", Path: "pki/issue/keda", Key: "certificate", Type: kedav1alpha1.VaultSecretTypePki, PkiData: &kedav1alpha1.VaultPkiData{CommonName: "keda"}},
+			},
+		}},
+	},
+	{
+		name: "hashiCorpVault with approle authentication without secretId",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+			Address:        "http://vault:8200",
+			Authentication: kedav1alpha1.VaultAuthenticationAppRole,
+			Mount:          "approle",
+			Credential:     &kedav1alpha1.Credential{RoleID: "keda"},
+		}},
+		isError: true,
+	},
+	{
+		name: "valid hashiCorpVault with jwt authentication",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+			Address:        "http://vault:8200",
+			Authentication: kedav1alpha1.VaultAuthenticationJWT,
+			Mount:          "jwt",
+			Role:           "keda",
+			Credential:     &kedav1alpha1.Credential{ServiceAccount: "/var/run/secrets/tokens/vault-token"},
+		}},
+	},
+	{
+		name: "hashiCorpVault with jwt authentication without jwt",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+			Address:        "http://vault:8200",
+			Authentication: kedav1alpha1.VaultAuthenticationJWT,
+			Mount:          "jwt",
+			Role:           "keda",
+			Credential:     &kedav1alpha1.Credential{},
+		}},
+		isError: true,
+	},
+	{
+		name: "hashiCorpVault pki secret without pkiData",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+			Address:        "http://vault:8200",
+			Authentication: kedav1alpha1.VaultAuthenticationToken,
+			Secrets:        []kedav1alpha1.VaultSecret{{Parameter: "cert", Path: "pki/issue/keda", Key: "certificate", Type: kedav1alpha1.VaultSecretTypePki}},
+		}},
+		isError: true,
+	},
+	{
+		name: "hashiCorpVault with unknown authentication",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+			Address:        "http://vault:8200",
+			Authentication: "aws",
+		}},
+		isError: true,
+	},
+	{
+		name:    "azureKeyVault without vaultUri",
+		spec:    kedav1alpha1.TriggerAuthenticationSpec{AzureKeyVault: &kedav1alpha1.AzureKeyVault{}},
+		isError: true,
+	},
+	{
+		name: "awsSecretManager",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{AwsSecretManager: &kedav1alpha1.AwsSecretManager{
+			Region:  "eu-west-1",
+			Secrets: []kedav1alpha1.AwsSecretManagerSecret{{Parameter: "password", Name: "db", Key: "password"}},
+		}},
+	},
+	{
+		name:    "awsSecretManager without region",
+		spec:    kedav1alpha1.TriggerAuthenticationSpec{AwsSecretManager: &kedav1alpha1.AwsSecretManager{}},
+		isError: true,
+	},
+	{
+		name: "awsSecretManager secret without name",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{AwsSecretManager: &kedav1alpha1.AwsSecretManager{
+			Region:  "eu-west-1",
+			Secrets: []kedav1alpha1.AwsSecretManagerSecret{{Parameter: "password"}},
+		}},
+		isError: true,
+	},
+	{
+		name: "gcpSecretManager",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{GcpSecretManager: &kedav1alpha1.GcpSecretManager{
+			Secrets: []kedav1alpha1.GcpSecretManagerSecret{{Parameter: "password", ID: "db-password", Version: "2"}},
+		}},
+	},
+	{
+		name: "gcpSecretManager secret without id",
+		spec: kedav1alpha1.TriggerAuthenticationSpec{GcpSecretManager: &kedav1alpha1.GcpSecretManager{
+			Secrets: []kedav1alpha1.GcpSecretManagerSecret{{Parameter: "password"}},
+		}},
+		isError: true,
+	},
+}
+
+func TestValidateTriggerAuthenticationSpec(t *testing.T) {
+	for _, testData := range triggerAuthenticationValidatorTestDataset {
+		spec := testData.spec
+		err := validateTriggerAuthenticationSpec(&spec)
+		if err != nil && !testData.isError {
+			t.Errorf("Test %q: expected success but got error %s", testData.name, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Test %q: expected error but got success", testData.name)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Paths on which the validating admission webhooks are served
+const (
+	ScaledObjectValidationPath                 = "/validate-keda-sh-v1alpha1-scaledobject"
+	ScaledJobValidationPath                    = "/validate-keda-sh-v1alpha1-scaledjob"
+	TriggerAuthenticationValidationPath        = "/validate-keda-sh-v1alpha1-triggerauthentication"
+	ClusterTriggerAuthenticationValidationPath = "/validate-keda-sh-v1alpha1-clustertriggerauthentication"
+)
+
+var log = logf.Log.WithName("webhooks")
+
+// SetupWebhooksWithManager registers validating admission webhooks for KEDA resources on the webhook server of the manager
+func SetupWebhooksWithManager(mgr ctrl.Manager, globalHTTPTimeout time.Duration) error {
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	server := mgr.GetWebhookServer()
+	server.Register(ScaledObjectValidationPath, &webhook.Admission{Handler: &scaledObjectValidator{
+		client:            mgr.GetClient(),
+		restMapper:        mgr.GetRESTMapper(),
+		decoder:           decoder,
+		globalHTTPTimeout: globalHTTPTimeout,
+	}})
+	server.Register(ScaledJobValidationPath, &webhook.Admission{Handler: &scaledJobValidator{
+		client:            mgr.GetClient(),
+		decoder:           decoder,
+		globalHTTPTimeout: globalHTTPTimeout,
+	}})
+	server.Register(TriggerAuthenticationValidationPath, &webhook.Admission{Handler: &triggerAuthenticationValidator{
+		decoder: decoder,
+	}})
+	server.Register(ClusterTriggerAuthenticationValidationPath, &webhook.Admission{Handler: &triggerAuthenticationValidator{
+		decoder:   decoder,
+		isCluster: true,
+	}})
+
+	return nil
+}